@@ -0,0 +1,99 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Command kaibind generates typed Go bindings of contract ABIs on top of kardia.BoundContract.
+//
+//	kaibind -smc KRC20 -pkg kardia -out krc20_bind.go
+//	kaibind -abi Token.abi -bin Token.bin -type Token -pkg token -out token.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/kardiachain/go-kaiclient/kardia/bindgen"
+)
+
+func main() {
+	var (
+		smcName = flag.String("smc", "", "contract of kardia/smc to bind: "+strings.Join(bindgen.SMCContracts(), ", "))
+		abiPath = flag.String("abi", "", "path of the JSON ABI to bind")
+		binPath = flag.String("bin", "", "path of the hex bytecode, generates a Deploy function (optional)")
+		typ     = flag.String("type", "", "name of the binding type, <smc>Contract for -smc")
+		prefix  = flag.String("prefix", "", "prefix of the event and output types, -type by default")
+		pkg     = flag.String("pkg", "", "package of the generated file")
+		out     = flag.String("out", "", "output file, stdout if empty")
+	)
+	flag.Parse()
+	if err := run(*smcName, *abiPath, *binPath, *typ, *prefix, *pkg, *out); err != nil {
+		fmt.Fprintln(os.Stderr, "kaibind:", err)
+		os.Exit(1)
+	}
+}
+
+func run(smcName, abiPath, binPath, typ, prefix, pkg, out string) error {
+	if pkg == "" {
+		return fmt.Errorf("missing -pkg")
+	}
+	var contract bindgen.Contract
+	switch {
+	case smcName != "" && abiPath != "":
+		return fmt.Errorf("-smc and -abi are exclusive")
+	case smcName != "":
+		var err error
+		if contract, err = bindgen.SMCContract(smcName); err != nil {
+			return err
+		}
+	case abiPath != "":
+		data, err := ioutil.ReadFile(abiPath)
+		if err != nil {
+			return err
+		}
+		contract.ABI = string(data)
+		if binPath != "" {
+			bytecode, err := ioutil.ReadFile(binPath)
+			if err != nil {
+				return err
+			}
+			contract.Bytecode = strings.TrimSpace(string(bytecode))
+		}
+	default:
+		return fmt.Errorf("missing -smc or -abi")
+	}
+	if typ != "" {
+		contract.Type = typ
+	}
+	if prefix != "" {
+		contract.Prefix = prefix
+	}
+	if contract.Type == "" {
+		return fmt.Errorf("missing -type")
+	}
+
+	code, err := bindgen.Generate(bindgen.Config{Package: pkg, Contracts: []bindgen.Contract{contract}})
+	if err != nil {
+		return err
+	}
+	if out == "" {
+		_, err = os.Stdout.Write(code)
+		return err
+	}
+	return ioutil.WriteFile(out, code, 0644)
+}
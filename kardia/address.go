@@ -31,34 +31,42 @@ type IAddress interface {
 	NonceAt(ctx context.Context, addressHash string) (uint64, error)
 }
 
+// Balance returns the balance of the given account.
+// The block can be selected with WithBlock, otherwise the balance is taken from the latest known block.
 func (n *node) Balance(ctx context.Context, addressHash string) (string, error) {
 	var (
 		result string
 		err    error
 	)
-	err = n.client.CallContext(ctx, &result, "account_balance", common.HexToAddress(addressHash), "latest")
+	err = n.client.CallContext(ctx, &result, "account_balance", common.HexToAddress(addressHash), BlockFromContext(ctx).arg())
 	return result, err
 }
 
 // StorageAt returns the value of key in the contract storage of the given account.
-// The block number can be nil, in which case the value is taken from the latest known block.
+// The block can be selected with WithBlock, otherwise the value is taken from the latest known block.
 func (n *node) StorageAt(ctx context.Context, addressHash string, key string) ([]byte, error) {
 	var result common.Bytes
-	err := n.client.CallContext(ctx, &result, "account_getStorageAt", common.HexToAddress(addressHash), key, "latest")
+	err := n.client.CallContext(ctx, &result, "account_getStorageAt", common.HexToAddress(addressHash), key, BlockFromContext(ctx).arg())
 	return result, err
 }
 
-// CodeAt returns the contract code of the given account.
-// The block number can be nil, in which case the code is taken from the latest known block.
+// Code returns the contract code of the given account.
+// The block can be selected with WithBlock, otherwise the code is taken from the latest known block.
 func (n *node) Code(ctx context.Context, addressHash string) (common.Bytes, error) {
 	var result common.Bytes
-	err := n.client.CallContext(ctx, &result, "account_getCode", common.HexToAddress(addressHash), "latest")
+	err := n.client.CallContext(ctx, &result, "account_getCode", common.HexToAddress(addressHash), BlockFromContext(ctx).arg())
 	return result, err
 }
 
-// NonceAt returns the account nonce of the given account.
+// NonceAt returns the account nonce of the given account, including pending transactions.
+// If a block is selected with WithBlock, the nonce at that block is returned instead.
 func (n *node) NonceAt(ctx context.Context, account string) (uint64, error) {
 	var result uint64
-	err := n.client.CallContext(ctx, &result, "account_nonce", common.HexToAddress(account))
+	block := BlockFromContext(ctx)
+	if block.IsLatest() {
+		err := n.client.CallContext(ctx, &result, "account_nonce", common.HexToAddress(account))
+		return result, err
+	}
+	err := n.client.CallContext(ctx, &result, "account_nonceAtHeight", common.HexToAddress(account), block.arg())
 	return result, err
 }
@@ -0,0 +1,72 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package kardia
+package kardia
+
+import (
+	"context"
+
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/rpc"
+)
+
+type IBatch interface {
+	// BatchCall sends elems in one JSON-RPC batch request. The error returned by the node for an element
+	// is set in its Error field, the returned error is set for transport errors only.
+	BatchCall(ctx context.Context, elems []rpc.BatchElem) error
+	// BatchKardiaCall executes calls in one JSON-RPC batch request, against the block selected by ctx.
+	// The results are in the order of calls.
+	BatchKardiaCall(ctx context.Context, calls []SMCCallArgs) ([]*CallResult, error)
+}
+
+// CallResult is the result of a call of a batch, Err is a *RevertError if the call is reverted
+type CallResult struct {
+	Data []byte
+	Err  error
+}
+
+func (n *node) BatchCall(ctx context.Context, elems []rpc.BatchElem) error {
+	if len(elems) == 0 {
+		return nil
+	}
+	return n.client.BatchCallContext(ctx, elems)
+}
+
+func (n *node) BatchKardiaCall(ctx context.Context, calls []SMCCallArgs) ([]*CallResult, error) {
+	block := BlockFromContext(ctx).arg()
+	elems := make([]rpc.BatchElem, len(calls))
+	for i := range calls {
+		elems[i] = rpc.BatchElem{
+			Method: "kai_kardiaCall",
+			Args:   []interface{}{calls[i], block},
+			Result: new(common.Bytes),
+		}
+	}
+	if err := n.BatchCall(ctx, elems); err != nil {
+		return nil, err
+	}
+	results := make([]*CallResult, len(calls))
+	for i, elem := range elems {
+		if elem.Error != nil {
+			results[i] = &CallResult{Err: n.asRevertError(elem.Error, calls[i], nil)}
+			continue
+		}
+		results[i] = &CallResult{Data: *elem.Result.(*common.Bytes)}
+	}
+	return results, nil
+}
@@ -0,0 +1,95 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package kardia
+package kardia
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/kardiachain/go-kardia/lib/abi"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+// testKRC721Calls returns calls of the test KRC721 token at address, the third one reverts
+func testKRC721Calls(t *testing.T, krc721ABI *abi.ABI, address, owner common.Address) []SMCCallArgs {
+	var calls []SMCCallArgs
+	for _, args := range [][]interface{}{
+		{"totalSupply"},
+		{"ownerOf", big.NewInt(1)},
+		{"ownerOf", big.NewInt(9)},
+		{"balanceOf", owner},
+	} {
+		payload, err := krc721ABI.Pack(args[0].(string), args[1:]...)
+		assert.Nil(t, err)
+		calls = append(calls, ConstructCallArgs(address.Hex(), payload))
+	}
+	return calls
+}
+
+func TestNode_BatchKardiaCall(t *testing.T) {
+	node, auth, token, closeFn := setupSimulatedKRC721(t, "1", "2")
+	defer closeFn()
+	ctx := context.Background()
+	calls := testKRC721Calls(t, token.ABI(), token.(*krc721Token).c.ContractAddress, auth.From)
+
+	results, err := node.BatchKardiaCall(ctx, calls)
+	assert.Nil(t, err)
+	assert.Len(t, results, len(calls))
+	for i, result := range results {
+		if i == 2 {
+			var revertErr *RevertError
+			assert.True(t, errors.As(result.Err, &revertErr))
+			assert.Equal(t, "KRC721: nonexistent token", revertErr.Reason)
+			continue
+		}
+		assert.Nil(t, result.Err)
+		data, err := node.KardiaCall(ctx, calls[i])
+		assert.Nil(t, err)
+		assert.Equal(t, data, result.Data)
+	}
+	var owner common.Address
+	assert.Nil(t, token.ABI().UnpackIntoInterface(&owner, "ownerOf", results[1].Data))
+	assert.Equal(t, auth.From, owner)
+
+	results, err = node.BatchKardiaCall(ctx, nil)
+	assert.Nil(t, err)
+	assert.Len(t, results, 0)
+}
+
+func TestNode_BatchCall(t *testing.T) {
+	b, node, _ := setupSimulatedNode(t)
+	defer b.Close()
+	ctx := context.Background()
+
+	var height uint64
+	elems := []rpc.BatchElem{
+		{Method: "kai_blockNumber", Result: &height},
+		{Method: "kai_unknownMethod", Result: new(interface{})},
+	}
+	assert.Nil(t, node.BatchCall(ctx, elems))
+	latest, err := node.LatestBlockNumber(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, latest, height)
+	assert.Nil(t, elems[0].Error)
+	assert.NotNil(t, elems[1].Error)
+}
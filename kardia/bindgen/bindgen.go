@@ -0,0 +1,343 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package bindgen generates typed Go bindings of contract ABIs on top of kardia.BoundContract,
+// with call, transact, filter, watch and parse methods for every method and event of the ABI.
+package bindgen
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/kardiachain/go-kardia/lib/abi"
+
+	"github.com/kardiachain/go-kaiclient/kardia/smc"
+)
+
+var ErrNoContract = errors.New("bindgen: no contract to bind")
+
+// Contract is a contract to generate the binding of
+type Contract struct {
+	// Type is the name of the binding type, e.g. KRC20Contract
+	Type string
+	// Prefix names the event, iterator and output types of the binding, Type by default
+	Prefix string
+	// ABI is the JSON ABI of the contract
+	ABI string
+	// ABIRef is the Go expression of the ABI used by the binding at runtime, e.g. smc.KRC20ABI.
+	// The ABI is embedded as the <Type>ABI constant when empty.
+	ABIRef string
+	// Bytecode, or the Go expression BytecodeRef, is the hex bytecode of the contract.
+	// A Deploy<Type> function is generated when either is set.
+	Bytecode    string
+	BytecodeRef string
+}
+
+// Config is a set of contracts bound in one Go file
+type Config struct {
+	// Package is the package of the generated file, bindings generated into package kardia
+	// refer to its types without qualifier
+	Package   string
+	Contracts []Contract
+}
+
+// smcContracts are the contracts of kardia/smc, by name
+var smcContracts = map[string]Contract{
+	"Params":    {ABI: smc.ParamsABI, ABIRef: "smc.ParamsABI"},
+	"Staking":   {ABI: smc.StakingABI, ABIRef: "smc.StakingABI"},
+	"Validator": {ABI: smc.ValidatorABI, ABIRef: "smc.ValidatorABI"},
+	"KRC20":     {ABI: smc.KRC20ABI, ABIRef: "smc.KRC20ABI", BytecodeRef: "smc.KRC20Bytecode"},
+	"KRC721":    {ABI: smc.KRC721ABI, ABIRef: "smc.KRC721ABI", BytecodeRef: "smc.KRC721Bytecode"},
+	"KRC1155":   {ABI: smc.KRC1155ABI, ABIRef: "smc.KRC1155ABI", BytecodeRef: "smc.KRC1155Bytecode"},
+}
+
+// SMCContract returns the contract of kardia/smc named name, bound as <name>Contract
+func SMCContract(name string) (Contract, error) {
+	c, ok := smcContracts[name]
+	if !ok {
+		return Contract{}, fmt.Errorf("bindgen: unknown smc contract %q, expected one of %s", name, strings.Join(SMCContracts(), ", "))
+	}
+	c.Type, c.Prefix = name+"Contract", name
+	return c, nil
+}
+
+// SMCContracts returns the names of the contracts of kardia/smc
+func SMCContracts() []string {
+	var names []string
+	for name := range smcContracts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Generate returns the formatted Go source of the bindings of cfg
+func Generate(cfg Config) ([]byte, error) {
+	if len(cfg.Contracts) == 0 {
+		return nil, ErrNoContract
+	}
+	data := &tmplData{Package: cfg.Package}
+	if cfg.Package != "kardia" {
+		data.Kardia = "kardia."
+	}
+	for _, c := range cfg.Contracts {
+		contract, err := newTmplContract(c)
+		if err != nil {
+			return nil, fmt.Errorf("bindgen: %s: %w", c.Type, err)
+		}
+		if strings.HasPrefix(c.ABIRef, "smc.") || strings.HasPrefix(c.BytecodeRef, "smc.") {
+			data.SMC = true
+		}
+		data.Contracts = append(data.Contracts, contract)
+	}
+
+	var buf bytes.Buffer
+	if err := bindTemplate.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	code, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("bindgen: invalid generated code: %w\n%s", err, buf.String())
+	}
+	return code, nil
+}
+
+type tmplData struct {
+	Package   string
+	Kardia    string
+	SMC       bool
+	Contracts []*tmplContract
+}
+
+type tmplContract struct {
+	Type        string
+	Prefix      string
+	ABI         string
+	ABIRef      string
+	Bytecode    string
+	BytecodeRef string
+	Constructor []tmplArg
+	Calls       []*tmplMethod
+	Transacts   []*tmplMethod
+	Events      []*tmplEvent
+}
+
+type tmplMethod struct {
+	Name      string
+	RawName   string
+	Signature string
+	Inputs    []tmplArg
+	Outputs   []tmplArg
+}
+
+type tmplEvent struct {
+	Name      string
+	RawName   string
+	Signature string
+	Fields    []tmplArg
+	Indexed   []tmplArg
+}
+
+type tmplArg struct {
+	Name  string
+	Field string
+	Type  string
+}
+
+func newTmplContract(c Contract) (*tmplContract, error) {
+	if c.Type == "" {
+		return nil, errors.New("missing binding type name")
+	}
+	parsed, err := abi.JSON(strings.NewReader(c.ABI))
+	if err != nil {
+		return nil, err
+	}
+	contract := &tmplContract{
+		Type:        c.Type,
+		Prefix:      c.Prefix,
+		ABIRef:      c.ABIRef,
+		Bytecode:    strings.TrimPrefix(c.Bytecode, "0x"),
+		BytecodeRef: c.BytecodeRef,
+		Constructor: inputArgs(parsed.Constructor.Inputs),
+	}
+	if contract.Prefix == "" {
+		contract.Prefix = c.Type
+	}
+	if contract.ABIRef == "" {
+		// compact the embedded ABI
+		var buf bytes.Buffer
+		for _, line := range strings.Split(c.ABI, "\n") {
+			buf.WriteString(strings.TrimSpace(line))
+		}
+		contract.ABI = buf.String()
+		contract.ABIRef = c.Type + "ABI"
+	}
+	if contract.BytecodeRef == "" && contract.Bytecode != "" {
+		contract.BytecodeRef = c.Type + "Bytecode"
+	}
+
+	for _, name := range sortedKeys(parsed.Methods) {
+		method := parsed.Methods[name]
+		m := &tmplMethod{
+			Name:      abi.ToCamelCase(method.Name),
+			RawName:   method.Name,
+			Signature: method.String(),
+			Inputs:    inputArgs(method.Inputs),
+		}
+		if method.IsConstant() {
+			for i, output := range method.Outputs {
+				m.Outputs = append(m.Outputs, tmplArg{Field: fieldName(output.Name, i), Type: goType(output.Type)})
+			}
+			contract.Calls = append(contract.Calls, m)
+		} else {
+			contract.Transacts = append(contract.Transacts, m)
+		}
+	}
+	for _, name := range sortedKeys(parsed.Events) {
+		event := parsed.Events[name]
+		e := &tmplEvent{
+			Name:      abi.ToCamelCase(event.Name),
+			RawName:   event.Name,
+			Signature: event.String(),
+		}
+		// unnamed event arguments are named argN by the abi package
+		for i, input := range event.Inputs {
+			arg := tmplArg{Name: paramName(input.Name, i), Field: fieldName(input.Name, i), Type: goType(input.Type)}
+			if input.Indexed {
+				arg.Type = topicType(input.Type)
+				e.Indexed = append(e.Indexed, arg)
+			}
+			e.Fields = append(e.Fields, arg)
+		}
+		contract.Events = append(contract.Events, e)
+	}
+	return contract, nil
+}
+
+func inputArgs(args abi.Arguments) []tmplArg {
+	var inputs []tmplArg
+	for i, arg := range args {
+		inputs = append(inputs, tmplArg{Name: paramName(arg.Name, i), Type: goType(arg.Type)})
+	}
+	return inputs
+}
+
+func sortedKeys(m interface{}) []string {
+	var keys []string
+	switch m := m.(type) {
+	case map[string]abi.Method:
+		for key := range m {
+			keys = append(keys, key)
+		}
+	case map[string]abi.Event:
+		for key := range m {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// reserved are the Go keywords and the identifiers used by the generated code,
+// parameters with these names are renamed
+var reserved = map[string]bool{
+	"break": true, "case": true, "chan": true, "const": true, "continue": true, "default": true,
+	"defer": true, "else": true, "fallthrough": true, "for": true, "func": true, "go": true,
+	"goto": true, "if": true, "import": true, "interface": true, "map": true, "package": true,
+	"range": true, "return": true, "select": true, "struct": true, "switch": true, "type": true,
+	"var": true,
+
+	"abi": true, "bind": true, "big": true, "common": true, "event": true, "kardia": true,
+	"smc": true, "strings": true, "types": true,
+
+	"address": true, "auth": true, "c": true, "err": true, "ev": true, "it": true, "item": true, "log": true,
+	"node": true, "opts": true, "out": true, "parsed": true, "quit": true, "sink": true, "tx": true,
+}
+
+// paramName returns the Go parameter name of the ABI argument name
+func paramName(name string, i int) string {
+	name = abi.ToCamelCase(name)
+	if name == "" {
+		return fmt.Sprintf("arg%d", i)
+	}
+	name = strings.ToLower(name[:1]) + name[1:]
+	if reserved[name] {
+		name += "Arg"
+	}
+	return name
+}
+
+// fieldName returns the exported Go field name of the ABI argument name
+func fieldName(name string, i int) string {
+	name = abi.ToCamelCase(name)
+	if name == "" {
+		return fmt.Sprintf("Arg%d", i)
+	}
+	return name
+}
+
+// goType returns the Go type of values of t, as unpacked by the abi package
+func goType(t abi.Type) string {
+	switch t.T {
+	case abi.IntTy, abi.UintTy:
+		switch t.Size {
+		case 8, 16, 32, 64:
+			if t.T == abi.UintTy {
+				return fmt.Sprintf("uint%d", t.Size)
+			}
+			return fmt.Sprintf("int%d", t.Size)
+		}
+		return "*big.Int"
+	case abi.BoolTy:
+		return "bool"
+	case abi.StringTy:
+		return "string"
+	case abi.AddressTy:
+		return "common.Address"
+	case abi.BytesTy:
+		return "[]byte"
+	case abi.FixedBytesTy:
+		return fmt.Sprintf("[%d]byte", t.Size)
+	case abi.HashTy:
+		return "common.Hash"
+	case abi.FunctionTy:
+		return "[24]byte"
+	case abi.SliceTy:
+		return "[]" + goType(*t.Elem)
+	case abi.ArrayTy:
+		return fmt.Sprintf("[%d]%s", t.Size, goType(*t.Elem))
+	}
+	// tuples are unpacked into anonymous structs, which print as valid Go types
+	return t.GetType().String()
+}
+
+// topicType returns the Go type of an indexed event argument of type t,
+// dynamic types are indexed by their hash
+func topicType(t abi.Type) string {
+	switch t.T {
+	case abi.StringTy, abi.BytesTy, abi.SliceTy, abi.ArrayTy, abi.TupleTy:
+		return "common.Hash"
+	}
+	return goType(t)
+}
+
+var bindTemplate = template.Must(template.New("bind").Parse(tmplSource))
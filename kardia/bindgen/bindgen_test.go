@@ -0,0 +1,102 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package bindgen
+package bindgen
+
+import (
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testABI = `[
+	{"type":"constructor","inputs":[{"name":"owner","type":"address"}]},
+	{"type":"function","name":"get","stateMutability":"view","inputs":[{"name":"type","type":"uint8"},{"name":"","type":"bytes32[]"}],"outputs":[{"name":"value","type":"uint256"},{"name":"","type":"bool"}]},
+	{"type":"function","name":"set","stateMutability":"nonpayable","inputs":[{"name":"_opts","type":"string"}],"outputs":[]},
+	{"type":"event","name":"Set","inputs":[{"name":"key","type":"string","indexed":true},{"name":"value","type":"uint64","indexed":false}]}
+]`
+
+func TestGenerate_UserABI(t *testing.T) {
+	code, err := Generate(Config{Package: "store", Contracts: []Contract{{Type: "Store", ABI: testABI, Bytecode: "0x6080"}}})
+	assert.Nil(t, err)
+	_, err = parser.ParseFile(token.NewFileSet(), "store.go", code, 0)
+	assert.Nil(t, err)
+	src := string(code)
+
+	for _, expected := range []string{
+		`"github.com/kardiachain/go-kaiclient/kardia"`,
+		"const StoreABI = ",
+		`const StoreBytecode = "6080"`,
+		"*kardia.BoundContract",
+		"func NewStore(node kardia.Node, address common.Address) (*Store, error)",
+		"func DeployStore(auth *bind.TransactOpts, node kardia.Node, owner common.Address) (common.Address, *types.Transaction, *Store, error)",
+		// reserved and unnamed parameters are renamed
+		"func (c *Store) Get(opts *bind.CallOpts, typeArg uint8, arg1 [][32]byte) (*StoreGetOutput, error)",
+		"Value *big.Int",
+		"Arg1  bool",
+		"func (c *Store) Set(opts *bind.TransactOpts, optsArg string) (*types.Transaction, error)",
+		// dynamic indexed arguments are filtered by hash
+		"Key   common.Hash",
+		"func (c *Store) FilterSet(opts *bind.FilterOpts, key []common.Hash) (*StoreSetIterator, error)",
+		"func (c *Store) WatchSet(opts *bind.WatchOpts, sink chan<- *StoreSet, key []common.Hash) (event.Subscription, error)",
+		"func (c *Store) ParseSet(log types.Log) (*StoreSet, error)",
+	} {
+		assert.Contains(t, src, expected)
+	}
+	assert.NotContains(t, src, "kardia/smc")
+}
+
+func TestGenerate_UnnamedEventArgs(t *testing.T) {
+	unnamed := `[{"type":"event","name":"Set","inputs":[{"name":"","type":"address","indexed":true},{"name":"","type":"uint64","indexed":false}]}]`
+	code, err := Generate(Config{Package: "store", Contracts: []Contract{{Type: "Store", ABI: unnamed}}})
+	assert.Nil(t, err)
+	assert.Contains(t, string(code), "Arg0 common.Address")
+	assert.Contains(t, string(code), "Arg1 uint64")
+	assert.Contains(t, string(code), "func (c *Store) FilterSet(opts *bind.FilterOpts, arg0 []common.Address) (*StoreSetIterator, error)")
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	_, err := Generate(Config{Package: "store"})
+	assert.Equal(t, ErrNoContract, err)
+
+	_, err = Generate(Config{Package: "store", Contracts: []Contract{{ABI: testABI}}})
+	assert.NotNil(t, err)
+	_, err = Generate(Config{Package: "store", Contracts: []Contract{{Type: "Store", ABI: "{"}}})
+	assert.NotNil(t, err)
+
+	_, err = SMCContract("KRC1")
+	assert.NotNil(t, err)
+}
+
+// TestGenerate_SMCUpToDate checks the bindings generated into package kardia match their ABI
+func TestGenerate_SMCUpToDate(t *testing.T) {
+	for _, name := range SMCContracts() {
+		contract, err := SMCContract(name)
+		assert.Nil(t, err)
+		code, err := Generate(Config{Package: "kardia", Contracts: []Contract{contract}})
+		assert.Nil(t, err)
+		generated, err := ioutil.ReadFile(filepath.Join("..", strings.ToLower(name)+"_bind.go"))
+		assert.Nil(t, err)
+		assert.Equal(t, string(code), string(generated), "run go generate in kardia to update the %s binding", name)
+	}
+}
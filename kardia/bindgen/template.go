@@ -0,0 +1,197 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package bindgen
+package bindgen
+
+// tmplSource is the Go source template of the bindings, formatted by Generate
+const tmplSource = `// Code generated by kaibind. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/kardiachain/go-kardia/lib/abi"
+	"github.com/kardiachain/go-kardia/lib/abi/bind"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/event"
+	"github.com/kardiachain/go-kardia/types"
+{{if .Kardia}}
+	"github.com/kardiachain/go-kaiclient/kardia"
+{{- end}}
+{{- if .SMC}}
+	"github.com/kardiachain/go-kaiclient/kardia/smc"
+{{- end}}
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = abi.JSON
+	_ = bind.NewBoundContract
+	_ = common.Big1
+	_ = event.NewSubscription
+	_ = types.BloomLookup
+)
+{{range $c := .Contracts}}
+{{- if $c.ABI}}
+// {{$c.Type}}ABI is the ABI of {{$c.Type}}.
+const {{$c.Type}}ABI = {{printf "%q" $c.ABI}}
+{{end}}
+{{- if $c.Bytecode}}
+// {{$c.Type}}Bytecode is the bytecode deployed by Deploy{{$c.Type}}.
+const {{$c.Type}}Bytecode = {{printf "%q" $c.Bytecode}}
+{{end}}
+// {{$c.Type}} is a typed binding of the contract, on top of BoundContract.
+type {{$c.Type}} struct {
+	*{{$.Kardia}}BoundContract
+}
+
+// New{{$c.Type}} binds the contract deployed at address.
+func New{{$c.Type}}(node {{$.Kardia}}Node, address common.Address) (*{{$c.Type}}, error) {
+	parsed, err := abi.JSON(strings.NewReader({{$c.ABIRef}}))
+	if err != nil {
+		return nil, err
+	}
+	return &{{$c.Type}}{ {{- $.Kardia}}NewBoundContract(node, &parsed, address)}, nil
+}
+{{if $c.BytecodeRef}}
+// Deploy{{$c.Type}} deploys the contract and binds it.
+func Deploy{{$c.Type}}(auth *bind.TransactOpts, node {{$.Kardia}}Node{{range $c.Constructor}}, {{.Name}} {{.Type}}{{end}}) (common.Address, *types.Transaction, *{{$c.Type}}, error) {
+	parsed, err := abi.JSON(strings.NewReader({{$c.ABIRef}}))
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	address, tx, _, err := bind.DeployContract(auth, parsed, common.FromHex({{$c.BytecodeRef}}), node{{range $c.Constructor}}, {{.Name}}{{end}})
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	c := &{{$c.Type}}{ {{- $.Kardia}}NewBoundContract(node, &parsed, address)}
+	c.BoundContract.Bytecode = {{$c.BytecodeRef}}
+	return address, tx, c, nil
+}
+{{end}}
+{{- range $m := $c.Calls}}
+{{- if gt (len $m.Outputs) 1}}
+// {{$c.Prefix}}{{$m.Name}}Output is the output of {{$c.Type}}.{{$m.Name}}.
+type {{$c.Prefix}}{{$m.Name}}Output struct {
+{{- range $m.Outputs}}
+	{{.Field}} {{.Type}}
+{{- end}}
+}
+{{end}}
+// {{$m.Name}} calls {{$m.Signature}}.
+func (c *{{$c.Type}}) {{$m.Name}}(opts *bind.CallOpts{{range $m.Inputs}}, {{.Name}} {{.Type}}{{end}}) (
+{{- if eq (len $m.Outputs) 0}}error{{else if eq (len $m.Outputs) 1}}{{(index $m.Outputs 0).Type}}, error{{else}}*{{$c.Prefix}}{{$m.Name}}Output, error{{end}}) {
+{{- if eq (len $m.Outputs) 0}}
+	return c.BoundContract.Call(opts, new([]interface{}), "{{$m.RawName}}"{{range $m.Inputs}}, {{.Name}}{{end}})
+{{- else if eq (len $m.Outputs) 1}}
+	var out {{(index $m.Outputs 0).Type}}
+	err := c.BoundContract.Call(opts, &out, "{{$m.RawName}}"{{range $m.Inputs}}, {{.Name}}{{end}})
+	return out, err
+{{- else}}
+	out := make([]interface{}, {{len $m.Outputs}})
+	if err := c.BoundContract.Call(opts, &out, "{{$m.RawName}}"{{range $m.Inputs}}, {{.Name}}{{end}}); err != nil {
+		return nil, err
+	}
+	return &{{$c.Prefix}}{{$m.Name}}Output{
+{{- range $i, $o := $m.Outputs}}
+		{{$o.Field}}: *abi.ConvertType(out[{{$i}}], new({{$o.Type}})).(*{{$o.Type}}),
+{{- end}}
+	}, nil
+{{- end}}
+}
+{{end}}
+{{- range $m := $c.Transacts}}
+// {{$m.Name}} sends a transaction calling {{$m.Signature}}.
+func (c *{{$c.Type}}) {{$m.Name}}(opts *bind.TransactOpts{{range $m.Inputs}}, {{.Name}} {{.Type}}{{end}}) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "{{$m.RawName}}"{{range $m.Inputs}}, {{.Name}}{{end}})
+}
+{{end}}
+{{- range $e := $c.Events}}
+// {{$c.Prefix}}{{$e.Name}} is the {{$e.RawName}} event of {{$c.Type}}.
+type {{$c.Prefix}}{{$e.Name}} struct {
+{{- range $e.Fields}}
+	{{.Field}} {{.Type}}
+{{- end}}
+	Raw types.Log
+}
+
+// {{$c.Prefix}}{{$e.Name}}Iterator iterates over the {{$e.RawName}} events returned by {{$c.Type}}.Filter{{$e.Name}}.
+type {{$c.Prefix}}{{$e.Name}}Iterator struct {
+	// Event is the event the iterator is at
+	Event *{{$c.Prefix}}{{$e.Name}}
+
+	*{{$.Kardia}}EventIterator
+}
+
+// Filter{{$e.Name}} iterates over past logs of {{$e.Signature}}.
+func (c *{{$c.Type}}) Filter{{$e.Name}}(opts *bind.FilterOpts{{range $e.Indexed}}, {{.Name}} []{{.Type}}{{end}}) (*{{$c.Prefix}}{{$e.Name}}Iterator, error) {
+{{- range $e.Indexed}}
+	var {{.Name}}Rule []interface{}
+	for _, item := range {{.Name}} {
+		{{.Name}}Rule = append({{.Name}}Rule, item)
+	}
+{{- end}}
+	it := new({{$c.Prefix}}{{$e.Name}}Iterator)
+	var err error
+	it.EventIterator, err = c.BoundContract.FilterEvent(opts, "{{$e.RawName}}", func(log types.Log) error {
+		ev, err := c.Parse{{$e.Name}}(log)
+		it.Event = ev
+		return err
+	}{{range $e.Indexed}}, {{.Name}}Rule{{end}})
+	if err != nil {
+		return nil, err
+	}
+	return it, nil
+}
+
+// Watch{{$e.Name}} sends new logs of {{$e.Signature}} to sink.
+func (c *{{$c.Type}}) Watch{{$e.Name}}(opts *bind.WatchOpts, sink chan<- *{{$c.Prefix}}{{$e.Name}}{{range $e.Indexed}}, {{.Name}} []{{.Type}}{{end}}) (event.Subscription, error) {
+{{- range $e.Indexed}}
+	var {{.Name}}Rule []interface{}
+	for _, item := range {{.Name}} {
+		{{.Name}}Rule = append({{.Name}}Rule, item)
+	}
+{{- end}}
+	return c.BoundContract.WatchEvent(opts, "{{$e.RawName}}", func(log types.Log, quit <-chan struct{}) error {
+		ev, err := c.Parse{{$e.Name}}(log)
+		if err != nil {
+			return err
+		}
+		select {
+		case sink <- ev:
+		case <-quit:
+		}
+		return nil
+	}{{range $e.Indexed}}, {{.Name}}Rule{{end}})
+}
+
+// Parse{{$e.Name}} unpacks a log of {{$e.Signature}}.
+func (c *{{$c.Type}}) Parse{{$e.Name}}(log types.Log) (*{{$c.Prefix}}{{$e.Name}}, error) {
+	ev := new({{$c.Prefix}}{{$e.Name}})
+	if err := c.BoundContract.UnpackLog(ev, "{{$e.RawName}}", log); err != nil {
+		return nil, err
+	}
+	ev.Raw = log
+	return ev, nil
+}
+{{end}}
+{{- end}}`
@@ -18,12 +18,21 @@
 // Package kardia
 package kardia
 
+//go:generate go run ../cmd/kaibind -smc Params -pkg kardia -out params_bind.go
+//go:generate go run ../cmd/kaibind -smc Staking -pkg kardia -out staking_bind.go
+//go:generate go run ../cmd/kaibind -smc Validator -pkg kardia -out validator_bind.go
+//go:generate go run ../cmd/kaibind -smc KRC20 -pkg kardia -out krc20_bind.go
+//go:generate go run ../cmd/kaibind -smc KRC721 -pkg kardia -out krc721_bind.go
+//go:generate go run ../cmd/kaibind -smc KRC1155 -pkg kardia -out krc1155_bind.go
+
 import (
 	"strings"
 
 	"github.com/kardiachain/go-kardia/lib/abi"
 	"github.com/kardiachain/go-kardia/lib/abi/bind"
 	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/event"
+	"github.com/kardiachain/go-kardia/types"
 
 	"github.com/kardiachain/go-kaiclient/kardia/smc"
 )
@@ -42,7 +51,7 @@ func NewBoundContract(node Node, abi *abi.ABI, addr common.Address) *BoundContra
 		Abi:             abi,
 		ContractAddress: addr,
 		node:            node,
-		BoundContract:   bind.NewBoundContract(addr, *abi, node, node, nil),
+		BoundContract:   bind.NewBoundContract(addr, *abi, node, node, node),
 	}
 
 	return c
@@ -59,3 +68,132 @@ func (n *node) DeployKRC20(auth *bind.TransactOpts) (common.Address, common.Hash
 	}
 	return address, tx.Hash(), nil
 }
+
+// DeployKRC721 deploys the KRC721 test token of smc.KRC721Bytecode, whose mint(address to, uint256 tokenId,
+// string uri) can be called by anyone
+func (n *node) DeployKRC721(auth *bind.TransactOpts) (common.Address, common.Hash, error) {
+	parsed, err := abi.JSON(strings.NewReader(smc.KRC721ABI))
+	if err != nil {
+		return common.Address{}, common.Hash{}, err
+	}
+	address, tx, _, err := bind.DeployContract(auth, parsed, common.FromHex(smc.KRC721Bytecode), n)
+	if err != nil {
+		return common.Address{}, common.Hash{}, err
+	}
+	return address, tx.Hash(), nil
+}
+
+// DeployKRC1155 deploys the KRC1155 test token of smc.KRC1155Bytecode, whose mint(address to, uint256 id,
+// uint256 amount) can be called by anyone
+func (n *node) DeployKRC1155(auth *bind.TransactOpts) (common.Address, common.Hash, error) {
+	parsed, err := abi.JSON(strings.NewReader(smc.KRC1155ABI))
+	if err != nil {
+		return common.Address{}, common.Hash{}, err
+	}
+	address, tx, _, err := bind.DeployContract(auth, parsed, common.FromHex(smc.KRC1155Bytecode), n)
+	if err != nil {
+		return common.Address{}, common.Hash{}, err
+	}
+	return address, tx.Hash(), nil
+}
+
+// DeployMulticall deploys the aggregator of smc.MulticallBytecode, see NewMulticall
+func (n *node) DeployMulticall(auth *bind.TransactOpts) (common.Address, common.Hash, error) {
+	parsed, err := abi.JSON(strings.NewReader(smc.MulticallABI))
+	if err != nil {
+		return common.Address{}, common.Hash{}, err
+	}
+	address, tx, _, err := bind.DeployContract(auth, parsed, common.FromHex(smc.MulticallBytecode), n)
+	if err != nil {
+		return common.Address{}, common.Hash{}, err
+	}
+	return address, tx.Hash(), nil
+}
+
+// EventIterator iterates over the logs of a contract event returned by FilterEvent,
+// typed bindings wrap it to expose the unpacked event.
+type EventIterator struct {
+	logs   chan types.Log
+	sub    event.Subscription
+	unpack func(log types.Log) error
+
+	done bool
+	fail error
+}
+
+// FilterEvent filters past logs of the event name matching query, each log is
+// passed to unpack when the iterator moves to it.
+func (c *BoundContract) FilterEvent(opts *bind.FilterOpts, name string, unpack func(log types.Log) error, query ...[]interface{}) (*EventIterator, error) {
+	logs, sub, err := c.FilterLogs(opts, name, query...)
+	if err != nil {
+		return nil, err
+	}
+	return &EventIterator{logs: logs, sub: sub, unpack: unpack}, nil
+}
+
+// Next advances the iterator to the next log, it returns false when there are no more
+// logs or unpacking failed, see Error.
+func (it *EventIterator) Next() bool {
+	if it.fail != nil {
+		return false
+	}
+	if it.done {
+		select {
+		case log := <-it.logs:
+			return it.next(log)
+		default:
+			return false
+		}
+	}
+	select {
+	case log := <-it.logs:
+		return it.next(log)
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+func (it *EventIterator) next(log types.Log) bool {
+	if err := it.unpack(log); err != nil {
+		it.fail = err
+		return false
+	}
+	return true
+}
+
+// Error returns the error which stopped the iteration
+func (it *EventIterator) Error() error {
+	return it.fail
+}
+
+// Close stops the iteration and releases the resources
+func (it *EventIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// WatchEvent subscribes to new logs of the event name matching query and passes them to
+// deliver until the subscription is closed, then quit is closed.
+func (c *BoundContract) WatchEvent(opts *bind.WatchOpts, name string, deliver func(log types.Log, quit <-chan struct{}) error, query ...[]interface{}) (event.Subscription, error) {
+	logs, sub, err := c.WatchLogs(opts, name, query...)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				if err := deliver(log, quit); err != nil {
+					return err
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
@@ -19,11 +19,15 @@
 package kardia
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"math/big"
 	"testing"
+	"time"
 
+	"github.com/kardiachain/go-kardia/lib/abi/bind"
+	"github.com/kardiachain/go-kardia/lib/common"
 	"github.com/kardiachain/go-kardia/lib/crypto"
 	"github.com/stretchr/testify/assert"
 )
@@ -55,3 +59,82 @@ func TestBoundContract_Deploy(t *testing.T) {
 	fmt.Println("SMC Addr", smcAddress.String())
 	fmt.Println("TxHash", txHash.String())
 }
+
+func TestBoundContract_KRC20Binding(t *testing.T) {
+	b, node, auth := setupSimulatedNode(t)
+	defer b.Close()
+	ctx := context.Background()
+
+	address, tx, krc20, err := DeployKRC20Contract(auth, node)
+	assert.Nil(t, err)
+	_, err = node.WaitMined(ctx, tx.Hash().Hex())
+	assert.Nil(t, err)
+	assert.Equal(t, address, krc20.ContractAddress)
+	symbol, err := krc20.Symbol(nil)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, symbol)
+
+	receiver := common.HexToAddress(test1SmcAddr)
+	transfers := make(chan *KRC20Transfer)
+	sub, err := krc20.WatchTransfer(nil, transfers, nil, []common.Address{receiver})
+	assert.Nil(t, err)
+	defer sub.Unsubscribe()
+
+	tx, err = krc20.Transfer(auth, receiver, big.NewInt(1000))
+	assert.Nil(t, err)
+	receipt, err := node.WaitMined(ctx, tx.Hash().Hex())
+	assert.Nil(t, err)
+	select {
+	case transfer := <-transfers:
+		assert.Equal(t, auth.From, transfer.From)
+		assert.Equal(t, receiver, transfer.To)
+		assert.Equal(t, big.NewInt(1000), transfer.Value)
+		assert.Equal(t, tx.Hash(), transfer.Raw.TxHash)
+	case err := <-sub.Err():
+		t.Fatal(err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no transfer watched")
+	}
+
+	balance, err := krc20.BalanceOf(&bind.CallOpts{Context: ctx}, receiver)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(1000), balance)
+
+	it, err := krc20.FilterTransfer(&bind.FilterOpts{Start: receipt.BlockHeight}, nil, []common.Address{receiver})
+	assert.Nil(t, err)
+	defer it.Close()
+	var count int
+	for it.Next() {
+		count++
+		assert.Equal(t, big.NewInt(1000), it.Event.Value)
+	}
+	assert.Nil(t, it.Error())
+	assert.Equal(t, 1, count)
+}
+
+func TestBoundContract_StakingBindings(t *testing.T) {
+	b, node, _ := setupSimulatedNode(t)
+	defer b.Close()
+
+	staking, err := NewStakingContract(node, common.HexToAddress(StakingContractAddr))
+	assert.Nil(t, err)
+	validators, err := staking.GetAllValidator(nil)
+	assert.Nil(t, err)
+	assert.Len(t, validators, 1)
+	sets, err := staking.GetValidatorSets(nil)
+	assert.Nil(t, err)
+	assert.Len(t, sets.ValidatorAddresses, 1)
+	// voting power is counted in units of 1e10 hydro
+	assert.Equal(t, "1300000000000000", sets.ValidatorPowers[0].String())
+
+	validator, err := NewValidatorContract(node, validators[0])
+	assert.Nil(t, err)
+	commission, err := validator.Commission(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "100000000000000000", commission.Rate.String())
+	assert.Equal(t, "250000000000000000", commission.MaxRate.String())
+	info, err := validator.InforValidator(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "simulated", string(bytes.TrimRight(info.Name[:], "\x00")))
+	assert.Equal(t, FloatToBigInt(13000000, 18), info.Tokens)
+}
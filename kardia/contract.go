@@ -56,6 +56,21 @@ func parseBytesArrayIntoString(v interface{}) interface{} {
 		if value, ok := v.(*big.Int); ok {
 			return value.String()
 		}
+	case reflect.Slice:
+		switch values := v.(type) {
+		case []*big.Int:
+			converted := make([]string, len(values))
+			for i, value := range values {
+				converted[i] = value.String()
+			}
+			return converted
+		case []common.Address:
+			converted := make([]string, len(values))
+			for i, value := range values {
+				converted[i] = common.Bytes(value[:]).String()
+			}
+			return converted
+		}
 	default:
 		return v
 	}
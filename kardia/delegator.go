@@ -19,18 +19,46 @@
 package kardia
 
 import (
+	"bytes"
 	"context"
+	"math/big"
+	"time"
 
+	"github.com/kardiachain/go-kardia/lib/common"
 	"go.uber.org/zap"
 )
 
+// Roles of ValidatorsByDelegator
+const (
+	// ValidatorRoleCandidate is a validator out of the validator set
+	ValidatorRoleCandidate = iota
+	// ValidatorRoleValidator is a validator of the validator set, proposing and signing blocks
+	ValidatorRoleValidator
+)
+
 type IDelegator interface {
 	UnbondedRecords(ctx context.Context, validatorSMCAddress, delegatorAddress string) (*UnbondedRecord, error)
+	// DelegatorPortfolio returns the delegations of delegator to every validator, at the block selected by ctx
+	DelegatorPortfolio(ctx context.Context, delegator common.Address) (*DelegatorPortfolio, error)
+}
+
+// DelegatorPortfolio is the delegations of a delegator with their totals
+type DelegatorPortfolio struct {
+	Delegator common.Address `json:"delegator"`
+	Height    uint64         `json:"height"`
+	// Time is the block time the unbonding entries are matured at
+	Time       time.Time                `json:"time"`
+	Validators []*ValidatorsByDelegator `json:"validators"`
+
+	StakedAmount       *big.Int `json:"stakedAmount"`
+	ClaimableRewards   *big.Int `json:"claimableRewards"`
+	UnbondedAmount     *big.Int `json:"unbondedAmount"`
+	WithdrawableAmount *big.Int `json:"withdrawableAmount"`
 }
 
 func (n *node) UnbondedRecords(ctx context.Context, validatorSMCAddress, delegatorAddress string) (*UnbondedRecord, error) {
 	lgr := n.lgr.With(zap.String("method", "UnbondedRecords"))
-	payload, err := n.validatorSMC.Abi.Pack("getUBDEntries", delegatorAddress)
+	payload, err := n.validatorSMC.Abi.Pack("getUBDEntries", common.HexToAddress(delegatorAddress))
 	if err != nil {
 		lgr.Error("Error packing UDB entry payload: ", zap.Error(err))
 		return nil, err
@@ -44,7 +72,7 @@ func (n *node) UnbondedRecords(ctx context.Context, validatorSMCAddress, delegat
 		return nil, ErrEmptyList
 	}
 
-	var result *UnbondedRecord
+	var result UnbondedRecord
 	// unpack result
 	err = n.validatorSMC.Abi.UnpackIntoInterface(&result, "getUBDEntries", res)
 	if err != nil {
@@ -52,5 +80,117 @@ func (n *node) UnbondedRecords(ctx context.Context, validatorSMCAddress, delegat
 		return nil, err
 	}
 
-	return result, nil
+	return &result, nil
+}
+
+func (n *node) DelegatorPortfolio(ctx context.Context, delegator common.Address) (*DelegatorPortfolio, error) {
+	lgr := n.lgr.With(zap.String("method", "DelegatorPortfolio"))
+	header, err := n.selectedHeader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// every read is pinned to the header, the latest block may change meanwhile
+	if header.Height != 0 {
+		ctx = WithBlock(ctx, BlockAtHeight(header.Height))
+	}
+	payload, err := n.stakingSMC.Abi.Pack("getValidatorsByDelegator", delegator)
+	if err != nil {
+		return nil, err
+	}
+	res, err := n.KardiaCall(ctx, ConstructCallArgs(n.stakingSMC.ContractAddress.Hex(), payload))
+	if err != nil {
+		lgr.Error("GetValidatorsByDelegator KardiaCall error: ", zap.Error(err))
+		return nil, err
+	}
+	var validatorSMCAddresses []common.Address
+	if err := n.stakingSMC.Abi.UnpackIntoInterface(&validatorSMCAddresses, "getValidatorsByDelegator", res); err != nil {
+		return nil, err
+	}
+	signers, err := n.ValidatorSets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	inSet := make(map[common.Address]bool, len(signers))
+	for _, signer := range signers {
+		inSet[signer] = true
+	}
+
+	// the info of every validator and the delegation to it are loaded in one batch
+	methods := []string{"inforValidator", "getDelegatorStake", "getDelegationRewards", "getUBDEntries"}
+	var calls []SMCCallArgs
+	for _, smcAddr := range validatorSMCAddresses {
+		for _, method := range methods {
+			var args []interface{}
+			if method != "inforValidator" {
+				args = append(args, delegator)
+			}
+			payload, err := n.validatorSMC.Abi.Pack(method, args...)
+			if err != nil {
+				return nil, err
+			}
+			calls = append(calls, ConstructCallArgs(smcAddr.Hex(), payload))
+		}
+	}
+	results, err := n.BatchKardiaCall(ctx, calls)
+	if err != nil {
+		lgr.Error("DelegatorPortfolio batch call error: ", zap.Error(err))
+		return nil, err
+	}
+	portfolio := &DelegatorPortfolio{
+		Delegator:          delegator,
+		Height:             header.Height,
+		Time:               header.Time,
+		StakedAmount:       new(big.Int),
+		ClaimableRewards:   new(big.Int),
+		UnbondedAmount:     new(big.Int),
+		WithdrawableAmount: new(big.Int),
+	}
+	for i, smcAddr := range validatorSMCAddresses {
+		var (
+			info    Validator
+			stake   *big.Int
+			rewards *big.Int
+			record  UnbondedRecord
+		)
+		for j, out := range []interface{}{&info, &stake, &rewards, &record} {
+			result := results[i*len(methods)+j]
+			if result.Err != nil {
+				return nil, result.Err
+			}
+			if err := n.validatorSMC.Abi.UnpackIntoInterface(out, methods[j], result.Data); err != nil {
+				lgr.Error("Error unpacking delegation", zap.String("method", methods[j]), zap.Error(err))
+				return nil, err
+			}
+		}
+		matured, pending := record.Split(header.Time)
+		withdrawable, unbonded := matured.Total(), pending.Total()
+		delegation := &ValidatorsByDelegator{
+			Name:                    string(bytes.TrimRight(info.Name[:], "\x00")),
+			Validator:               info.Signer,
+			ValidatorContractAddr:   smcAddr,
+			ValidatorStatus:         info.Status,
+			ValidatorRole:           ValidatorRoleCandidate,
+			StakedAmount:            stake.String(),
+			ClaimableRewards:        rewards.String(),
+			TotalWithdrawableAmount: new(big.Int).Add(withdrawable, rewards).String(),
+			TotalUnbondedAmount:     new(big.Int).Add(withdrawable, unbonded).String(),
+			UnbondedAmount:          unbonded.String(),
+			WithdrawableAmount:      withdrawable.String(),
+		}
+		if inSet[info.Signer] {
+			delegation.ValidatorRole = ValidatorRoleValidator
+		}
+		if len(pending.Balances) > 0 {
+			delegation.UnbondedRecords = []*UnbondedRecord{pending}
+		}
+		if len(matured.Balances) > 0 {
+			delegation.WithdrawableRecords = []*UnbondedRecord{matured}
+		}
+		portfolio.Validators = append(portfolio.Validators, delegation)
+		portfolio.StakedAmount.Add(portfolio.StakedAmount, stake)
+		portfolio.ClaimableRewards.Add(portfolio.ClaimableRewards, rewards)
+		portfolio.UnbondedAmount.Add(portfolio.UnbondedAmount, unbonded)
+		portfolio.WithdrawableAmount.Add(portfolio.WithdrawableAmount, withdrawable)
+	}
+	return portfolio, nil
 }
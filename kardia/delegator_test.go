@@ -0,0 +1,122 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package kardia
+package kardia
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnbondedRecord_Split(t *testing.T) {
+	now := time.Unix(1000, 0)
+	record := &UnbondedRecord{
+		Balances:        []*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(4)},
+		CompletionTimes: []*big.Int{big.NewInt(999), big.NewInt(1000), big.NewInt(1001)},
+	}
+	matured, pending := record.Split(now)
+	assert.Equal(t, []*big.Int{big.NewInt(1)}, matured.Balances)
+	assert.Equal(t, []*big.Int{big.NewInt(999)}, matured.CompletionTimes)
+	// the entry completed at the block time is withdrawn by the next block
+	assert.Equal(t, []*big.Int{big.NewInt(1000), big.NewInt(1001)}, pending.CompletionTimes)
+	assert.Equal(t, big.NewInt(6), pending.Total())
+	assert.Equal(t, big.NewInt(0), new(UnbondedRecord).Total())
+}
+
+func TestNode_DelegatorPortfolio(t *testing.T) {
+	b, node, _, delegator, validator := setupSimulatedStaking(t)
+	defer b.Close()
+	ctx := context.Background()
+
+	portfolio, err := node.DelegatorPortfolio(ctx, delegator.From)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(portfolio.Validators))
+	assert.Equal(t, 0, portfolio.StakedAmount.Sign())
+
+	candidate, _, err := node.CreateValidator(ctx, delegator, CreateValidatorArgs{
+		Name:           "candidate",
+		Rate:           FloatToBigInt(0.1, 18),
+		MaxRate:        FloatToBigInt(0.2, 18),
+		MaxChangeRate:  FloatToBigInt(0.01, 18),
+		SelfDelegation: FloatToBigInt(12600000, 18),
+	})
+	assert.Nil(t, err)
+	_, err = node.Delegate(ctx, delegator, validator, FloatToBigInt(60000, 18))
+	assert.Nil(t, err)
+	receipt, err := node.Undelegate(ctx, delegator, validator, FloatToBigInt(10000, 18))
+	assert.Nil(t, err)
+	matured := receipt.Undelegates[0].Amount
+	assert.Nil(t, b.AdjustTime(8*24*time.Hour))
+	_, err = b.Commit()
+	assert.Nil(t, err)
+	receipt, err = node.Undelegate(ctx, delegator, validator, FloatToBigInt(10000, 18))
+	assert.Nil(t, err)
+	pending := receipt.Undelegates[0]
+
+	portfolio, err = node.DelegatorPortfolio(ctx, delegator.From)
+	assert.Nil(t, err)
+	assert.Equal(t, delegator.From, portfolio.Delegator)
+	assert.Equal(t, 2, len(portfolio.Validators))
+	byAddress := make(map[string]*ValidatorsByDelegator)
+	for _, v := range portfolio.Validators {
+		byAddress[v.ValidatorContractAddr.Hex()] = v
+	}
+
+	own := byAddress[candidate.Hex()]
+	assert.Equal(t, "candidate", own.Name)
+	assert.Equal(t, delegator.From, own.Validator)
+	assert.Equal(t, ValidatorRoleCandidate, own.ValidatorRole)
+	assert.Equal(t, uint8(1), own.ValidatorStatus)
+	assert.Equal(t, 0, len(own.UnbondedRecords))
+
+	delegation := byAddress[validator.Hex()]
+	assert.Equal(t, "simulated", delegation.Name)
+	assert.Equal(t, ValidatorRoleValidator, delegation.ValidatorRole)
+	assert.Equal(t, validatorStatusBonded, delegation.ValidatorStatus)
+	stake, err := node.DelegatorStakedAmount(ctx, validator.Hex(), delegator.From.Hex())
+	assert.Nil(t, err)
+	assert.Equal(t, stake.String(), delegation.StakedAmount)
+	assert.Equal(t, 1, len(delegation.WithdrawableRecords))
+	assert.Equal(t, []*big.Int{matured}, delegation.WithdrawableRecords[0].Balances)
+	assert.Equal(t, []*UnbondedRecord{{Balances: []*big.Int{pending.Amount}, CompletionTimes: []*big.Int{pending.CompletionTime}}}, delegation.UnbondedRecords)
+	assert.Equal(t, matured.String(), delegation.WithdrawableAmount)
+	assert.Equal(t, pending.Amount.String(), delegation.UnbondedAmount)
+	assert.Equal(t, new(big.Int).Add(matured, pending.Amount).String(), delegation.TotalUnbondedAmount)
+	rewards, ok := new(big.Int).SetString(delegation.ClaimableRewards, 10)
+	assert.True(t, ok)
+	assert.Equal(t, new(big.Int).Add(matured, rewards).String(), delegation.TotalWithdrawableAmount)
+
+	total := new(big.Int)
+	for _, v := range portfolio.Validators {
+		amount, _ := new(big.Int).SetString(v.StakedAmount, 10)
+		total.Add(total, amount)
+	}
+	assert.Equal(t, total, portfolio.StakedAmount)
+	assert.Equal(t, matured, portfolio.WithdrawableAmount)
+	assert.Equal(t, pending.Amount, portfolio.UnbondedAmount)
+
+	// the first entry was not matured before the time was adjusted
+	past, err := node.DelegatorPortfolio(WithBlock(ctx, BlockAtHeight(pending.Raw.BlockHeight-2)), delegator.From)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, past.WithdrawableAmount.Sign())
+	assert.Equal(t, matured, past.UnbondedAmount)
+}
@@ -23,4 +23,42 @@ import "errors"
 var (
 	ErrMethodNotFound = errors.New("abi: could not locate named method or event")
 	ErrEmptyList      = errors.New("empty list")
+
+	ErrNoNodeAvailable    = errors.New("no node available")
+	ErrInvalidFilterQuery = errors.New("cannot specify both BlockHash and FromBlock/ToBlock")
+	ErrInvalidBlockRange  = errors.New("fromBlock is greater than toBlock")
+	ErrNodeBehind         = errors.New("node is behind its peers")
+	ErrWrongNetwork       = errors.New("node is on the wrong network")
+
+	ErrNonceAlreadyMined      = errors.New("a transaction with this nonce is already mined")
+	ErrNonceConsumed          = errors.New("nonce consumed by unknown transaction")
+	ErrReplacementUnderpriced = errors.New("replacement gas price too low")
+	ErrTxNotFailed            = errors.New("transaction did not fail")
+	ErrRevertNotReproduced    = errors.New("transaction does not revert when executed as a call")
+
+	ErrUnsupportedURI   = errors.New("unsupported token URI")
+	ErrMetadataTooLarge = errors.New("token metadata too large")
+	ErrLengthMismatch   = errors.New("ids and amounts lengths mismatch")
+
+	ErrMissingCallTarget = errors.New("call without target contract")
+
+	ErrInvalidDelegateAmount   = errors.New("delegate amount must be positive")
+	ErrBelowMinStake           = errors.New("amount is below the minimum stake")
+	ErrNoDelegation            = errors.New("delegation not found")
+	ErrInvalidUndelegateAmount = errors.New("undelegate amount invalid")
+	ErrTooManyUnbondingEntries = errors.New("too many unbonding delegation entries")
+	ErrNoUnbondedAmount        = errors.New("no unbonding amount to withdraw")
+	ErrNoRewards               = errors.New("no delegation rewards to withdraw")
+	ErrNotValidator            = errors.New("caller is not the validator")
+	ErrNoCommission            = errors.New("no validator commission to withdraw")
+
+	ErrNameTooLong              = errors.New("validator name longer than 32 bytes")
+	ErrInvalidCommissionRate    = errors.New("commission rate must be positive")
+	ErrCommissionAboveMaxRate   = errors.New("commission cannot be more than the max rate")
+	ErrCommissionChangeTooLarge = errors.New("commission cannot be changed more than max change rate")
+	ErrCommissionChangeTooSoon  = errors.New("commission cannot be changed more than once in 24h")
+
+	ErrInvalidCommit = errors.New("commit does not match the validator set")
+
+	ErrInvalidSlashHeight = errors.New("slash at genesis block")
 )
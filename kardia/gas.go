@@ -0,0 +1,167 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package kardia
+package kardia
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/kardiachain/go-kardia"
+	"github.com/kardiachain/go-kardia/configs"
+	"go.uber.org/zap"
+)
+
+var (
+	// MinGasPrice is the minimum gas price accepted by the network, 1 OXY
+	MinGasPrice = new(big.Int).Set(configs.GasPriceCap)
+)
+
+type IGas interface {
+	GasPrice(ctx context.Context) (*big.Int, error)
+	SetGasPriceStrategy(strategy GasPriceStrategy)
+	SetGasLimitMargin(percent uint64)
+}
+
+// GasPriceStrategy decides which gas price SuggestGasPrice returns, and so which
+// gas price bind.TransactOpts without GasPrice (e.g NewKeyedTransactor) are sent with.
+type GasPriceStrategy interface {
+	SuggestGasPrice(ctx context.Context, n Node) (*big.Int, error)
+}
+
+// FixedGasPrice always suggests the same gas price
+type FixedGasPrice struct {
+	Price *big.Int
+}
+
+func (s FixedGasPrice) SuggestGasPrice(ctx context.Context, n Node) (*big.Int, error) {
+	return new(big.Int).Set(s.Price), nil
+}
+
+// NodeGasPrice suggests the gas price reported by the node oracle, this is the default strategy
+type NodeGasPrice struct{}
+
+func (s NodeGasPrice) SuggestGasPrice(ctx context.Context, n Node) (*big.Int, error) {
+	return n.GasPrice(ctx)
+}
+
+// PercentileGasPrice suggests the given percentile of gas prices paid in the latest Blocks blocks.
+// MinGasPrice is suggested if those blocks contain no transaction.
+type PercentileGasPrice struct {
+	Blocks     uint64
+	Percentile int
+}
+
+func (s PercentileGasPrice) SuggestGasPrice(ctx context.Context, n Node) (*big.Int, error) {
+	if s.Percentile < 0 || s.Percentile > 100 {
+		return nil, fmt.Errorf("invalid gas price percentile %d", s.Percentile)
+	}
+	latest, err := n.LatestBlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var prices []uint64
+	for height := latest; height > 0 && latest-height < s.Blocks; height-- {
+		block, err := n.BlockByHeight(ctx, height)
+		if err != nil {
+			return nil, err
+		}
+		for _, tx := range block.Txs {
+			prices = append(prices, tx.GasPrice)
+		}
+	}
+	if len(prices) == 0 {
+		return new(big.Int).Set(MinGasPrice), nil
+	}
+	sort.Slice(prices, func(i, j int) bool { return prices[i] < prices[j] })
+	price := new(big.Int).SetUint64(prices[(len(prices)-1)*s.Percentile/100])
+	if price.Cmp(MinGasPrice) < 0 {
+		return new(big.Int).Set(MinGasPrice), nil
+	}
+	return price, nil
+}
+
+// SetGasPriceStrategy changes the strategy used by SuggestGasPrice, transactions already being
+// prepared may still use the previous one.
+func (n *node) SetGasPriceStrategy(strategy GasPriceStrategy) {
+	n.gasMu.Lock()
+	defer n.gasMu.Unlock()
+	n.gasPriceStrategy = strategy
+}
+
+// SetGasLimitMargin adds a safety margin of percent to every gas estimation,
+// capped at the network gas limit. Default is no margin.
+func (n *node) SetGasLimitMargin(percent uint64) {
+	n.gasMu.Lock()
+	defer n.gasMu.Unlock()
+	n.gasLimitMargin = percent
+}
+
+// GasPrice returns the gas price suggested by the node oracle
+func (n *node) GasPrice(ctx context.Context) (*big.Int, error) {
+	var result string
+	if err := n.client.CallContext(ctx, &result, "kai_gasPrice"); err != nil {
+		return nil, err
+	}
+	price, ok := new(big.Int).SetString(result, 0)
+	if !ok {
+		return nil, fmt.Errorf("invalid gas price %s", result)
+	}
+	return price, nil
+}
+
+// SuggestGasPrice retrieves the gas price of the configured strategy
+func (n *node) SuggestGasPrice(ctx context.Context) (uint64, error) {
+	n.gasMu.RLock()
+	strategy := n.gasPriceStrategy
+	n.gasMu.RUnlock()
+	if strategy == nil {
+		strategy = NodeGasPrice{}
+	}
+	price, err := strategy.SuggestGasPrice(ctx, n)
+	if err != nil {
+		n.lgr.Error("Cannot suggest gas price", zap.Error(err))
+		return 0, err
+	}
+	if !price.IsUint64() {
+		return 0, fmt.Errorf("gas price %s overflows uint64", price)
+	}
+	return price.Uint64(), nil
+}
+
+// EstimateGas estimates the gas needed to execute call against the latest state,
+// plus the configured safety margin.
+func (n *node) EstimateGas(ctx context.Context, call kardia.CallMsg) (uint64, error) {
+	var gas uint64
+	if err := n.client.CallContext(ctx, &gas, "kai_estimateGas", toCallArgs(call), "latest"); err != nil {
+		return 0, err
+	}
+	n.gasMu.RLock()
+	margin := n.gasLimitMargin
+	n.gasMu.RUnlock()
+	if margin == 0 {
+		return gas, nil
+	}
+	gas += gas * margin / 100
+	if gas > configs.GasLimitCap {
+		gas = configs.GasLimitCap
+	}
+	return gas, nil
+}
@@ -0,0 +1,152 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package kardia
+package kardia
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/kardiachain/go-kardia"
+	"github.com/kardiachain/go-kardia/configs"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/rpc"
+	"github.com/kardiachain/go-kardia/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockGasAPI struct {
+	estimateArgs []types.CallArgsJSON
+	// gas prices of transactions per block height
+	blocks map[uint64][]uint64
+}
+
+func (api *mockGasAPI) GasPrice() string {
+	return "2000000000"
+}
+
+func (api *mockGasAPI) EstimateGas(args types.CallArgsJSON, blockHeightOrHash rpc.BlockHeightOrHash) uint64 {
+	api.estimateArgs = append(api.estimateArgs, args)
+	return 50000
+}
+
+func (api *mockGasAPI) BlockNumber() uint64 {
+	return uint64(len(api.blocks))
+}
+
+func (api *mockGasAPI) GetBlockByNumber(height rpc.BlockHeight) *Block {
+	block := &Block{Height: height.Uint64()}
+	for _, price := range api.blocks[height.Uint64()] {
+		block.Txs = append(block.Txs, &Transaction{GasPrice: price})
+	}
+	return block
+}
+
+func TestGas_EstimateGas(t *testing.T) {
+	api := &mockGasAPI{}
+	node, err := setupMockNodeInstance(map[string]interface{}{"kai": api})
+	assert.Nil(t, err)
+
+	to := common.HexToAddress(test1SmcAddr)
+	call := kardia.CallMsg{
+		From:  common.HexToAddress("0x01"),
+		To:    &to,
+		Value: big.NewInt(10),
+		Data:  []byte{0x01, 0x02},
+	}
+	gas, err := node.EstimateGas(context.Background(), call)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(50000), gas)
+	assert.Equal(t, to.Hex(), *api.estimateArgs[0].To)
+	assert.Equal(t, "0x0102", api.estimateArgs[0].Data)
+	assert.Equal(t, big.NewInt(10), api.estimateArgs[0].Value)
+
+	node.SetGasLimitMargin(20)
+	gas, err = node.EstimateGas(context.Background(), call)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(60000), gas)
+
+	node.SetGasLimitMargin(100000)
+	gas, err = node.EstimateGas(context.Background(), call)
+	assert.Nil(t, err)
+	assert.Equal(t, configs.GasLimitCap, gas)
+}
+
+func TestGas_SuggestGasPrice(t *testing.T) {
+	api := &mockGasAPI{
+		blocks: map[uint64][]uint64{
+			1: {5000000000, 1000000000},
+			2: {},
+			3: {3000000000, 2000000000, 4000000000},
+		},
+	}
+	node, err := setupMockNodeInstance(map[string]interface{}{"kai": api})
+	assert.Nil(t, err)
+	ctx := context.Background()
+
+	price, err := node.SuggestGasPrice(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2000000000), price)
+
+	node.SetGasPriceStrategy(FixedGasPrice{Price: big.NewInt(7000000000)})
+	price, err = node.SuggestGasPrice(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(7000000000), price)
+
+	node.SetGasPriceStrategy(PercentileGasPrice{Blocks: 3, Percentile: 50})
+	price, err = node.SuggestGasPrice(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(3000000000), price)
+
+	node.SetGasPriceStrategy(PercentileGasPrice{Blocks: 2, Percentile: 100})
+	price, err = node.SuggestGasPrice(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(4000000000), price)
+
+	// no transaction in range
+	node.SetGasPriceStrategy(PercentileGasPrice{Blocks: 1, Percentile: 50})
+	api.blocks[4] = nil
+	price, err = node.SuggestGasPrice(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, MinGasPrice.Uint64(), price)
+}
+
+func TestGas_ConcurrentSettings(t *testing.T) {
+	node, err := setupMockNodeInstance(map[string]interface{}{"kai": &mockGasAPI{}})
+	assert.Nil(t, err)
+	node.SetGasPriceStrategy(FixedGasPrice{Price: big.NewInt(1000000000)})
+
+	// settings may change while transactions are prepared, run with -race
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := int64(1); i <= 100; i++ {
+			node.SetGasPriceStrategy(FixedGasPrice{Price: big.NewInt(i * 1000000000)})
+			node.SetGasLimitMargin(uint64(i))
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		price, err := node.SuggestGasPrice(context.Background())
+		assert.Nil(t, err)
+		assert.True(t, price >= 1000000000)
+	}
+	wg.Wait()
+}
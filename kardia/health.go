@@ -0,0 +1,259 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package kardia
+package kardia
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/kardiachain/go-kardia/lib/event"
+	"go.uber.org/zap"
+)
+
+const (
+	// HealthUnknown is the status of a node which has not been checked yet, it is considered alive
+	HealthUnknown HealthStatus = iota
+	Healthy
+	Unhealthy
+)
+
+const (
+	defaultHealthCheckInterval = 10 * time.Second
+	defaultHealthCheckTimeout  = 5 * time.Second
+)
+
+type HealthStatus int32
+
+func (s HealthStatus) String() string {
+	switch s {
+	case Healthy:
+		return "healthy"
+	case Unhealthy:
+		return "unhealthy"
+	default:
+		return "unknown"
+	}
+}
+
+// Health is the result of the latest health check of a node
+type Health struct {
+	Url     string
+	Status  HealthStatus
+	Head    uint64
+	Lag     uint64 // blocks behind the highest head of monitored nodes
+	Network string
+	Latency time.Duration
+	// Err is the reason why the node is unhealthy
+	Err       error
+	CheckedAt time.Time
+}
+
+type HealthConfig struct {
+	// Interval between two checks, default 10s
+	Interval time.Duration
+	// Timeout of each probe, default 5s
+	Timeout time.Duration
+	// MaxBlockLag marks nodes lagging more than MaxBlockLag blocks behind their peers unhealthy,
+	// 0 disables lag detection
+	MaxBlockLag uint64
+	// Network is the expected NodeInfo.Network, empty accepts any network
+	Network string
+
+	Logger *zap.Logger
+}
+
+// healthReporter is implemented by nodes whose IsAlive reflects the monitor status
+type healthReporter interface {
+	setHealthStatus(status HealthStatus)
+}
+
+// HealthMonitor periodically probes kai_blockNumber and node_nodeInfo of a set of nodes,
+// compares their heads with each other and drives their IsAlive.
+type HealthMonitor struct {
+	cfg   HealthConfig
+	nodes []Node
+
+	mu     sync.RWMutex
+	health []Health
+
+	feed event.Feed
+	quit chan struct{}
+	once sync.Once
+}
+
+func NewHealthMonitor(cfg HealthConfig, nodes ...Node) *HealthMonitor {
+	if cfg.Interval == 0 {
+		cfg.Interval = defaultHealthCheckInterval
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = defaultHealthCheckTimeout
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = zap.L()
+	}
+	m := &HealthMonitor{
+		cfg:    cfg,
+		nodes:  nodes,
+		health: make([]Health, len(nodes)),
+		quit:   make(chan struct{}),
+	}
+	for i, n := range nodes {
+		m.health[i].Url = n.Url()
+	}
+	return m
+}
+
+// Start checks nodes immediately then every Interval in background until Stop is called
+func (m *HealthMonitor) Start() {
+	go func() {
+		ticker := time.NewTicker(m.cfg.Interval)
+		defer ticker.Stop()
+		for {
+			m.Check(context.Background())
+			select {
+			case <-ticker.C:
+			case <-m.quit:
+				return
+			}
+		}
+	}()
+}
+
+func (m *HealthMonitor) Stop() {
+	m.once.Do(func() { close(m.quit) })
+}
+
+// SubscribeHealthChanges delivers the new Health of a node every time its status changes
+func (m *HealthMonitor) SubscribeHealthChanges(ch chan<- Health) event.Subscription {
+	return m.feed.Subscribe(ch)
+}
+
+// Health returns the latest health of every monitored node, in the order nodes were given
+func (m *HealthMonitor) Health() []Health {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append([]Health{}, m.health...)
+}
+
+// NodeHealth returns the latest health of the node with the given url
+func (m *HealthMonitor) NodeHealth(url string) (Health, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, h := range m.health {
+		if h.Url == url {
+			return h, true
+		}
+	}
+	return Health{}, false
+}
+
+// Check probes every node once and updates their status
+func (m *HealthMonitor) Check(ctx context.Context) {
+	results := make([]Health, len(m.nodes))
+	var wg sync.WaitGroup
+	for i, n := range m.nodes {
+		wg.Add(1)
+		go func(i int, n Node) {
+			defer wg.Done()
+			results[i] = m.probe(ctx, n)
+		}(i, n)
+	}
+	wg.Wait()
+
+	var highest uint64
+	for _, h := range results {
+		if h.Err == nil && h.Head > highest {
+			highest = h.Head
+		}
+	}
+	for i := range results {
+		h := &results[i]
+		if h.Err != nil {
+			h.Status = Unhealthy
+			continue
+		}
+		h.Lag = highest - h.Head
+		switch {
+		case m.cfg.Network != "" && h.Network != m.cfg.Network:
+			h.Err = fmt.Errorf("%w: got %s, want %s", ErrWrongNetwork, h.Network, m.cfg.Network)
+			h.Status = Unhealthy
+		case m.cfg.MaxBlockLag != 0 && h.Lag > m.cfg.MaxBlockLag:
+			h.Err = fmt.Errorf("%w: %d blocks behind", ErrNodeBehind, h.Lag)
+			h.Status = Unhealthy
+		default:
+			h.Status = Healthy
+		}
+	}
+
+	var changes []Health
+	m.mu.Lock()
+	for i, h := range results {
+		if m.health[i].Status != h.Status {
+			changes = append(changes, h)
+		}
+		m.health[i] = h
+		if r, ok := m.nodes[i].(healthReporter); ok {
+			r.setHealthStatus(h.Status)
+		}
+	}
+	m.mu.Unlock()
+
+	for _, h := range changes {
+		if h.Status == Unhealthy {
+			m.cfg.Logger.Warn("Node is unhealthy", zap.String("url", h.Url), zap.Error(h.Err))
+		} else {
+			m.cfg.Logger.Info("Node is healthy", zap.String("url", h.Url), zap.Uint64("head", h.Head))
+		}
+		m.feed.Send(h)
+	}
+}
+
+func (m *HealthMonitor) probe(ctx context.Context, n Node) Health {
+	ctx, cancel := context.WithTimeout(ctx, m.cfg.Timeout)
+	defer cancel()
+	h := Health{Url: n.Url(), CheckedAt: time.Now()}
+	start := time.Now()
+	head, err := n.LatestBlockNumber(ctx)
+	h.Latency = time.Since(start)
+	if err != nil {
+		h.Err = err
+		return h
+	}
+	h.Head = head
+	info, err := n.NodeInfo(ctx)
+	if err != nil {
+		h.Err = err
+		return h
+	}
+	h.Network = info.Network
+	return h
+}
+
+func (n *node) setHealthStatus(status HealthStatus) {
+	atomic.StoreInt32(&n.healthStatus, int32(status))
+}
+
+// IsAlive returns false if the latest health check failed. Nodes which are not monitored
+// by a HealthMonitor are always alive.
+func (n *node) IsAlive() bool {
+	return HealthStatus(atomic.LoadInt32(&n.healthStatus)) != Unhealthy
+}
@@ -0,0 +1,88 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package kardia
+package kardia
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestHealth_Check(t *testing.T) {
+	synced, _ := setupMockEndpoint(t, 100, false)
+	behind, _ := setupMockEndpoint(t, 80, false)
+	forked, forkedAPI := setupMockEndpoint(t, 100, false)
+	forkedAPI.network = "kai-testnet"
+	down, _ := setupMockEndpoint(t, 100, false)
+	down.Node.(*node).client.Close()
+
+	monitor := NewHealthMonitor(HealthConfig{MaxBlockLag: 10, Network: "kai-mainnet", Logger: zap.NewNop()},
+		synced.Node, behind.Node, forked.Node, down.Node)
+	changes := make(chan Health, 4)
+	sub := monitor.SubscribeHealthChanges(changes)
+	defer sub.Unsubscribe()
+
+	assert.True(t, behind.IsAlive(), "unchecked nodes are alive")
+	monitor.Check(context.Background())
+
+	health := monitor.Health()
+	assert.Equal(t, Healthy, health[0].Status)
+	assert.Equal(t, uint64(100), health[0].Head)
+	assert.Equal(t, Unhealthy, health[1].Status)
+	assert.Equal(t, uint64(20), health[1].Lag)
+	assert.True(t, errors.Is(health[1].Err, ErrNodeBehind))
+	assert.Equal(t, Unhealthy, health[2].Status)
+	assert.True(t, errors.Is(health[2].Err, ErrWrongNetwork))
+	assert.Equal(t, Unhealthy, health[3].Status)
+	assert.NotNil(t, health[3].Err)
+
+	assert.True(t, synced.IsAlive())
+	assert.False(t, behind.IsAlive())
+	assert.False(t, forked.IsAlive())
+	assert.False(t, down.IsAlive())
+	assert.Len(t, changes, 4)
+}
+
+func TestHealth_Changes(t *testing.T) {
+	synced, _ := setupMockEndpoint(t, 100, false)
+	behind, behindAPI := setupMockEndpoint(t, 80, false)
+	monitor := NewHealthMonitor(HealthConfig{MaxBlockLag: 10, Logger: zap.NewNop()}, synced.Node, behind.Node)
+	monitor.Check(context.Background())
+
+	changes := make(chan Health, 2)
+	sub := monitor.SubscribeHealthChanges(changes)
+	defer sub.Unsubscribe()
+
+	// unchanged status is not notified
+	monitor.Check(context.Background())
+	assert.Len(t, changes, 0)
+
+	behindAPI.mu.Lock()
+	behindAPI.head = 95
+	behindAPI.mu.Unlock()
+	monitor.Check(context.Background())
+	assert.Len(t, changes, 1)
+	change := <-changes
+	assert.Equal(t, behind.Url(), change.Url)
+	assert.Equal(t, Healthy, change.Status)
+	assert.True(t, behind.IsAlive())
+}
@@ -27,6 +27,7 @@ import (
 	"math/big"
 	"strings"
 
+	"github.com/kardiachain/go-kardia"
 	"github.com/kardiachain/go-kardia/lib/abi"
 	"github.com/kardiachain/go-kardia/lib/abi/bind"
 	"github.com/kardiachain/go-kardia/lib/common"
@@ -73,6 +74,28 @@ func ConstructCallArgs(address string, payload []byte) SMCCallArgs {
 	}
 }
 
+// toCallArgs converts a bind call message into node call arguments
+func toCallArgs(call kardia.CallMsg) SMCCallArgs {
+	args := SMCCallArgs{
+		From:     call.From.Hex(),
+		Gas:      call.Gas,
+		GasPrice: call.GasPrice,
+		Value:    call.Value,
+		Data:     common.Bytes(call.Data).String(),
+	}
+	if call.To != nil {
+		to := call.To.Hex()
+		args.To = &to
+	}
+	if args.GasPrice == nil {
+		args.GasPrice = big.NewInt(0)
+	}
+	if args.Value == nil {
+		args.Value = big.NewInt(0)
+	}
+	return args
+}
+
 // NewKeyedTransactor is a utility method to easily create a transaction signer
 // from a single private key.
 func NewKeyedTransactor(key *ecdsa.PrivateKey) *bind.TransactOpts {
@@ -172,3 +195,9 @@ func GenerateWallet() (common.Address, ecdsa.PrivateKey, error) {
 func FloatToBigInt(amount float64, decimals int32) *big.Int {
 	return decimal.NewFromFloat(amount).Mul(decimal.New(1, decimals)).BigInt()
 }
+
+// BigIntToFloat is the inverse of FloatToBigInt, it converts amount in units of 10^-decimals
+func BigIntToFloat(amount *big.Int, decimals int32) float64 {
+	f, _ := decimal.NewFromBigInt(amount, -decimals).Float64()
+	return f
+}
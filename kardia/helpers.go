@@ -21,6 +21,15 @@ func KRC721ABI() (*abi.ABI, error) {
 	return &abiData, nil
 }
 
+func KRC1155ABI() (*abi.ABI, error) {
+	r := strings.NewReader(smc.KRC1155ABI)
+	abiData, err := abi.JSON(r)
+	if err != nil {
+		return nil, err
+	}
+	return &abiData, nil
+}
+
 func KRC20ABI() (*abi.ABI, error) {
 	r := strings.NewReader(smc.KRC20ABI)
 	abiData, err := abi.JSON(r)
@@ -0,0 +1,143 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package evmasm is a small KVM assembler, used to build the test contracts of kardia/smc
+// without a Solidity compiler. Programs are written with expressions, which push one value,
+// and statements, which leave the stack as they found it.
+package evmasm
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/kardiachain/go-kardia/kvm"
+)
+
+// Program is a KVM program under construction
+type Program struct {
+	code   []byte
+	labels map[string]int
+	refs   map[int]string
+	next   int
+}
+
+// NewProgram returns an empty program
+func NewProgram() *Program {
+	return &Program{labels: make(map[string]int), refs: make(map[int]string)}
+}
+
+// Expr emits code pushing one value on the stack
+type Expr func(p *Program)
+
+// Op emits the opcodes ops
+func (p *Program) Op(ops ...kvm.OpCode) {
+	for _, op := range ops {
+		p.code = append(p.code, byte(op))
+	}
+}
+
+// Push emits the shortest PUSH of v, an int, uint64, *big.Int or []byte
+func (p *Program) Push(v interface{}) {
+	var b []byte
+	switch v := v.(type) {
+	case int:
+		b = new(big.Int).SetInt64(int64(v)).Bytes()
+	case uint64:
+		b = new(big.Int).SetUint64(v).Bytes()
+	case *big.Int:
+		b = v.Bytes()
+	case []byte:
+		b = new(big.Int).SetBytes(v).Bytes()
+	default:
+		panic(fmt.Sprintf("evmasm: cannot push %T", v))
+	}
+	if len(b) == 0 {
+		b = []byte{0}
+	}
+	if len(b) > 32 {
+		panic("evmasm: pushed value exceeds 32 bytes")
+	}
+	p.code = append(p.code, byte(kvm.PUSH1)+byte(len(b)-1))
+	p.code = append(p.code, b...)
+}
+
+// NewLabel returns a label name unique in the program
+func (p *Program) NewLabel(prefix string) string {
+	p.next++
+	return fmt.Sprintf("%s_%d", prefix, p.next)
+}
+
+// Label marks the jump destination name
+func (p *Program) Label(name string) {
+	if _, ok := p.labels[name]; ok {
+		panic("evmasm: duplicate label " + name)
+	}
+	p.labels[name] = len(p.code)
+	p.Op(kvm.JUMPDEST)
+}
+
+// PushLabel pushes the position of the label name, resolved by Bytes
+func (p *Program) PushLabel(name string) {
+	p.code = append(p.code, byte(kvm.PUSH2))
+	p.refs[len(p.code)] = name
+	p.code = append(p.code, 0, 0)
+}
+
+// Jump jumps to the label name
+func (p *Program) Jump(name string) {
+	p.PushLabel(name)
+	p.Op(kvm.JUMP)
+}
+
+// JumpIf pops a condition and jumps to the label name if it is not zero
+func (p *Program) JumpIf(name string) {
+	p.PushLabel(name)
+	p.Op(kvm.JUMPI)
+}
+
+// Bytes returns the code of the program with resolved labels
+func (p *Program) Bytes() ([]byte, error) {
+	code := make([]byte, len(p.code))
+	copy(code, p.code)
+	for pos, name := range p.refs {
+		dest, ok := p.labels[name]
+		if !ok {
+			return nil, fmt.Errorf("evmasm: undefined label %s", name)
+		}
+		if dest > 0xffff {
+			return nil, fmt.Errorf("evmasm: label %s out of range", name)
+		}
+		code[pos], code[pos+1] = byte(dest>>8), byte(dest)
+	}
+	return code, nil
+}
+
+// Deployment returns the creation code deploying runtime
+func Deployment(runtime []byte) []byte {
+	// PUSH2 len, DUP1, PUSH1 offset, PUSH1 0, CODECOPY, PUSH1 0, RETURN
+	const size = 12
+	code := []byte{
+		byte(kvm.PUSH2), byte(len(runtime) >> 8), byte(len(runtime)),
+		byte(kvm.DUP1),
+		byte(kvm.PUSH1), size,
+		byte(kvm.PUSH1), 0,
+		byte(kvm.CODECOPY),
+		byte(kvm.PUSH1), 0,
+		byte(kvm.RETURN),
+	}
+	return append(code, runtime...)
+}
@@ -0,0 +1,58 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package evmasm
+package evmasm
+
+import (
+	"encoding/hex"
+	"io/ioutil"
+	"testing"
+
+	"github.com/kardiachain/go-kardia/kvm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgram_Bytes(t *testing.T) {
+	p := NewProgram()
+	p.Push(0)
+	p.Push(0x1234)
+	p.Jump("end")
+	p.Op(kvm.STOP)
+	p.Label("end")
+	code, err := p.Bytes()
+	assert.Nil(t, err)
+	assert.Equal(t, "600061123461000a56005b", hex.EncodeToString(code))
+
+	p.Jump("missing")
+	_, err = p.Bytes()
+	assert.NotNil(t, err)
+}
+
+func TestDeployment(t *testing.T) {
+	code := Deployment([]byte{0x60, 0x00})
+	assert.Equal(t, "61000280600c6000396000f36000", hex.EncodeToString(code))
+}
+
+// TestSource_UpToDate checks the bytecode generated into package smc matches the programs
+func TestSource_UpToDate(t *testing.T) {
+	code, err := Source()
+	assert.Nil(t, err)
+	generated, err := ioutil.ReadFile("../../smc/bytecode.go")
+	assert.Nil(t, err)
+	assert.Equal(t, string(code), string(generated), "run go generate in kardia/smc to update the bytecode")
+}
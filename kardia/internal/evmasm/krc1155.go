@@ -0,0 +1,202 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package evmasm
+package evmasm
+
+import (
+	"github.com/kardiachain/go-kardia/kvm"
+)
+
+// Storage of the KRC1155 test token
+const (
+	slotMultiBalances  = iota // id => account => balance
+	slotMultiOperators        // account => operator => approved
+)
+
+const (
+	// KRC1155URI is the URI of all the tokens of the KRC1155 test token
+	KRC1155URI = "ipfs://QmKRC1155/{id}.json"
+
+	krc1155TransferSingleTopic   = "TransferSingle(address,address,address,uint256,uint256)"
+	krc1155TransferBatchTopic    = "TransferBatch(address,address,address,uint256[],uint256[])"
+	krc1155ApprovalForAllTopic   = "ApprovalForAll(address,address,bool)"
+	krc1155ReceivedSelector      = "onERC1155Received(address,address,uint256,uint256,bytes)"
+	krc1155BatchReceivedSelector = "onERC1155BatchReceived(address,address,uint256[],uint256[],bytes)"
+)
+
+// KRC1155 returns the creation code of the KRC1155 test token.
+//
+// The token implements the KRC1155 standard with the metadata URI extension, all tokens share KRC1155URI.
+// mint(address to, uint256 id, uint256 amount) can be called by anyone.
+func KRC1155() ([]byte, error) {
+	p := NewProgram()
+	dispatch(p, []method{
+		{"supportsInterface(bytes4)", func(p *Program) {
+			p.Set(varTmp, Shr(224, Arg(0)))
+			isID := func(id []byte) Expr { return Eq(Mem(varTmp), Const(id)) }
+			p.ReturnWord(Or(
+				Or(isID([]byte{0x01, 0xff, 0xc9, 0xa7}), isID([]byte{0xd9, 0xb6, 0x7a, 0x26})),
+				isID([]byte{0x0e, 0x89, 0x34, 0x1c}),
+			))
+		}},
+		{"balanceOf(address,uint256)", func(p *Program) {
+			p.Set(varOwner, Address(Arg(0)))
+			p.Require(Mem(varOwner), "KRC1155: zero address")
+			p.ReturnWord(Sload(multiBalanceSlot(Arg(1), Mem(varOwner))))
+		}},
+		{"balanceOfBatch(address[],uint256[])", balanceOfBatch},
+		{"uri(uint256)", func(p *Program) { p.ReturnString(KRC1155URI) }},
+		{"isApprovedForAll(address,address)", func(p *Program) {
+			p.ReturnWord(isMultiOperator(Address(Arg(0)), Address(Arg(1))))
+		}},
+		{"setApprovalForAll(address,bool)", func(p *Program) {
+			p.Set(varTo, Address(Arg(0)))
+			p.Require(IsZero(Eq(Mem(varTo), Caller())), "KRC1155: approve to caller")
+			p.Set(varTmp, IsZero(IsZero(Arg(1))))
+			p.Sstore(Keccak(Mem(varTo), Keccak(Caller(), Const(slotMultiOperators))), Mem(varTmp))
+			p.Log(Mem(varTmp), Topic(krc1155ApprovalForAllTopic), Caller(), Mem(varTo))
+			p.Stop()
+		}},
+		{"safeTransferFrom(address,address,uint256,uint256,bytes)", safeTransferFrom},
+		{"safeBatchTransferFrom(address,address,uint256[],uint256[],bytes)", safeBatchTransferFrom},
+		{"mint(address,uint256,uint256)", func(p *Program) {
+			p.Set(varTo, Address(Arg(0)))
+			p.Set(varID, Arg(1))
+			p.Set(varAmount, Arg(2))
+			p.Require(Mem(varTo), "KRC1155: mint to zero address")
+			p.Set(varBase, multiBalanceSlot(Mem(varID), Mem(varTo)))
+			p.Sstore(Mem(varBase), Add(Sload(Mem(varBase)), Mem(varAmount)))
+			p.Set(varFrom, Const(0))
+			logTransferSingle(p)
+			p.Stop()
+		}},
+	})
+	acceptTransfer(p)
+
+	runtime, err := p.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	return Deployment(runtime), nil
+}
+
+func multiBalanceSlot(id, account Expr) Expr {
+	return Keccak(account, Keccak(id, Const(slotMultiBalances)))
+}
+
+func isMultiOperator(account, operator Expr) Expr {
+	return Sload(Keccak(operator, Keccak(account, Const(slotMultiOperators))))
+}
+
+// element pushes the i-th element of the calldata array whose length is at calldata array
+func element(array, i Expr) Expr {
+	return Call(kvm.CALLDATALOAD, Add(Add(array, Const(0x20)), Mul(i, Const(32))))
+}
+
+func balanceOfBatch(p *Program) {
+	p.Set(varData, Add(Arg(0), Const(4)))
+	p.Set(varIDs, Add(Arg(1), Const(4)))
+	p.Set(varLen, Call(kvm.CALLDATALOAD, Mem(varData)))
+	p.Require(Eq(Mem(varLen), Call(kvm.CALLDATALOAD, Mem(varIDs))), "KRC1155: length mismatch")
+	p.Set(output, Const(0x20))
+	p.Set(output+0x20, Mem(varLen))
+	p.Set(varI, Const(0))
+	p.While(Lt(Mem(varI), Mem(varLen)), func() {
+		p.Set(varTmp, Sload(multiBalanceSlot(element(Mem(varIDs), Mem(varI)), Address(element(Mem(varData), Mem(varI))))))
+		p.Emit(kvm.MSTORE, Add(Const(output+0x40), Mul(Mem(varI), Const(32))), Mem(varTmp))
+		p.Set(varI, Add(Mem(varI), Const(1)))
+	})
+	p.Emit(kvm.RETURN, Const(output), Add(Const(0x40), Mul(Mem(varLen), Const(32))))
+}
+
+// checkTransfer loads the sender and receiver of the transfer, and checks the caller may transfer
+func checkTransfer(p *Program) {
+	p.Set(varFrom, Address(Arg(0)))
+	p.Set(varTo, Address(Arg(1)))
+	p.Require(Mem(varTo), "KRC1155: transfer to zero addr")
+	p.Require(Or(Eq(Caller(), Mem(varFrom)), isMultiOperator(Mem(varFrom), Caller())), "KRC1155: not owner nor approved")
+}
+
+// move moves varAmount of varID from varFrom to varTo
+func move(p *Program) {
+	p.Set(varBase, multiBalanceSlot(Mem(varID), Mem(varFrom)))
+	p.Set(varTmp, Sload(Mem(varBase)))
+	p.Require(IsZero(Lt(Mem(varTmp), Mem(varAmount))), "KRC1155: insufficient balance")
+	p.Sstore(Mem(varBase), Sub(Mem(varTmp), Mem(varAmount)))
+	p.Set(varBase, multiBalanceSlot(Mem(varID), Mem(varTo)))
+	p.Sstore(Mem(varBase), Add(Sload(Mem(varBase)), Mem(varAmount)))
+}
+
+func logTransferSingle(p *Program) {
+	p.Set(scratch, Mem(varID))
+	p.Set(scratch+0x20, Mem(varAmount))
+	p.Emit(kvm.LOG4, Const(scratch), Const(0x40), Topic(krc1155TransferSingleTopic), Caller(), Mem(varFrom), Mem(varTo))
+}
+
+func safeTransferFrom(p *Program) {
+	checkTransfer(p)
+	p.Set(varID, Arg(2))
+	p.Set(varAmount, Arg(3))
+	move(p)
+	logTransferSingle(p)
+	p.Set(varSelector, SelectorWord(krc1155ReceivedSelector))
+	p.Jump("accept")
+}
+
+func safeBatchTransferFrom(p *Program) {
+	checkTransfer(p)
+	p.Set(varIDs, Add(Arg(2), Const(4)))
+	p.Set(varAmounts, Add(Arg(3), Const(4)))
+	p.Set(varLen, Call(kvm.CALLDATALOAD, Mem(varIDs)))
+	p.Require(Eq(Mem(varLen), Call(kvm.CALLDATALOAD, Mem(varAmounts))), "KRC1155: length mismatch")
+	p.Set(varI, Const(0))
+	p.While(Lt(Mem(varI), Mem(varLen)), func() {
+		p.Set(varID, element(Mem(varIDs), Mem(varI)))
+		p.Set(varAmount, element(Mem(varAmounts), Mem(varI)))
+		move(p)
+		p.Set(varI, Add(Mem(varI), Const(1)))
+	})
+
+	// the log data are the ids and amounts arrays, copied from the calldata
+	p.Set(varTmp, Add(Const(0x20), Mul(Mem(varLen), Const(32))))
+	p.Set(output, Const(0x40))
+	p.Set(output+0x20, Add(Const(0x40), Mem(varTmp)))
+	p.Emit(kvm.CALLDATACOPY, Const(output+0x40), Mem(varIDs), Mem(varTmp))
+	p.Emit(kvm.CALLDATACOPY, Add(Const(output+0x40), Mem(varTmp)), Mem(varAmounts), Mem(varTmp))
+	p.Emit(kvm.LOG4, Const(output), Add(Const(0x40), Mul(Mem(varTmp), Const(2))),
+		Topic(krc1155TransferBatchTopic), Caller(), Mem(varFrom), Mem(varTo))
+	p.Set(varSelector, SelectorWord(krc1155BatchReceivedSelector))
+	p.Jump("accept")
+}
+
+// acceptTransfer calls the receiver method of varSelector of contract receivers. The arguments of the transfer
+// methods and of the receiver methods have the same layout after from, they are copied from the calldata.
+func acceptTransfer(p *Program) {
+	p.Label("accept")
+	p.If(Lt(Const(0), Call(kvm.EXTCODESIZE, Mem(varTo))), func() {
+		p.Set(output, Mem(varSelector))
+		p.Set(output+0x04, Caller())
+		p.Set(output+0x24, Mem(varFrom))
+		p.Emit(kvm.CALLDATACOPY, Const(output+0x44), Const(0x44), Sub(Call(kvm.CALLDATASIZE), Const(0x44)))
+		p.Set(0, Const(0))
+		p.Require(Call(kvm.CALL, Call(kvm.GAS), Mem(varTo), Const(0), Const(output),
+			Call(kvm.CALLDATASIZE), Const(0), Const(0x20)), "KRC1155: rejected by receiver")
+		p.Require(Eq(Shr(224, Mem(0)), Shr(224, Mem(varSelector))), "KRC1155: rejected by receiver")
+	})
+	p.Stop()
+}
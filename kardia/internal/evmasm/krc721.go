@@ -0,0 +1,313 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package evmasm
+package evmasm
+
+import (
+	"fmt"
+
+	"github.com/kardiachain/go-kardia/kvm"
+)
+
+// Storage of the KRC721 test token
+const (
+	slotTotalSupply = iota
+	slotOwners      // tokenId => owner
+	slotBalances    // owner => balance
+	slotApprovals   // tokenId => approved
+	slotOperators   // owner => operator => approved
+	slotAllTokens   // index => tokenId
+	slotOwnedTokens // owner => index => tokenId
+	slotOwnedIndex  // tokenId => index in the tokens of its owner
+	slotURIs        // tokenId => length, followed by the words of the URI
+)
+
+// Memory variables of the test contracts
+const (
+	varFrom = 0x100 + 0x20*iota
+	varTo
+	varID
+	varOwner
+	varIndex
+	varLast
+	varLastID
+	varTmp
+	varSafe
+	varData
+	varBase
+	varLen
+	varI
+	varAmount
+	varIDs
+	varAmounts
+	varSelector
+	varElem
+	varCursor
+	varOut
+	varSuccess
+
+	// output is the start of the memory built by the methods returning arrays and passed to receivers
+	output = 0x400
+)
+
+const (
+	krc721TransferTopic       = "Transfer(address,address,uint256)"
+	krc721ApprovalTopic       = "Approval(address,address,uint256)"
+	krc721ApprovalForAllTopic = "ApprovalForAll(address,address,bool)"
+	krc721ReceivedSelector    = "onERC721Received(address,address,uint256,bytes)"
+)
+
+// KRC721 returns the creation code of the KRC721 test token "Kardia Test NFT" (KTN).
+//
+// The token implements the KRC721 standard with the metadata and enumerable extensions,
+// and mint(address to, uint256 tokenId, string uri), which anyone can call.
+func KRC721() ([]byte, error) {
+	p := NewProgram()
+	dispatch(p, []method{
+		{"name()", func(p *Program) { p.ReturnString("Kardia Test NFT") }},
+		{"symbol()", func(p *Program) { p.ReturnString("KTN") }},
+		{"totalSupply()", func(p *Program) { p.ReturnWord(Sload(Const(slotTotalSupply))) }},
+		{"supportsInterface(bytes4)", supportsInterface},
+		{"balanceOf(address)", balanceOf},
+		{"ownerOf(uint256)", func(p *Program) {
+			p.Set(varOwner, ownerOf(Arg(0)))
+			p.Require(Mem(varOwner), "KRC721: nonexistent token")
+			p.ReturnWord(Mem(varOwner))
+		}},
+		{"tokenURI(uint256)", tokenURI},
+		{"tokenByIndex(uint256)", func(p *Program) {
+			p.Require(Lt(Arg(0), Sload(Const(slotTotalSupply))), "KRC721: index out of bounds")
+			p.ReturnWord(Sload(Keccak(Arg(0), Const(slotAllTokens))))
+		}},
+		{"tokenOfOwnerByIndex(address,uint256)", func(p *Program) {
+			p.Set(varOwner, Address(Arg(0)))
+			p.Require(Lt(Arg(1), balance(Mem(varOwner))), "KRC721: index out of bounds")
+			p.ReturnWord(Sload(ownedSlot(Mem(varOwner), Arg(1))))
+		}},
+		{"getApproved(uint256)", func(p *Program) {
+			p.Require(ownerOf(Arg(0)), "KRC721: nonexistent token")
+			p.ReturnWord(Sload(Keccak(Arg(0), Const(slotApprovals))))
+		}},
+		{"isApprovedForAll(address,address)", func(p *Program) {
+			p.ReturnWord(isOperator(Address(Arg(0)), Address(Arg(1))))
+		}},
+		{"approve(address,uint256)", approve},
+		{"setApprovalForAll(address,bool)", setApprovalForAll},
+		{"transferFrom(address,address,uint256)", func(p *Program) {
+			p.Set(varSafe, Const(0))
+			p.Set(varData, Const(0))
+			p.Jump("transfer")
+		}},
+		{"safeTransferFrom(address,address,uint256)", func(p *Program) {
+			p.Set(varSafe, Const(1))
+			p.Set(varData, Const(0))
+			p.Jump("transfer")
+		}},
+		{"safeTransferFrom(address,address,uint256,bytes)", func(p *Program) {
+			p.Set(varSafe, Const(1))
+			p.Set(varData, Add(Arg(3), Const(4)))
+			p.Jump("transfer")
+		}},
+		{"mint(address,uint256,string)", mint},
+	})
+	transfer(p)
+
+	runtime, err := p.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	return Deployment(runtime), nil
+}
+
+// method is a method of a test contract, body runs with the selector at the bottom of the stack
+type method struct {
+	sig  string
+	body func(p *Program)
+}
+
+// dispatch jumps to the body of the method called, or reverts
+func dispatch(p *Program, methods []method) {
+	p.Emit(kvm.SHR, Const(224), Call(kvm.CALLDATALOAD, Const(0)))
+	for i, m := range methods {
+		p.Op(kvm.DUP1)
+		Selector(m.sig)(p)
+		p.Op(kvm.EQ)
+		p.JumpIf(methodLabel(i))
+	}
+	p.Emit(kvm.REVERT, Const(0), Const(0))
+	for i, m := range methods {
+		p.Label(methodLabel(i))
+		m.body(p)
+	}
+}
+
+func methodLabel(i int) string {
+	return fmt.Sprintf("method_%d", i)
+}
+
+func ownerOf(id Expr) Expr {
+	return Sload(Keccak(id, Const(slotOwners)))
+}
+
+func balance(owner Expr) Expr {
+	return Sload(Keccak(owner, Const(slotBalances)))
+}
+
+func isOperator(owner, operator Expr) Expr {
+	return Sload(Keccak(operator, Keccak(owner, Const(slotOperators))))
+}
+
+func ownedSlot(owner, index Expr) Expr {
+	return Keccak(index, Keccak(owner, Const(slotOwnedTokens)))
+}
+
+func supportsInterface(p *Program) {
+	p.Set(varTmp, Shr(224, Arg(0)))
+	isID := func(id []byte) Expr { return Eq(Mem(varTmp), Const(id)) }
+	p.ReturnWord(Or(
+		Or(isID([]byte{0x01, 0xff, 0xc9, 0xa7}), isID([]byte{0x80, 0xac, 0x58, 0xcd})),
+		Or(isID([]byte{0x5b, 0x5e, 0x13, 0x9f}), isID([]byte{0x78, 0x0e, 0x9d, 0x63})),
+	))
+}
+
+func balanceOf(p *Program) {
+	p.Set(varOwner, Address(Arg(0)))
+	p.Require(Mem(varOwner), "KRC721: zero address")
+	p.ReturnWord(balance(Mem(varOwner)))
+}
+
+func tokenURI(p *Program) {
+	p.Require(ownerOf(Arg(0)), "KRC721: nonexistent token")
+	p.Set(varBase, Keccak(Arg(0), Const(slotURIs)))
+	p.Set(varLen, Sload(Mem(varBase)))
+	p.Set(output, Const(0x20))
+	p.Set(output+0x20, Mem(varLen))
+	p.Set(varI, Const(0))
+	p.While(Lt(Mul(Mem(varI), Const(32)), Mem(varLen)), func() {
+		p.Set(varTmp, Sload(Add(Mem(varBase), Add(Mem(varI), Const(1)))))
+		p.Emit(kvm.MSTORE, Add(Const(output+0x40), Mul(Mem(varI), Const(32))), Mem(varTmp))
+		p.Set(varI, Add(Mem(varI), Const(1)))
+	})
+	p.Emit(kvm.RETURN, Const(output), Add(Const(0x40), Ceil32(Mem(varLen))))
+}
+
+func approve(p *Program) {
+	p.Set(varTo, Address(Arg(0)))
+	p.Set(varID, Arg(1))
+	p.Set(varOwner, ownerOf(Mem(varID)))
+	p.Require(Mem(varOwner), "KRC721: nonexistent token")
+	p.Require(IsZero(Eq(Mem(varTo), Mem(varOwner))), "KRC721: approval to owner")
+	p.Require(Or(Eq(Caller(), Mem(varOwner)), isOperator(Mem(varOwner), Caller())), "KRC721: not owner nor operator")
+	p.Sstore(Keccak(Mem(varID), Const(slotApprovals)), Mem(varTo))
+	p.Log(nil, Topic(krc721ApprovalTopic), Mem(varOwner), Mem(varTo), Mem(varID))
+	p.Stop()
+}
+
+func setApprovalForAll(p *Program) {
+	p.Set(varTo, Address(Arg(0)))
+	p.Require(IsZero(Eq(Mem(varTo), Caller())), "KRC721: approve to caller")
+	p.Set(varTmp, IsZero(IsZero(Arg(1))))
+	p.Sstore(Keccak(Mem(varTo), Keccak(Caller(), Const(slotOperators))), Mem(varTmp))
+	p.Log(Mem(varTmp), Topic(krc721ApprovalForAllTopic), Caller(), Mem(varTo))
+	p.Stop()
+}
+
+// transfer moves varID from varFrom to varTo, then calls onERC721Received of contract receivers
+// when varSafe is set, with the bytes at calldata varData, or no data if zero
+func transfer(p *Program) {
+	p.Label("transfer")
+	p.Set(varFrom, Address(Arg(0)))
+	p.Set(varTo, Address(Arg(1)))
+	p.Set(varID, Arg(2))
+	p.Set(varOwner, ownerOf(Mem(varID)))
+	p.Require(Mem(varOwner), "KRC721: nonexistent token")
+	p.Require(Eq(Mem(varOwner), Mem(varFrom)), "KRC721: from is not owner")
+	p.Require(Mem(varTo), "KRC721: transfer to zero address")
+	p.Require(Or(
+		Or(Eq(Caller(), Mem(varOwner)), Eq(Caller(), Sload(Keccak(Mem(varID), Const(slotApprovals))))),
+		isOperator(Mem(varOwner), Caller()),
+	), "KRC721: not owner nor approved")
+	p.Sstore(Keccak(Mem(varID), Const(slotApprovals)), Const(0))
+
+	// move the last token of the sender to the index of the transferred one
+	p.Set(varLast, Sub(balance(Mem(varFrom)), Const(1)))
+	p.Set(varIndex, Sload(Keccak(Mem(varID), Const(slotOwnedIndex))))
+	p.If(IsZero(Eq(Mem(varIndex), Mem(varLast))), func() {
+		p.Set(varLastID, Sload(ownedSlot(Mem(varFrom), Mem(varLast))))
+		p.Sstore(ownedSlot(Mem(varFrom), Mem(varIndex)), Mem(varLastID))
+		p.Sstore(Keccak(Mem(varLastID), Const(slotOwnedIndex)), Mem(varIndex))
+	})
+	p.Sstore(ownedSlot(Mem(varFrom), Mem(varLast)), Const(0))
+	p.Sstore(Keccak(Mem(varFrom), Const(slotBalances)), Mem(varLast))
+	addToken(p)
+	p.Log(nil, Topic(krc721TransferTopic), Mem(varFrom), Mem(varTo), Mem(varID))
+
+	p.If(And(Mem(varSafe), Lt(Const(0), Call(kvm.EXTCODESIZE, Mem(varTo)))), func() {
+		p.Set(varLen, Const(0))
+		p.If(Mem(varData), func() {
+			p.Set(varLen, Call(kvm.CALLDATALOAD, Mem(varData)))
+		})
+		p.Set(output, SelectorWord(krc721ReceivedSelector))
+		p.Set(output+0x04, Caller())
+		p.Set(output+0x24, Mem(varFrom))
+		p.Set(output+0x44, Mem(varID))
+		p.Set(output+0x64, Const(0x80))
+		p.Set(output+0x84, Mem(varLen))
+		p.Emit(kvm.CALLDATACOPY, Const(output+0xa4), Add(Mem(varData), Const(0x20)), Ceil32(Mem(varLen)))
+		p.Set(0, Const(0))
+		p.Require(Call(kvm.CALL, Call(kvm.GAS), Mem(varTo), Const(0), Const(output),
+			Add(Const(0xa4), Ceil32(Mem(varLen))), Const(0), Const(0x20)), "KRC721: transfer to non receiver")
+		p.Require(Eq(Shr(224, Mem(0)), Selector(krc721ReceivedSelector)), "KRC721: transfer to non receiver")
+	})
+	p.Stop()
+}
+
+// addToken gives varID to varTo
+func addToken(p *Program) {
+	p.Set(varIndex, balance(Mem(varTo)))
+	p.Sstore(ownedSlot(Mem(varTo), Mem(varIndex)), Mem(varID))
+	p.Sstore(Keccak(Mem(varID), Const(slotOwnedIndex)), Mem(varIndex))
+	p.Sstore(Keccak(Mem(varTo), Const(slotBalances)), Add(Mem(varIndex), Const(1)))
+	p.Sstore(Keccak(Mem(varID), Const(slotOwners)), Mem(varTo))
+}
+
+func mint(p *Program) {
+	p.Set(varTo, Address(Arg(0)))
+	p.Set(varID, Arg(1))
+	p.Require(Mem(varTo), "KRC721: mint to zero address")
+	p.Require(IsZero(ownerOf(Mem(varID))), "KRC721: token already minted")
+
+	p.Set(varIndex, Sload(Const(slotTotalSupply)))
+	p.Sstore(Keccak(Mem(varIndex), Const(slotAllTokens)), Mem(varID))
+	p.Sstore(Const(slotTotalSupply), Add(Mem(varIndex), Const(1)))
+	addToken(p)
+
+	// copy the URI words to storage
+	p.Set(varData, Add(Arg(2), Const(4)))
+	p.Set(varLen, Call(kvm.CALLDATALOAD, Mem(varData)))
+	p.Set(varBase, Keccak(Mem(varID), Const(slotURIs)))
+	p.Sstore(Mem(varBase), Mem(varLen))
+	p.Set(varI, Const(0))
+	p.While(Lt(Mul(Mem(varI), Const(32)), Mem(varLen)), func() {
+		p.Sstore(Add(Mem(varBase), Add(Mem(varI), Const(1))),
+			Call(kvm.CALLDATALOAD, Add(Add(Mem(varData), Const(0x20)), Mul(Mem(varI), Const(32)))))
+		p.Set(varI, Add(Mem(varI), Const(1)))
+	})
+	p.Log(nil, Topic(krc721TransferTopic), Const(0), Mem(varTo), Mem(varID))
+	p.Stop()
+}
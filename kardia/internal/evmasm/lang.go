@@ -0,0 +1,202 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package evmasm
+package evmasm
+
+import (
+	"github.com/kardiachain/go-kardia/kvm"
+	"github.com/kardiachain/go-kardia/lib/crypto"
+)
+
+// Memory below scratch is used by Keccak, memory from scratch to scratch+0x80 by
+// Revert, ReturnWord, ReturnString and Log. Programs keep their variables above it.
+const scratch = 0x80
+
+// Const pushes v, see Program.Push
+func Const(v interface{}) Expr {
+	return func(p *Program) { p.Push(v) }
+}
+
+// Selector pushes the 4 bytes selector of the method signature sig
+func Selector(sig string) Expr {
+	return Const(crypto.Keccak256([]byte(sig))[:4])
+}
+
+// SelectorWord pushes the selector of the method signature sig followed by 28 zero bytes,
+// the first word of calls of the method
+func SelectorWord(sig string) Expr {
+	return Const(append(crypto.Keccak256([]byte(sig))[:4], make([]byte, 28)...))
+}
+
+// Topic pushes the topic of the event signature sig
+func Topic(sig string) Expr {
+	return Const(crypto.Keccak256([]byte(sig)))
+}
+
+// Call pushes the result of op applied to args, args[0] being the top of the stack
+func Call(op kvm.OpCode, args ...Expr) Expr {
+	return func(p *Program) { p.Emit(op, args...) }
+}
+
+func Add(a, b Expr) Expr { return Call(kvm.ADD, a, b) }
+func Sub(a, b Expr) Expr { return Call(kvm.SUB, a, b) }
+func Mul(a, b Expr) Expr { return Call(kvm.MUL, a, b) }
+func Lt(a, b Expr) Expr  { return Call(kvm.LT, a, b) }
+func Eq(a, b Expr) Expr  { return Call(kvm.EQ, a, b) }
+func And(a, b Expr) Expr { return Call(kvm.AND, a, b) }
+func Or(a, b Expr) Expr  { return Call(kvm.OR, a, b) }
+func IsZero(a Expr) Expr { return Call(kvm.ISZERO, a) }
+
+// Shr pushes a shifted right by n bits
+func Shr(n int, a Expr) Expr { return Call(kvm.SHR, Const(n), a) }
+
+// Ceil32 pushes a rounded up to a multiple of 32
+func Ceil32(a Expr) Expr {
+	return And(Add(a, Const(31)), Call(kvm.NOT, Const(31)))
+}
+
+// Mem pushes the word of memory at addr
+func Mem(addr int) Expr { return Call(kvm.MLOAD, Const(addr)) }
+
+// Arg pushes the i-th word of the call arguments
+func Arg(i int) Expr { return Call(kvm.CALLDATALOAD, Const(4+32*i)) }
+
+// Address pushes a with the bits above 160 cleared
+func Address(a Expr) Expr {
+	return And(a, Const(append(make([]byte, 12), []byte{
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}...)))
+}
+
+// Caller pushes the sender of the call
+func Caller() Expr { return Call(kvm.CALLER) }
+
+// Sload pushes the word stored at slot
+func Sload(slot Expr) Expr { return Call(kvm.SLOAD, slot) }
+
+// Keccak pushes keccak256(key . base), the slot of key in a mapping stored at base
+func Keccak(key, base Expr) Expr {
+	return func(p *Program) {
+		base(p)
+		key(p)
+		p.Push(0)
+		p.Op(kvm.MSTORE)
+		p.Push(0x20)
+		p.Op(kvm.MSTORE)
+		p.Push(0x40)
+		p.Push(0)
+		p.Op(kvm.SHA3)
+	}
+}
+
+// Emit emits op applied to args, args[0] being the top of the stack
+func (p *Program) Emit(op kvm.OpCode, args ...Expr) {
+	for i := len(args) - 1; i >= 0; i-- {
+		args[i](p)
+	}
+	p.Op(op)
+}
+
+// Set stores the value of e in memory at addr
+func (p *Program) Set(addr int, e Expr) {
+	p.Emit(kvm.MSTORE, Const(addr), e)
+}
+
+// Sstore stores value at slot
+func (p *Program) Sstore(slot, value Expr) {
+	p.Emit(kvm.SSTORE, slot, value)
+}
+
+// If runs then when cond is not zero
+func (p *Program) If(cond Expr, then func()) {
+	end := p.NewLabel("endif")
+	IsZero(cond)(p)
+	p.JumpIf(end)
+	then()
+	p.Label(end)
+}
+
+// While runs body as long as cond is not zero
+func (p *Program) While(cond Expr, body func()) {
+	start, end := p.NewLabel("while"), p.NewLabel("endwhile")
+	p.Label(start)
+	IsZero(cond)(p)
+	p.JumpIf(end)
+	body()
+	p.Jump(start)
+	p.Label(end)
+}
+
+// Require reverts with reason when cond is zero
+func (p *Program) Require(cond Expr, reason string) {
+	ok := p.NewLabel("require")
+	cond(p)
+	p.JumpIf(ok)
+	p.Revert(reason)
+	p.Label(ok)
+}
+
+// Revert reverts with Error(reason), reason is at most 32 bytes
+func (p *Program) Revert(reason string) {
+	if len(reason) > 32 {
+		panic("evmasm: revert reason exceeds 32 bytes")
+	}
+	p.Set(scratch, SelectorWord("Error(string)"))
+	p.Set(scratch+0x04, Const(0x20))
+	p.Set(scratch+0x24, Const(len(reason)))
+	p.Set(scratch+0x44, Const(padRight(reason)))
+	p.Emit(kvm.REVERT, Const(scratch), Const(0x64))
+}
+
+// ReturnWord returns the value of e
+func (p *Program) ReturnWord(e Expr) {
+	p.Set(scratch, e)
+	p.Emit(kvm.RETURN, Const(scratch), Const(0x20))
+}
+
+// ReturnString returns the ABI encoded s, s is at most 32 bytes
+func (p *Program) ReturnString(s string) {
+	if len(s) > 32 {
+		panic("evmasm: returned string exceeds 32 bytes")
+	}
+	p.Set(scratch, Const(0x20))
+	p.Set(scratch+0x20, Const(len(s)))
+	p.Set(scratch+0x40, Const(padRight(s)))
+	p.Emit(kvm.RETURN, Const(scratch), Const(0x60))
+}
+
+// Log emits a log of topics with the word data, or no data if nil
+func (p *Program) Log(data Expr, topics ...Expr) {
+	size := 0
+	if data != nil {
+		p.Set(scratch, data)
+		size = 0x20
+	}
+	p.Emit(kvm.LOG0+kvm.OpCode(len(topics)), append([]Expr{Const(scratch), Const(size)}, topics...)...)
+}
+
+// Stop ends the call successfully without output
+func (p *Program) Stop() {
+	p.Op(kvm.STOP)
+}
+
+func padRight(s string) []byte {
+	b := make([]byte, 32)
+	copy(b, s)
+	return b
+}
@@ -0,0 +1,71 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package evmasm
+package evmasm
+
+import (
+	"github.com/kardiachain/go-kardia/kvm"
+)
+
+// Multicall returns the creation code of an aggregator implementing tryAggregate(bool requireSuccess,
+// (address target, bytes callData)[] calls) returns ((bool success, bytes returnData)[]) of Multicall2.
+func Multicall() ([]byte, error) {
+	p := NewProgram()
+	dispatch(p, []method{
+		{"tryAggregate(bool,(address,bytes)[])", tryAggregate},
+	})
+
+	runtime, err := p.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	return Deployment(runtime), nil
+}
+
+// tryAggregate runs the calls in order. The results are encoded at output, the input of each call is copied
+// where its result is written after the call.
+func tryAggregate(p *Program) {
+	p.Set(varData, Add(Arg(1), Const(4)))
+	p.Set(varLen, Call(kvm.CALLDATALOAD, Mem(varData)))
+	p.Set(output, Const(0x20))
+	p.Set(output+0x20, Mem(varLen))
+	p.Set(varCursor, Mul(Mem(varLen), Const(32)))
+	p.Set(varI, Const(0))
+	p.While(Lt(Mem(varI), Mem(varLen)), func() {
+		p.Set(varElem, Add(Add(Mem(varData), Const(0x20)), element(Mem(varData), Mem(varI))))
+		p.Set(varTo, Address(Call(kvm.CALLDATALOAD, Mem(varElem))))
+		p.Set(varBase, Add(Mem(varElem), Call(kvm.CALLDATALOAD, Add(Mem(varElem), Const(0x20)))))
+		p.Set(varOut, Add(Const(output+0x40), Mem(varCursor)))
+		p.Emit(kvm.MSTORE, Add(Const(output+0x40), Mul(Mem(varI), Const(32))), Mem(varCursor))
+
+		p.Set(varTmp, Call(kvm.CALLDATALOAD, Mem(varBase)))
+		p.Emit(kvm.CALLDATACOPY, Add(Mem(varOut), Const(0x60)), Add(Mem(varBase), Const(0x20)), Mem(varTmp))
+		p.Set(varSuccess, Call(kvm.CALL, Call(kvm.GAS), Mem(varTo), Const(0), Add(Mem(varOut), Const(0x60)), Mem(varTmp), Const(0), Const(0)))
+		p.Require(Or(Mem(varSuccess), IsZero(Arg(0))), "Multicall: call failed")
+
+		p.Emit(kvm.MSTORE, Mem(varOut), Mem(varSuccess))
+		p.Emit(kvm.MSTORE, Add(Mem(varOut), Const(0x20)), Const(0x40))
+		p.Emit(kvm.MSTORE, Add(Mem(varOut), Const(0x40)), Call(kvm.RETURNDATASIZE))
+		p.Emit(kvm.RETURNDATACOPY, Add(Mem(varOut), Const(0x60)), Const(0), Call(kvm.RETURNDATASIZE))
+		// clear the padding of the returned data
+		p.Emit(kvm.MSTORE, Add(Add(Mem(varOut), Const(0x60)), Call(kvm.RETURNDATASIZE)), Const(0))
+		p.Set(varCursor, Add(Mem(varCursor), Add(Const(0x60), Ceil32(Call(kvm.RETURNDATASIZE)))))
+		p.Set(varI, Add(Mem(varI), Const(1)))
+	})
+	p.Emit(kvm.RETURN, Const(output), Add(Const(0x40), Mem(varCursor)))
+}
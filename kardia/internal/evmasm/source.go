@@ -0,0 +1,54 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package evmasm
+package evmasm
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"go/format"
+)
+
+// contracts are the test contracts written to package smc, by constant name
+var contracts = []struct {
+	name     string
+	doc      string
+	assemble func() ([]byte, error)
+}{
+	{"KRC721Bytecode", "KRC721Bytecode deploys the KRC721 test token \"Kardia Test NFT\" (KTN), which implements KRC721ABI\n" +
+		"// with the metadata and enumerable extensions, and mint(address to, uint256 tokenId, string uri) open to anyone.", KRC721},
+	{"KRC1155Bytecode", "KRC1155Bytecode deploys the KRC1155 test token, which implements KRC1155ABI with the metadata URI\n" +
+		"// extension, and mint(address to, uint256 id, uint256 amount) open to anyone.", KRC1155},
+	{"MulticallBytecode", "MulticallBytecode deploys an aggregator implementing the tryAggregate method of MulticallABI.", Multicall},
+}
+
+// Source returns the Go source of package smc declaring the bytecode of the test contracts
+func Source() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by internal/evmasm/gen. DO NOT EDIT.\n\npackage smc\n\nconst (\n")
+	for _, c := range contracts {
+		code, err := c.assemble()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", c.name, err)
+		}
+		fmt.Fprintf(&buf, "\t// %s\n\t%s = %q\n", c.doc, c.name, hex.EncodeToString(code))
+	}
+	buf.WriteString(")\n")
+	return format.Source(buf.Bytes())
+}
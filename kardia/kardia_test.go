@@ -20,6 +20,10 @@ package kardia
 
 import (
 	"crypto/ecdsa"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
 
 	"github.com/kardiachain/go-kardia/lib/crypto"
 	"github.com/kardiachain/go-kardia/rpc"
@@ -72,6 +76,74 @@ func setupTestNodeInstance() (*node, error) {
 	return node, nil
 }
 
+// setupMockNodeInstance return node backed by an in-process RPC server
+// which serves given services, for offline test
+func setupMockNodeInstance(services map[string]interface{}) (*node, error) {
+	lgr, err := zap.NewDevelopment()
+	if err != nil {
+		return nil, err
+	}
+	server := rpc.NewServer()
+	for name, service := range services {
+		if err := server.RegisterName(name, service); err != nil {
+			return nil, err
+		}
+	}
+	node := &node{
+		client: rpc.DialInProc(server),
+		url:    "inproc",
+		lgr:    lgr,
+	}
+	return node, nil
+}
+
+// setupMockWSNodeInstance return node connected through websocket to a local RPC server
+// which serves given services, and a func to drop every open connection
+func setupMockWSNodeInstance(services map[string]interface{}) (*node, func(), error) {
+	lgr, err := zap.NewDevelopment()
+	if err != nil {
+		return nil, nil, err
+	}
+	server := rpc.NewServer()
+	for name, service := range services {
+		if err := server.RegisterName(name, service); err != nil {
+			return nil, nil, err
+		}
+	}
+	var (
+		mu    sync.Mutex
+		conns []net.Conn
+	)
+	httpServer := httptest.NewUnstartedServer(server.WebsocketHandler([]string{"*"}))
+	httpServer.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateHijacked {
+			mu.Lock()
+			conns = append(conns, conn)
+			mu.Unlock()
+		}
+	}
+	httpServer.Start()
+	drop := func() {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, conn := range conns {
+			_ = conn.Close()
+		}
+		conns = nil
+	}
+	wsUrl := "ws://" + httpServer.Listener.Addr().String()
+	rpcClient, err := rpc.Dial(wsUrl)
+	if err != nil {
+		return nil, nil, err
+	}
+	node := &node{
+		client: rpcClient,
+		url:    wsUrl,
+		lgr:    lgr,
+	}
+	return node, drop, nil
+}
+
 func setupTestAccount() (*ecdsa.PublicKey, *ecdsa.PrivateKey, error) {
 	privateKey, err := crypto.HexToECDSA("63e16b5334e76d63ee94f35bd2a81c721ebbbb27e81620be6fc1c448c767eed9")
 	if err != nil {
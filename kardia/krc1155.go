@@ -0,0 +1,132 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package kardia
+package kardia
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/kardiachain/go-kardia/lib/abi/bind"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/event"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+// KRC1155Token is a KRC1155 multi token, with the balances and transfers of the standard on top of the
+// reads of Token. Logs of the token are decoded by UnpackLog and NewFilter with its ABI.
+type KRC1155Token interface {
+	Token
+	BalanceOf(ctx context.Context, account common.Address, id *big.Int) (*big.Int, error)
+	// BalanceOfBatch returns the balance of accounts[i] in ids[i], for each i
+	BalanceOfBatch(ctx context.Context, accounts []common.Address, ids []*big.Int) ([]*big.Int, error)
+	// URI returns the metadata URI of id, with the {id} placeholder replaced as described by the standard
+	URI(ctx context.Context, id *big.Int) (string, error)
+	IsApprovedForAll(ctx context.Context, account, operator common.Address) (bool, error)
+
+	SetApprovalForAll(opts *bind.TransactOpts, operator common.Address, approved bool) (*types.Transaction, error)
+	// SafeTransferFrom transfers amount of id and checks that a contract receiver accepts it, data is passed to its
+	// onERC1155Received and may be nil
+	SafeTransferFrom(opts *bind.TransactOpts, from, to common.Address, id, amount *big.Int, data []byte) (*types.Transaction, error)
+	SafeBatchTransferFrom(opts *bind.TransactOpts, from, to common.Address, ids, amounts []*big.Int, data []byte) (*types.Transaction, error)
+
+	FilterTransferSingle(opts *bind.FilterOpts, operator, from, to []common.Address) (*KRC1155TransferSingleIterator, error)
+	WatchTransferSingle(opts *bind.WatchOpts, sink chan<- *KRC1155TransferSingle, operator, from, to []common.Address) (event.Subscription, error)
+	FilterTransferBatch(opts *bind.FilterOpts, operator, from, to []common.Address) (*KRC1155TransferBatchIterator, error)
+	WatchTransferBatch(opts *bind.WatchOpts, sink chan<- *KRC1155TransferBatch, operator, from, to []common.Address) (event.Subscription, error)
+}
+
+type krc1155Token struct {
+	*token
+	contract *KRC1155Contract
+}
+
+// NewKRC1155Token binds the KRC1155 token deployed at address
+func NewKRC1155Token(node Node, address string) (KRC1155Token, error) {
+	krc1155ABI, err := KRC1155ABI()
+	if err != nil {
+		return nil, err
+	}
+	contract := NewBoundContract(node, krc1155ABI, common.HexToAddress(address))
+	return &krc1155Token{
+		token: &token{
+			node:    node,
+			c:       &Contract{Abi: krc1155ABI, ContractAddress: contract.ContractAddress},
+			krcType: TokenTypeKRC1155,
+		},
+		contract: &KRC1155Contract{contract},
+	}, nil
+}
+
+func (t *krc1155Token) BalanceOf(ctx context.Context, account common.Address, id *big.Int) (*big.Int, error) {
+	return t.contract.BalanceOf(&bind.CallOpts{Context: ctx}, account, id)
+}
+
+func (t *krc1155Token) BalanceOfBatch(ctx context.Context, accounts []common.Address, ids []*big.Int) ([]*big.Int, error) {
+	return t.contract.BalanceOfBatch(&bind.CallOpts{Context: ctx}, accounts, ids)
+}
+
+func (t *krc1155Token) URI(ctx context.Context, id *big.Int) (string, error) {
+	uri, err := t.contract.Uri(&bind.CallOpts{Context: ctx}, id)
+	if err != nil {
+		return "", err
+	}
+	return KRC1155TokenURI(uri, id), nil
+}
+
+func (t *krc1155Token) IsApprovedForAll(ctx context.Context, account, operator common.Address) (bool, error) {
+	return t.contract.IsApprovedForAll(&bind.CallOpts{Context: ctx}, account, operator)
+}
+
+func (t *krc1155Token) SetApprovalForAll(opts *bind.TransactOpts, operator common.Address, approved bool) (*types.Transaction, error) {
+	return t.contract.SetApprovalForAll(opts, operator, approved)
+}
+
+func (t *krc1155Token) SafeTransferFrom(opts *bind.TransactOpts, from, to common.Address, id, amount *big.Int, data []byte) (*types.Transaction, error) {
+	return t.contract.SafeTransferFrom(opts, from, to, id, amount, data)
+}
+
+func (t *krc1155Token) SafeBatchTransferFrom(opts *bind.TransactOpts, from, to common.Address, ids, amounts []*big.Int, data []byte) (*types.Transaction, error) {
+	if len(ids) != len(amounts) {
+		return nil, fmt.Errorf("%w: %d ids, %d amounts", ErrLengthMismatch, len(ids), len(amounts))
+	}
+	return t.contract.SafeBatchTransferFrom(opts, from, to, ids, amounts, data)
+}
+
+func (t *krc1155Token) FilterTransferSingle(opts *bind.FilterOpts, operator, from, to []common.Address) (*KRC1155TransferSingleIterator, error) {
+	return t.contract.FilterTransferSingle(opts, operator, from, to)
+}
+
+func (t *krc1155Token) WatchTransferSingle(opts *bind.WatchOpts, sink chan<- *KRC1155TransferSingle, operator, from, to []common.Address) (event.Subscription, error) {
+	return t.contract.WatchTransferSingle(opts, sink, operator, from, to)
+}
+
+func (t *krc1155Token) FilterTransferBatch(opts *bind.FilterOpts, operator, from, to []common.Address) (*KRC1155TransferBatchIterator, error) {
+	return t.contract.FilterTransferBatch(opts, operator, from, to)
+}
+
+func (t *krc1155Token) WatchTransferBatch(opts *bind.WatchOpts, sink chan<- *KRC1155TransferBatch, operator, from, to []common.Address) (event.Subscription, error) {
+	return t.contract.WatchTransferBatch(opts, sink, operator, from, to)
+}
+
+// KRC1155TokenURI replaces the {id} placeholder of uri with the lowercase hex id, padded to 64 characters
+func KRC1155TokenURI(uri string, id *big.Int) string {
+	return strings.Replace(uri, "{id}", fmt.Sprintf("%064x", id), -1)
+}
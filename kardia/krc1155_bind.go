@@ -0,0 +1,405 @@
+// Code generated by kaibind. DO NOT EDIT.
+
+package kardia
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/kardiachain/go-kardia/lib/abi"
+	"github.com/kardiachain/go-kardia/lib/abi/bind"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/event"
+	"github.com/kardiachain/go-kardia/types"
+
+	"github.com/kardiachain/go-kaiclient/kardia/smc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = abi.JSON
+	_ = bind.NewBoundContract
+	_ = common.Big1
+	_ = event.NewSubscription
+	_ = types.BloomLookup
+)
+
+// KRC1155Contract is a typed binding of the contract, on top of BoundContract.
+type KRC1155Contract struct {
+	*BoundContract
+}
+
+// NewKRC1155Contract binds the contract deployed at address.
+func NewKRC1155Contract(node Node, address common.Address) (*KRC1155Contract, error) {
+	parsed, err := abi.JSON(strings.NewReader(smc.KRC1155ABI))
+	if err != nil {
+		return nil, err
+	}
+	return &KRC1155Contract{NewBoundContract(node, &parsed, address)}, nil
+}
+
+// DeployKRC1155Contract deploys the contract and binds it.
+func DeployKRC1155Contract(auth *bind.TransactOpts, node Node) (common.Address, *types.Transaction, *KRC1155Contract, error) {
+	parsed, err := abi.JSON(strings.NewReader(smc.KRC1155ABI))
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	address, tx, _, err := bind.DeployContract(auth, parsed, common.FromHex(smc.KRC1155Bytecode), node)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	c := &KRC1155Contract{NewBoundContract(node, &parsed, address)}
+	c.BoundContract.Bytecode = smc.KRC1155Bytecode
+	return address, tx, c, nil
+}
+
+// BalanceOf calls function balanceOf(address account, uint256 id) view returns(uint256).
+func (c *KRC1155Contract) BalanceOf(opts *bind.CallOpts, account common.Address, id *big.Int) (*big.Int, error) {
+	var out *big.Int
+	err := c.BoundContract.Call(opts, &out, "balanceOf", account, id)
+	return out, err
+}
+
+// BalanceOfBatch calls function balanceOfBatch(address[] accounts, uint256[] ids) view returns(uint256[]).
+func (c *KRC1155Contract) BalanceOfBatch(opts *bind.CallOpts, accounts []common.Address, ids []*big.Int) ([]*big.Int, error) {
+	var out []*big.Int
+	err := c.BoundContract.Call(opts, &out, "balanceOfBatch", accounts, ids)
+	return out, err
+}
+
+// IsApprovedForAll calls function isApprovedForAll(address account, address operator) view returns(bool).
+func (c *KRC1155Contract) IsApprovedForAll(opts *bind.CallOpts, account common.Address, operator common.Address) (bool, error) {
+	var out bool
+	err := c.BoundContract.Call(opts, &out, "isApprovedForAll", account, operator)
+	return out, err
+}
+
+// SupportsInterface calls function supportsInterface(bytes4 interfaceId) view returns(bool).
+func (c *KRC1155Contract) SupportsInterface(opts *bind.CallOpts, interfaceId [4]byte) (bool, error) {
+	var out bool
+	err := c.BoundContract.Call(opts, &out, "supportsInterface", interfaceId)
+	return out, err
+}
+
+// Uri calls function uri(uint256 id) view returns(string).
+func (c *KRC1155Contract) Uri(opts *bind.CallOpts, id *big.Int) (string, error) {
+	var out string
+	err := c.BoundContract.Call(opts, &out, "uri", id)
+	return out, err
+}
+
+// SafeBatchTransferFrom sends a transaction calling function safeBatchTransferFrom(address from, address to, uint256[] ids, uint256[] amounts, bytes data) returns().
+func (c *KRC1155Contract) SafeBatchTransferFrom(opts *bind.TransactOpts, from common.Address, to common.Address, ids []*big.Int, amounts []*big.Int, data []byte) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "safeBatchTransferFrom", from, to, ids, amounts, data)
+}
+
+// SafeTransferFrom sends a transaction calling function safeTransferFrom(address from, address to, uint256 id, uint256 amount, bytes data) returns().
+func (c *KRC1155Contract) SafeTransferFrom(opts *bind.TransactOpts, from common.Address, to common.Address, id *big.Int, amount *big.Int, data []byte) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "safeTransferFrom", from, to, id, amount, data)
+}
+
+// SetApprovalForAll sends a transaction calling function setApprovalForAll(address operator, bool approved) returns().
+func (c *KRC1155Contract) SetApprovalForAll(opts *bind.TransactOpts, operator common.Address, approved bool) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "setApprovalForAll", operator, approved)
+}
+
+// KRC1155ApprovalForAll is the ApprovalForAll event of KRC1155Contract.
+type KRC1155ApprovalForAll struct {
+	Account  common.Address
+	Operator common.Address
+	Approved bool
+	Raw      types.Log
+}
+
+// KRC1155ApprovalForAllIterator iterates over the ApprovalForAll events returned by KRC1155Contract.FilterApprovalForAll.
+type KRC1155ApprovalForAllIterator struct {
+	// Event is the event the iterator is at
+	Event *KRC1155ApprovalForAll
+
+	*EventIterator
+}
+
+// FilterApprovalForAll iterates over past logs of event ApprovalForAll(address indexed account, address indexed operator, bool approved).
+func (c *KRC1155Contract) FilterApprovalForAll(opts *bind.FilterOpts, account []common.Address, operator []common.Address) (*KRC1155ApprovalForAllIterator, error) {
+	var accountRule []interface{}
+	for _, item := range account {
+		accountRule = append(accountRule, item)
+	}
+	var operatorRule []interface{}
+	for _, item := range operator {
+		operatorRule = append(operatorRule, item)
+	}
+	it := new(KRC1155ApprovalForAllIterator)
+	var err error
+	it.EventIterator, err = c.BoundContract.FilterEvent(opts, "ApprovalForAll", func(log types.Log) error {
+		ev, err := c.ParseApprovalForAll(log)
+		it.Event = ev
+		return err
+	}, accountRule, operatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return it, nil
+}
+
+// WatchApprovalForAll sends new logs of event ApprovalForAll(address indexed account, address indexed operator, bool approved) to sink.
+func (c *KRC1155Contract) WatchApprovalForAll(opts *bind.WatchOpts, sink chan<- *KRC1155ApprovalForAll, account []common.Address, operator []common.Address) (event.Subscription, error) {
+	var accountRule []interface{}
+	for _, item := range account {
+		accountRule = append(accountRule, item)
+	}
+	var operatorRule []interface{}
+	for _, item := range operator {
+		operatorRule = append(operatorRule, item)
+	}
+	return c.BoundContract.WatchEvent(opts, "ApprovalForAll", func(log types.Log, quit <-chan struct{}) error {
+		ev, err := c.ParseApprovalForAll(log)
+		if err != nil {
+			return err
+		}
+		select {
+		case sink <- ev:
+		case <-quit:
+		}
+		return nil
+	}, accountRule, operatorRule)
+}
+
+// ParseApprovalForAll unpacks a log of event ApprovalForAll(address indexed account, address indexed operator, bool approved).
+func (c *KRC1155Contract) ParseApprovalForAll(log types.Log) (*KRC1155ApprovalForAll, error) {
+	ev := new(KRC1155ApprovalForAll)
+	if err := c.BoundContract.UnpackLog(ev, "ApprovalForAll", log); err != nil {
+		return nil, err
+	}
+	ev.Raw = log
+	return ev, nil
+}
+
+// KRC1155TransferBatch is the TransferBatch event of KRC1155Contract.
+type KRC1155TransferBatch struct {
+	Operator common.Address
+	From     common.Address
+	To       common.Address
+	Ids      []*big.Int
+	Values   []*big.Int
+	Raw      types.Log
+}
+
+// KRC1155TransferBatchIterator iterates over the TransferBatch events returned by KRC1155Contract.FilterTransferBatch.
+type KRC1155TransferBatchIterator struct {
+	// Event is the event the iterator is at
+	Event *KRC1155TransferBatch
+
+	*EventIterator
+}
+
+// FilterTransferBatch iterates over past logs of event TransferBatch(address indexed operator, address indexed from, address indexed to, uint256[] ids, uint256[] values).
+func (c *KRC1155Contract) FilterTransferBatch(opts *bind.FilterOpts, operator []common.Address, from []common.Address, to []common.Address) (*KRC1155TransferBatchIterator, error) {
+	var operatorRule []interface{}
+	for _, item := range operator {
+		operatorRule = append(operatorRule, item)
+	}
+	var fromRule []interface{}
+	for _, item := range from {
+		fromRule = append(fromRule, item)
+	}
+	var toRule []interface{}
+	for _, item := range to {
+		toRule = append(toRule, item)
+	}
+	it := new(KRC1155TransferBatchIterator)
+	var err error
+	it.EventIterator, err = c.BoundContract.FilterEvent(opts, "TransferBatch", func(log types.Log) error {
+		ev, err := c.ParseTransferBatch(log)
+		it.Event = ev
+		return err
+	}, operatorRule, fromRule, toRule)
+	if err != nil {
+		return nil, err
+	}
+	return it, nil
+}
+
+// WatchTransferBatch sends new logs of event TransferBatch(address indexed operator, address indexed from, address indexed to, uint256[] ids, uint256[] values) to sink.
+func (c *KRC1155Contract) WatchTransferBatch(opts *bind.WatchOpts, sink chan<- *KRC1155TransferBatch, operator []common.Address, from []common.Address, to []common.Address) (event.Subscription, error) {
+	var operatorRule []interface{}
+	for _, item := range operator {
+		operatorRule = append(operatorRule, item)
+	}
+	var fromRule []interface{}
+	for _, item := range from {
+		fromRule = append(fromRule, item)
+	}
+	var toRule []interface{}
+	for _, item := range to {
+		toRule = append(toRule, item)
+	}
+	return c.BoundContract.WatchEvent(opts, "TransferBatch", func(log types.Log, quit <-chan struct{}) error {
+		ev, err := c.ParseTransferBatch(log)
+		if err != nil {
+			return err
+		}
+		select {
+		case sink <- ev:
+		case <-quit:
+		}
+		return nil
+	}, operatorRule, fromRule, toRule)
+}
+
+// ParseTransferBatch unpacks a log of event TransferBatch(address indexed operator, address indexed from, address indexed to, uint256[] ids, uint256[] values).
+func (c *KRC1155Contract) ParseTransferBatch(log types.Log) (*KRC1155TransferBatch, error) {
+	ev := new(KRC1155TransferBatch)
+	if err := c.BoundContract.UnpackLog(ev, "TransferBatch", log); err != nil {
+		return nil, err
+	}
+	ev.Raw = log
+	return ev, nil
+}
+
+// KRC1155TransferSingle is the TransferSingle event of KRC1155Contract.
+type KRC1155TransferSingle struct {
+	Operator common.Address
+	From     common.Address
+	To       common.Address
+	Id       *big.Int
+	Value    *big.Int
+	Raw      types.Log
+}
+
+// KRC1155TransferSingleIterator iterates over the TransferSingle events returned by KRC1155Contract.FilterTransferSingle.
+type KRC1155TransferSingleIterator struct {
+	// Event is the event the iterator is at
+	Event *KRC1155TransferSingle
+
+	*EventIterator
+}
+
+// FilterTransferSingle iterates over past logs of event TransferSingle(address indexed operator, address indexed from, address indexed to, uint256 id, uint256 value).
+func (c *KRC1155Contract) FilterTransferSingle(opts *bind.FilterOpts, operator []common.Address, from []common.Address, to []common.Address) (*KRC1155TransferSingleIterator, error) {
+	var operatorRule []interface{}
+	for _, item := range operator {
+		operatorRule = append(operatorRule, item)
+	}
+	var fromRule []interface{}
+	for _, item := range from {
+		fromRule = append(fromRule, item)
+	}
+	var toRule []interface{}
+	for _, item := range to {
+		toRule = append(toRule, item)
+	}
+	it := new(KRC1155TransferSingleIterator)
+	var err error
+	it.EventIterator, err = c.BoundContract.FilterEvent(opts, "TransferSingle", func(log types.Log) error {
+		ev, err := c.ParseTransferSingle(log)
+		it.Event = ev
+		return err
+	}, operatorRule, fromRule, toRule)
+	if err != nil {
+		return nil, err
+	}
+	return it, nil
+}
+
+// WatchTransferSingle sends new logs of event TransferSingle(address indexed operator, address indexed from, address indexed to, uint256 id, uint256 value) to sink.
+func (c *KRC1155Contract) WatchTransferSingle(opts *bind.WatchOpts, sink chan<- *KRC1155TransferSingle, operator []common.Address, from []common.Address, to []common.Address) (event.Subscription, error) {
+	var operatorRule []interface{}
+	for _, item := range operator {
+		operatorRule = append(operatorRule, item)
+	}
+	var fromRule []interface{}
+	for _, item := range from {
+		fromRule = append(fromRule, item)
+	}
+	var toRule []interface{}
+	for _, item := range to {
+		toRule = append(toRule, item)
+	}
+	return c.BoundContract.WatchEvent(opts, "TransferSingle", func(log types.Log, quit <-chan struct{}) error {
+		ev, err := c.ParseTransferSingle(log)
+		if err != nil {
+			return err
+		}
+		select {
+		case sink <- ev:
+		case <-quit:
+		}
+		return nil
+	}, operatorRule, fromRule, toRule)
+}
+
+// ParseTransferSingle unpacks a log of event TransferSingle(address indexed operator, address indexed from, address indexed to, uint256 id, uint256 value).
+func (c *KRC1155Contract) ParseTransferSingle(log types.Log) (*KRC1155TransferSingle, error) {
+	ev := new(KRC1155TransferSingle)
+	if err := c.BoundContract.UnpackLog(ev, "TransferSingle", log); err != nil {
+		return nil, err
+	}
+	ev.Raw = log
+	return ev, nil
+}
+
+// KRC1155URI is the URI event of KRC1155Contract.
+type KRC1155URI struct {
+	Value string
+	Id    *big.Int
+	Raw   types.Log
+}
+
+// KRC1155URIIterator iterates over the URI events returned by KRC1155Contract.FilterURI.
+type KRC1155URIIterator struct {
+	// Event is the event the iterator is at
+	Event *KRC1155URI
+
+	*EventIterator
+}
+
+// FilterURI iterates over past logs of event URI(string value, uint256 indexed id).
+func (c *KRC1155Contract) FilterURI(opts *bind.FilterOpts, id []*big.Int) (*KRC1155URIIterator, error) {
+	var idRule []interface{}
+	for _, item := range id {
+		idRule = append(idRule, item)
+	}
+	it := new(KRC1155URIIterator)
+	var err error
+	it.EventIterator, err = c.BoundContract.FilterEvent(opts, "URI", func(log types.Log) error {
+		ev, err := c.ParseURI(log)
+		it.Event = ev
+		return err
+	}, idRule)
+	if err != nil {
+		return nil, err
+	}
+	return it, nil
+}
+
+// WatchURI sends new logs of event URI(string value, uint256 indexed id) to sink.
+func (c *KRC1155Contract) WatchURI(opts *bind.WatchOpts, sink chan<- *KRC1155URI, id []*big.Int) (event.Subscription, error) {
+	var idRule []interface{}
+	for _, item := range id {
+		idRule = append(idRule, item)
+	}
+	return c.BoundContract.WatchEvent(opts, "URI", func(log types.Log, quit <-chan struct{}) error {
+		ev, err := c.ParseURI(log)
+		if err != nil {
+			return err
+		}
+		select {
+		case sink <- ev:
+		case <-quit:
+		}
+		return nil
+	}, idRule)
+}
+
+// ParseURI unpacks a log of event URI(string value, uint256 indexed id).
+func (c *KRC1155Contract) ParseURI(log types.Log) (*KRC1155URI, error) {
+	ev := new(KRC1155URI)
+	if err := c.BoundContract.UnpackLog(ev, "URI", log); err != nil {
+		return nil, err
+	}
+	ev.Raw = log
+	return ev, nil
+}
@@ -0,0 +1,251 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package kardia
+package kardia
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/kardiachain/go-kardia/kvm"
+	"github.com/kardiachain/go-kardia/lib/abi"
+	"github.com/kardiachain/go-kardia/lib/abi/bind"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kardiachain/go-kaiclient/kardia/internal/evmasm"
+)
+
+// testKRC1155MintABI is the mint method of the test token of smc.KRC1155Bytecode
+const testKRC1155MintABI = `[{"type":"function","name":"mint","stateMutability":"nonpayable","inputs":[{"name":"to","type":"address"},{"name":"id","type":"uint256"},{"name":"amount","type":"uint256"}],"outputs":[]}]`
+
+// testKRC1155ReceiverABI are the methods called by KRC1155 tokens on contract receivers
+const testKRC1155ReceiverABI = `[
+{"type":"function","name":"onERC1155Received","stateMutability":"nonpayable","inputs":[{"name":"operator","type":"address"},{"name":"from","type":"address"},{"name":"id","type":"uint256"},{"name":"value","type":"uint256"},{"name":"data","type":"bytes"}],"outputs":[{"name":"","type":"bytes4"}]},
+{"type":"function","name":"onERC1155BatchReceived","stateMutability":"nonpayable","inputs":[{"name":"operator","type":"address"},{"name":"from","type":"address"},{"name":"ids","type":"uint256[]"},{"name":"values","type":"uint256[]"},{"name":"data","type":"bytes"}],"outputs":[{"name":"","type":"bytes4"}]}]`
+
+// setupSimulatedKRC1155 deploys the KRC1155 test token and mints amounts[i] of token i+1 to the test account
+func setupSimulatedKRC1155(t *testing.T, amounts ...int64) (Node, *bind.TransactOpts, KRC1155Token, func()) {
+	b, node, auth := setupSimulatedNode(t)
+	ctx := context.Background()
+	address, txHash, err := node.DeployKRC1155(auth)
+	assert.Nil(t, err)
+	_, err = node.WaitMined(ctx, txHash.Hex())
+	assert.Nil(t, err)
+
+	mintABI, err := abi.JSON(strings.NewReader(testKRC1155MintABI))
+	assert.Nil(t, err)
+	minter := NewBoundContract(node, &mintABI, address)
+	for i, amount := range amounts {
+		tx, err := minter.Transact(auth, "mint", auth.From, big.NewInt(int64(i+1)), big.NewInt(amount))
+		assert.Nil(t, err)
+		_, err = node.WaitMined(ctx, tx.Hash().Hex())
+		assert.Nil(t, err)
+	}
+	token, err := NewKRC1155Token(node, address.Hex())
+	assert.Nil(t, err)
+	return node, auth, token, b.Close
+}
+
+// deployTestContract deploys runtime as a contract
+func deployTestContract(t *testing.T, node Node, auth *bind.TransactOpts, p *evmasm.Program) common.Address {
+	runtime, err := p.Bytes()
+	assert.Nil(t, err)
+	address, tx, _, err := bind.DeployContract(auth, abi.ABI{}, evmasm.Deployment(runtime), node)
+	assert.Nil(t, err)
+	_, err = node.WaitMined(context.Background(), tx.Hash().Hex())
+	assert.Nil(t, err)
+	return address
+}
+
+func TestKRC1155Token_Balances(t *testing.T) {
+	node, auth, token, closeFn := setupSimulatedKRC1155(t, 100, 50)
+	defer closeFn()
+	ctx := context.Background()
+	other := common.HexToAddress("0x0000000000000000000000000000000000c0ffee")
+
+	assert.Equal(t, TokenTypeKRC1155, token.TokenType())
+	krcType, err := node.DetectTokenType(ctx, token.(*krc1155Token).c.ContractAddress.Hex())
+	assert.Nil(t, err)
+	assert.Equal(t, TokenTypeKRC1155, krcType)
+
+	balance, err := token.BalanceOf(ctx, auth.From, big.NewInt(1))
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(100), balance)
+	balances, err := token.BalanceOfBatch(ctx, []common.Address{auth.From, auth.From, other, auth.From},
+		[]*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(1), big.NewInt(3)})
+	assert.Nil(t, err)
+	assert.Equal(t, "[100 50 0 0]", fmt.Sprint(balances))
+	_, err = token.BalanceOfBatch(ctx, []common.Address{auth.From}, []*big.Int{big.NewInt(1), big.NewInt(2)})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "KRC1155: length mismatch")
+
+	uri, err := token.URI(ctx, big.NewInt(0x4cce))
+	assert.Nil(t, err)
+	assert.Equal(t, "ipfs://QmKRC1155/0000000000000000000000000000000000000000000000000000000000004cce.json", uri)
+}
+
+func TestKRC1155Token_Transfers(t *testing.T) {
+	node, auth, token, closeFn := setupSimulatedKRC1155(t, 100, 50)
+	defer closeFn()
+	ctx := context.Background()
+	receiver := common.HexToAddress("0x0000000000000000000000000000000000c0ffee")
+	operator := common.HexToAddress("0x000000000000000000000000000000000000beef")
+
+	tx, err := token.SafeTransferFrom(auth, auth.From, receiver, big.NewInt(1), big.NewInt(30), nil)
+	assert.Nil(t, err)
+	receipt, err := node.WaitMined(ctx, tx.Hash().Hex())
+	assert.Nil(t, err)
+	balances, err := token.BalanceOfBatch(ctx, []common.Address{auth.From, receiver}, []*big.Int{big.NewInt(1), big.NewInt(1)})
+	assert.Nil(t, err)
+	assert.Equal(t, []*big.Int{big.NewInt(70), big.NewInt(30)}, balances)
+
+	singles, err := token.FilterTransferSingle(&bind.FilterOpts{Start: receipt.BlockHeight}, nil, nil, []common.Address{receiver})
+	assert.Nil(t, err)
+	defer singles.Close()
+	assert.True(t, singles.Next())
+	assert.Equal(t, auth.From, singles.Event.Operator)
+	assert.Equal(t, auth.From, singles.Event.From)
+	assert.Equal(t, big.NewInt(1), singles.Event.Id)
+	assert.Equal(t, big.NewInt(30), singles.Event.Value)
+	assert.False(t, singles.Next())
+
+	assert.Len(t, receipt.Logs, 1)
+	log, err := UnpackLog(receipt.Logs[0], token.ABI())
+	assert.Nil(t, err)
+	assert.Equal(t, "TransferSingle", log.MethodName)
+	assert.Equal(t, "1", log.Arguments["id"])
+	assert.Equal(t, "30", log.Arguments["value"])
+
+	tx, err = token.SafeBatchTransferFrom(auth, auth.From, receiver, []*big.Int{big.NewInt(1), big.NewInt(2)},
+		[]*big.Int{big.NewInt(20), big.NewInt(50)}, []byte("batch"))
+	assert.Nil(t, err)
+	receipt, err = node.WaitMined(ctx, tx.Hash().Hex())
+	assert.Nil(t, err)
+	balances, err = token.BalanceOfBatch(ctx, []common.Address{auth.From, auth.From, receiver, receiver},
+		[]*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(1), big.NewInt(2)})
+	assert.Nil(t, err)
+	assert.Equal(t, "[50 0 50 50]", fmt.Sprint(balances))
+
+	batches, err := token.FilterTransferBatch(&bind.FilterOpts{Start: receipt.BlockHeight}, nil, []common.Address{auth.From}, nil)
+	assert.Nil(t, err)
+	defer batches.Close()
+	assert.True(t, batches.Next())
+	assert.Equal(t, receiver, batches.Event.To)
+	assert.Equal(t, []*big.Int{big.NewInt(1), big.NewInt(2)}, batches.Event.Ids)
+	assert.Equal(t, []*big.Int{big.NewInt(20), big.NewInt(50)}, batches.Event.Values)
+	assert.False(t, batches.Next())
+
+	filter, err := NewFilter([]string{"TransferBatch"}, token.ABI())
+	assert.Nil(t, err)
+	events, err := filter.Events(receipt.Logs[0])
+	assert.Nil(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, []*big.Int{big.NewInt(20), big.NewInt(50)}, events[0].Inputs["values"])
+	log, err = UnpackLog(receipt.Logs[0], token.ABI())
+	assert.Nil(t, err)
+	assert.Equal(t, "TransferBatch", log.MethodName)
+	assert.Equal(t, []string{"1", "2"}, log.Arguments["ids"])
+	assert.Equal(t, common.Bytes(receiver[:]).String(), log.Arguments["to"])
+
+	_, err = token.SafeTransferFrom(auth, auth.From, receiver, big.NewInt(2), big.NewInt(1), nil)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "KRC1155: insufficient balance")
+	_, err = token.SafeBatchTransferFrom(auth, auth.From, receiver, []*big.Int{big.NewInt(1)}, nil, nil)
+	assert.True(t, errors.Is(err, ErrLengthMismatch))
+
+	tx, err = token.SetApprovalForAll(auth, operator, true)
+	assert.Nil(t, err)
+	_, err = node.WaitMined(ctx, tx.Hash().Hex())
+	assert.Nil(t, err)
+	isOperator, err := token.IsApprovedForAll(ctx, auth.From, operator)
+	assert.Nil(t, err)
+	assert.True(t, isOperator)
+	_, err = token.SafeTransferFrom(auth, receiver, auth.From, big.NewInt(1), big.NewInt(1), nil)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "KRC1155: not owner nor approved")
+}
+
+func TestKRC1155Token_Receivers(t *testing.T) {
+	node, auth, token, closeFn := setupSimulatedKRC1155(t, 100, 50)
+	defer closeFn()
+	ctx := context.Background()
+
+	// a contract logging its calldata and accepting every token
+	p := evmasm.NewProgram()
+	p.Emit(kvm.CALLDATACOPY, evmasm.Const(0), evmasm.Const(0), evmasm.Call(kvm.CALLDATASIZE))
+	p.Emit(kvm.LOG0, evmasm.Const(0), evmasm.Call(kvm.CALLDATASIZE))
+	p.ReturnWord(evmasm.Call(kvm.CALLDATALOAD, evmasm.Const(0)))
+	holder := deployTestContract(t, node, auth, p)
+	// a contract accepting nothing
+	p = evmasm.NewProgram()
+	p.Stop()
+	rejecter := deployTestContract(t, node, auth, p)
+
+	receiverABI, err := abi.JSON(strings.NewReader(testKRC1155ReceiverABI))
+	assert.Nil(t, err)
+	received := func(logs []*Log, method string) []interface{} {
+		for _, log := range logs {
+			if common.HexToAddress(log.Address) != holder {
+				continue
+			}
+			data := common.FromHex(log.Data)
+			assert.Equal(t, receiverABI.Methods[method].ID, data[:4])
+			args, err := receiverABI.Methods[method].Inputs.Unpack(data[4:])
+			assert.Nil(t, err)
+			return args
+		}
+		t.Fatalf("%s not called", method)
+		return nil
+	}
+
+	tx, err := token.SafeTransferFrom(auth, auth.From, holder, big.NewInt(1), big.NewInt(10), []byte("single"))
+	assert.Nil(t, err)
+	receipt, err := node.WaitMined(ctx, tx.Hash().Hex())
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{auth.From, auth.From, big.NewInt(1), big.NewInt(10), []byte("single")},
+		received(receipt.Logs, "onERC1155Received"))
+
+	tx, err = token.SafeBatchTransferFrom(auth, auth.From, holder, []*big.Int{big.NewInt(1), big.NewInt(2)},
+		[]*big.Int{big.NewInt(5), big.NewInt(6)}, []byte("batch"))
+	assert.Nil(t, err)
+	receipt, err = node.WaitMined(ctx, tx.Hash().Hex())
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{auth.From, auth.From, []*big.Int{big.NewInt(1), big.NewInt(2)},
+		[]*big.Int{big.NewInt(5), big.NewInt(6)}, []byte("batch")}, received(receipt.Logs, "onERC1155BatchReceived"))
+	balances, err := token.BalanceOfBatch(ctx, []common.Address{holder, holder}, []*big.Int{big.NewInt(1), big.NewInt(2)})
+	assert.Nil(t, err)
+	assert.Equal(t, []*big.Int{big.NewInt(15), big.NewInt(6)}, balances)
+
+	_, err = token.SafeTransferFrom(auth, auth.From, rejecter, big.NewInt(1), big.NewInt(1), nil)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "KRC1155: rejected by receiver")
+	_, err = token.SafeBatchTransferFrom(auth, auth.From, rejecter, []*big.Int{big.NewInt(1)}, []*big.Int{big.NewInt(1)}, nil)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "KRC1155: rejected by receiver")
+}
+
+func TestKRC1155TokenURI(t *testing.T) {
+	assert.Equal(t, "https://token-cdn-domain/000000000000000000000000000000000000000000000000000000000004cce0.json",
+		KRC1155TokenURI("https://token-cdn-domain/{id}.json", big.NewInt(314592)))
+	assert.Equal(t, "ipfs://QmStatic", KRC1155TokenURI("ipfs://QmStatic", big.NewInt(1)))
+}
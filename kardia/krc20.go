@@ -0,0 +1,153 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package kardia
+package kardia
+
+import (
+	"context"
+	"math/big"
+	"sync"
+
+	"github.com/kardiachain/go-kardia/lib/abi/bind"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/event"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+// KRC20Token is a KRC20 token, with the transactions and events of the standard on top of the reads of Token.
+// Transactions are signed and sent with opts, as bind.BoundContract.Transact does.
+type KRC20Token interface {
+	Token
+	Decimals(ctx context.Context) (uint8, error)
+	Allowance(ctx context.Context, owner, spender common.Address) (*big.Int, error)
+
+	Transfer(opts *bind.TransactOpts, to common.Address, amount *big.Int) (*types.Transaction, error)
+	Approve(opts *bind.TransactOpts, spender common.Address, amount *big.Int) (*types.Transaction, error)
+	TransferFrom(opts *bind.TransactOpts, from, to common.Address, amount *big.Int) (*types.Transaction, error)
+	IncreaseAllowance(opts *bind.TransactOpts, spender common.Address, addedAmount *big.Int) (*types.Transaction, error)
+	Burn(opts *bind.TransactOpts, amount *big.Int) (*types.Transaction, error)
+
+	FilterTransfer(opts *bind.FilterOpts, from, to []common.Address) (*KRC20TransferIterator, error)
+	WatchTransfer(opts *bind.WatchOpts, sink chan<- *KRC20Transfer, from, to []common.Address) (event.Subscription, error)
+	FilterApproval(opts *bind.FilterOpts, owner, spender []common.Address) (*KRC20ApprovalIterator, error)
+	WatchApproval(opts *bind.WatchOpts, sink chan<- *KRC20Approval, owner, spender []common.Address) (event.Subscription, error)
+
+	// ToUnits converts an amount of tokens to the smallest units of the token
+	ToUnits(ctx context.Context, amount float64) (*big.Int, error)
+	// FromUnits converts an amount of the smallest units of the token to tokens
+	FromUnits(ctx context.Context, units *big.Int) (float64, error)
+}
+
+type krc20Token struct {
+	*token
+	contract *KRC20Contract
+
+	mtx      sync.Mutex
+	decimals *uint8
+}
+
+// NewKRC20Token binds the KRC20 token deployed at address
+func NewKRC20Token(node Node, address string) (KRC20Token, error) {
+	krc20ABI, err := KRC20ABI()
+	if err != nil {
+		return nil, err
+	}
+	contract := NewBoundContract(node, krc20ABI, common.HexToAddress(address))
+	return &krc20Token{
+		token: &token{
+			node:    node,
+			c:       &Contract{Abi: krc20ABI, ContractAddress: contract.ContractAddress},
+			krcType: TokenTypeKRC20,
+		},
+		contract: &KRC20Contract{contract},
+	}, nil
+}
+
+// Decimals returns the decimals of the token, the value is fetched once
+func (t *krc20Token) Decimals(ctx context.Context) (uint8, error) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	if t.decimals != nil {
+		return *t.decimals, nil
+	}
+	decimals, err := t.getDecimals(ctx)
+	if err != nil {
+		return 0, err
+	}
+	t.decimals = &decimals
+	return decimals, nil
+}
+
+func (t *krc20Token) Allowance(ctx context.Context, owner, spender common.Address) (*big.Int, error) {
+	return t.contract.Allowance(&bind.CallOpts{Context: ctx}, owner, spender)
+}
+
+func (t *krc20Token) Transfer(opts *bind.TransactOpts, to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return t.contract.Transfer(opts, to, amount)
+}
+
+func (t *krc20Token) Approve(opts *bind.TransactOpts, spender common.Address, amount *big.Int) (*types.Transaction, error) {
+	return t.contract.Approve(opts, spender, amount)
+}
+
+func (t *krc20Token) TransferFrom(opts *bind.TransactOpts, from, to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return t.contract.TransferFrom(opts, from, to, amount)
+}
+
+// IncreaseAllowance raises the allowance of spender by addedAmount, without the race of
+// approving a new amount over an allowance being spent. The token must implement increaseAllowance.
+func (t *krc20Token) IncreaseAllowance(opts *bind.TransactOpts, spender common.Address, addedAmount *big.Int) (*types.Transaction, error) {
+	return t.contract.IncreaseAllowance(opts, spender, addedAmount)
+}
+
+// Burn destroys amount tokens of the sender. The token must implement burn.
+func (t *krc20Token) Burn(opts *bind.TransactOpts, amount *big.Int) (*types.Transaction, error) {
+	return t.contract.Burn(opts, amount)
+}
+
+func (t *krc20Token) FilterTransfer(opts *bind.FilterOpts, from, to []common.Address) (*KRC20TransferIterator, error) {
+	return t.contract.FilterTransfer(opts, from, to)
+}
+
+func (t *krc20Token) WatchTransfer(opts *bind.WatchOpts, sink chan<- *KRC20Transfer, from, to []common.Address) (event.Subscription, error) {
+	return t.contract.WatchTransfer(opts, sink, from, to)
+}
+
+func (t *krc20Token) FilterApproval(opts *bind.FilterOpts, owner, spender []common.Address) (*KRC20ApprovalIterator, error) {
+	return t.contract.FilterApproval(opts, owner, spender)
+}
+
+func (t *krc20Token) WatchApproval(opts *bind.WatchOpts, sink chan<- *KRC20Approval, owner, spender []common.Address) (event.Subscription, error) {
+	return t.contract.WatchApproval(opts, sink, owner, spender)
+}
+
+func (t *krc20Token) ToUnits(ctx context.Context, amount float64) (*big.Int, error) {
+	decimals, err := t.Decimals(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return FloatToBigInt(amount, int32(decimals)), nil
+}
+
+func (t *krc20Token) FromUnits(ctx context.Context, units *big.Int) (float64, error) {
+	decimals, err := t.Decimals(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return BigIntToFloat(units, int32(decimals)), nil
+}
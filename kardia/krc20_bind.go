@@ -0,0 +1,267 @@
+// Code generated by kaibind. DO NOT EDIT.
+
+package kardia
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/kardiachain/go-kardia/lib/abi"
+	"github.com/kardiachain/go-kardia/lib/abi/bind"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/event"
+	"github.com/kardiachain/go-kardia/types"
+
+	"github.com/kardiachain/go-kaiclient/kardia/smc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = abi.JSON
+	_ = bind.NewBoundContract
+	_ = common.Big1
+	_ = event.NewSubscription
+	_ = types.BloomLookup
+)
+
+// KRC20Contract is a typed binding of the contract, on top of BoundContract.
+type KRC20Contract struct {
+	*BoundContract
+}
+
+// NewKRC20Contract binds the contract deployed at address.
+func NewKRC20Contract(node Node, address common.Address) (*KRC20Contract, error) {
+	parsed, err := abi.JSON(strings.NewReader(smc.KRC20ABI))
+	if err != nil {
+		return nil, err
+	}
+	return &KRC20Contract{NewBoundContract(node, &parsed, address)}, nil
+}
+
+// DeployKRC20Contract deploys the contract and binds it.
+func DeployKRC20Contract(auth *bind.TransactOpts, node Node) (common.Address, *types.Transaction, *KRC20Contract, error) {
+	parsed, err := abi.JSON(strings.NewReader(smc.KRC20ABI))
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	address, tx, _, err := bind.DeployContract(auth, parsed, common.FromHex(smc.KRC20Bytecode), node)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	c := &KRC20Contract{NewBoundContract(node, &parsed, address)}
+	c.BoundContract.Bytecode = smc.KRC20Bytecode
+	return address, tx, c, nil
+}
+
+// Allowance calls function allowance(address owner, address spender) view returns(uint256).
+func (c *KRC20Contract) Allowance(opts *bind.CallOpts, owner common.Address, spender common.Address) (*big.Int, error) {
+	var out *big.Int
+	err := c.BoundContract.Call(opts, &out, "allowance", owner, spender)
+	return out, err
+}
+
+// BalanceOf calls function balanceOf(address account) view returns(uint256).
+func (c *KRC20Contract) BalanceOf(opts *bind.CallOpts, account common.Address) (*big.Int, error) {
+	var out *big.Int
+	err := c.BoundContract.Call(opts, &out, "balanceOf", account)
+	return out, err
+}
+
+// Decimals calls function decimals() view returns(uint8).
+func (c *KRC20Contract) Decimals(opts *bind.CallOpts) (uint8, error) {
+	var out uint8
+	err := c.BoundContract.Call(opts, &out, "decimals")
+	return out, err
+}
+
+// Name calls function name() view returns(string).
+func (c *KRC20Contract) Name(opts *bind.CallOpts) (string, error) {
+	var out string
+	err := c.BoundContract.Call(opts, &out, "name")
+	return out, err
+}
+
+// Symbol calls function symbol() view returns(string).
+func (c *KRC20Contract) Symbol(opts *bind.CallOpts) (string, error) {
+	var out string
+	err := c.BoundContract.Call(opts, &out, "symbol")
+	return out, err
+}
+
+// TotalSupply calls function totalSupply() view returns(uint256).
+func (c *KRC20Contract) TotalSupply(opts *bind.CallOpts) (*big.Int, error) {
+	var out *big.Int
+	err := c.BoundContract.Call(opts, &out, "totalSupply")
+	return out, err
+}
+
+// Approve sends a transaction calling function approve(address spender, uint256 amount) returns(bool).
+func (c *KRC20Contract) Approve(opts *bind.TransactOpts, spender common.Address, amount *big.Int) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "approve", spender, amount)
+}
+
+// Burn sends a transaction calling function burn(uint256 amount) returns().
+func (c *KRC20Contract) Burn(opts *bind.TransactOpts, amount *big.Int) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "burn", amount)
+}
+
+// IncreaseAllowance sends a transaction calling function increaseAllowance(address spender, uint256 addedValue) returns(bool).
+func (c *KRC20Contract) IncreaseAllowance(opts *bind.TransactOpts, spender common.Address, addedValue *big.Int) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "increaseAllowance", spender, addedValue)
+}
+
+// Transfer sends a transaction calling function transfer(address recipient, uint256 amount) returns(bool).
+func (c *KRC20Contract) Transfer(opts *bind.TransactOpts, recipient common.Address, amount *big.Int) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "transfer", recipient, amount)
+}
+
+// TransferFrom sends a transaction calling function transferFrom(address sender, address recipient, uint256 amount) returns(bool).
+func (c *KRC20Contract) TransferFrom(opts *bind.TransactOpts, sender common.Address, recipient common.Address, amount *big.Int) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "transferFrom", sender, recipient, amount)
+}
+
+// KRC20Approval is the Approval event of KRC20Contract.
+type KRC20Approval struct {
+	Owner   common.Address
+	Spender common.Address
+	Value   *big.Int
+	Raw     types.Log
+}
+
+// KRC20ApprovalIterator iterates over the Approval events returned by KRC20Contract.FilterApproval.
+type KRC20ApprovalIterator struct {
+	// Event is the event the iterator is at
+	Event *KRC20Approval
+
+	*EventIterator
+}
+
+// FilterApproval iterates over past logs of event Approval(address indexed owner, address indexed spender, uint256 value).
+func (c *KRC20Contract) FilterApproval(opts *bind.FilterOpts, owner []common.Address, spender []common.Address) (*KRC20ApprovalIterator, error) {
+	var ownerRule []interface{}
+	for _, item := range owner {
+		ownerRule = append(ownerRule, item)
+	}
+	var spenderRule []interface{}
+	for _, item := range spender {
+		spenderRule = append(spenderRule, item)
+	}
+	it := new(KRC20ApprovalIterator)
+	var err error
+	it.EventIterator, err = c.BoundContract.FilterEvent(opts, "Approval", func(log types.Log) error {
+		ev, err := c.ParseApproval(log)
+		it.Event = ev
+		return err
+	}, ownerRule, spenderRule)
+	if err != nil {
+		return nil, err
+	}
+	return it, nil
+}
+
+// WatchApproval sends new logs of event Approval(address indexed owner, address indexed spender, uint256 value) to sink.
+func (c *KRC20Contract) WatchApproval(opts *bind.WatchOpts, sink chan<- *KRC20Approval, owner []common.Address, spender []common.Address) (event.Subscription, error) {
+	var ownerRule []interface{}
+	for _, item := range owner {
+		ownerRule = append(ownerRule, item)
+	}
+	var spenderRule []interface{}
+	for _, item := range spender {
+		spenderRule = append(spenderRule, item)
+	}
+	return c.BoundContract.WatchEvent(opts, "Approval", func(log types.Log, quit <-chan struct{}) error {
+		ev, err := c.ParseApproval(log)
+		if err != nil {
+			return err
+		}
+		select {
+		case sink <- ev:
+		case <-quit:
+		}
+		return nil
+	}, ownerRule, spenderRule)
+}
+
+// ParseApproval unpacks a log of event Approval(address indexed owner, address indexed spender, uint256 value).
+func (c *KRC20Contract) ParseApproval(log types.Log) (*KRC20Approval, error) {
+	ev := new(KRC20Approval)
+	if err := c.BoundContract.UnpackLog(ev, "Approval", log); err != nil {
+		return nil, err
+	}
+	ev.Raw = log
+	return ev, nil
+}
+
+// KRC20Transfer is the Transfer event of KRC20Contract.
+type KRC20Transfer struct {
+	From  common.Address
+	To    common.Address
+	Value *big.Int
+	Raw   types.Log
+}
+
+// KRC20TransferIterator iterates over the Transfer events returned by KRC20Contract.FilterTransfer.
+type KRC20TransferIterator struct {
+	// Event is the event the iterator is at
+	Event *KRC20Transfer
+
+	*EventIterator
+}
+
+// FilterTransfer iterates over past logs of event Transfer(address indexed from, address indexed to, uint256 value).
+func (c *KRC20Contract) FilterTransfer(opts *bind.FilterOpts, from []common.Address, to []common.Address) (*KRC20TransferIterator, error) {
+	var fromRule []interface{}
+	for _, item := range from {
+		fromRule = append(fromRule, item)
+	}
+	var toRule []interface{}
+	for _, item := range to {
+		toRule = append(toRule, item)
+	}
+	it := new(KRC20TransferIterator)
+	var err error
+	it.EventIterator, err = c.BoundContract.FilterEvent(opts, "Transfer", func(log types.Log) error {
+		ev, err := c.ParseTransfer(log)
+		it.Event = ev
+		return err
+	}, fromRule, toRule)
+	if err != nil {
+		return nil, err
+	}
+	return it, nil
+}
+
+// WatchTransfer sends new logs of event Transfer(address indexed from, address indexed to, uint256 value) to sink.
+func (c *KRC20Contract) WatchTransfer(opts *bind.WatchOpts, sink chan<- *KRC20Transfer, from []common.Address, to []common.Address) (event.Subscription, error) {
+	var fromRule []interface{}
+	for _, item := range from {
+		fromRule = append(fromRule, item)
+	}
+	var toRule []interface{}
+	for _, item := range to {
+		toRule = append(toRule, item)
+	}
+	return c.BoundContract.WatchEvent(opts, "Transfer", func(log types.Log, quit <-chan struct{}) error {
+		ev, err := c.ParseTransfer(log)
+		if err != nil {
+			return err
+		}
+		select {
+		case sink <- ev:
+		case <-quit:
+		}
+		return nil
+	}, fromRule, toRule)
+}
+
+// ParseTransfer unpacks a log of event Transfer(address indexed from, address indexed to, uint256 value).
+func (c *KRC20Contract) ParseTransfer(log types.Log) (*KRC20Transfer, error) {
+	ev := new(KRC20Transfer)
+	if err := c.BoundContract.UnpackLog(ev, "Transfer", log); err != nil {
+		return nil, err
+	}
+	ev.Raw = log
+	return ev, nil
+}
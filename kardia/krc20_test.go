@@ -0,0 +1,135 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package kardia
+package kardia
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/kardiachain/go-kardia/lib/abi/bind"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupSimulatedKRC20(t *testing.T) (Node, *bind.TransactOpts, KRC20Token, func()) {
+	b, node, auth := setupSimulatedNode(t)
+	address, txHash, err := node.DeployKRC20(auth)
+	assert.Nil(t, err)
+	_, err = node.WaitMined(context.Background(), txHash.Hex())
+	assert.Nil(t, err)
+	token, err := NewKRC20Token(node, address.Hex())
+	assert.Nil(t, err)
+	return node, auth, token, b.Close
+}
+
+func TestKRC20Token_TransferAndApprove(t *testing.T) {
+	node, auth, token, closeFn := setupSimulatedKRC20(t)
+	defer closeFn()
+	ctx := context.Background()
+	receiver := common.HexToAddress("0x0000000000000000000000000000000000c0ffee")
+	assert.Equal(t, TokenTypeKRC20, token.TokenType())
+
+	amount, err := token.ToUnits(ctx, 1.5)
+	assert.Nil(t, err)
+	assert.Equal(t, "1500000000000000000", amount.String())
+
+	transfers := make(chan *KRC20Transfer, 2)
+	sub, err := token.WatchTransfer(nil, transfers, []common.Address{auth.From}, nil)
+	assert.Nil(t, err)
+	defer sub.Unsubscribe()
+
+	tx, err := token.Transfer(auth, receiver, amount)
+	assert.Nil(t, err)
+	_, err = node.WaitMined(ctx, tx.Hash().Hex())
+	assert.Nil(t, err)
+	balance, err := token.HolderBalance(ctx, receiver.Hex())
+	assert.Nil(t, err)
+	tokens, err := token.FromUnits(ctx, balance)
+	assert.Nil(t, err)
+	assert.Equal(t, 1.5, tokens)
+
+	// the owner spends its own allowance
+	tx, err = token.Approve(auth, auth.From, big.NewInt(500))
+	assert.Nil(t, err)
+	_, err = node.WaitMined(ctx, tx.Hash().Hex())
+	assert.Nil(t, err)
+	allowance, err := token.Allowance(ctx, auth.From, auth.From)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(500), allowance)
+
+	tx, err = token.TransferFrom(auth, auth.From, receiver, big.NewInt(200))
+	assert.Nil(t, err)
+	receipt, err := node.WaitMined(ctx, tx.Hash().Hex())
+	assert.Nil(t, err)
+	allowance, err = token.Allowance(ctx, auth.From, auth.From)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(300), allowance)
+
+	for _, value := range []*big.Int{amount, big.NewInt(200)} {
+		select {
+		case transfer := <-transfers:
+			assert.Equal(t, receiver, transfer.To)
+			assert.Equal(t, value, transfer.Value)
+		case <-time.After(5 * time.Second):
+			t.Fatal("transfer not watched")
+		}
+	}
+
+	approvals, err := token.FilterApproval(&bind.FilterOpts{Start: 1, End: &receipt.BlockHeight}, []common.Address{auth.From}, nil)
+	assert.Nil(t, err)
+	defer approvals.Close()
+	assert.True(t, approvals.Next())
+	assert.Equal(t, auth.From, approvals.Event.Spender)
+	assert.Equal(t, big.NewInt(500), approvals.Event.Value)
+	assert.False(t, approvals.Next())
+	assert.Nil(t, approvals.Error())
+}
+
+func TestKRC20Token_IncreaseAllowanceAndBurn(t *testing.T) {
+	node, auth, token, closeFn := setupSimulatedKRC20(t)
+	defer closeFn()
+	ctx := context.Background()
+	spender := common.HexToAddress("0x0000000000000000000000000000000000c0ffee")
+	krc20ABI, err := KRC20ABI()
+	assert.Nil(t, err)
+
+	// the test token implements neither, the transactions are mined and fail
+	opts := *auth
+	opts.GasLimit = 100000
+	tx, err := token.IncreaseAllowance(&opts, spender, big.NewInt(100))
+	assert.Nil(t, err)
+	call, err := DecodeWithABI(common.Encode(tx.Data()), krc20ABI)
+	assert.Nil(t, err)
+	assert.Equal(t, "increaseAllowance", call.MethodName)
+	assert.Equal(t, "100", call.Arguments["addedValue"])
+	_, err = node.WaitMined(ctx, tx.Hash().Hex())
+	assert.IsType(t, &TxFailedError{}, err)
+
+	tx, err = token.Burn(&opts, big.NewInt(100))
+	assert.Nil(t, err)
+	assert.Equal(t, krc20ABI.Methods["burn"].ID, tx.Data()[:4])
+}
+
+func TestBigIntToFloat(t *testing.T) {
+	assert.Equal(t, 1.5, BigIntToFloat(FloatToBigInt(1.5, 18), 18))
+	assert.Equal(t, 0.000123, BigIntToFloat(big.NewInt(123), 6))
+	assert.Equal(t, float64(0), BigIntToFloat(new(big.Int), 18))
+}
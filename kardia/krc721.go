@@ -0,0 +1,183 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package kardia
+package kardia
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"sync"
+
+	"github.com/kardiachain/go-kardia/lib/abi/bind"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/event"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+// KRC721Token is a KRC721 NFT, with the ownership, enumeration and transfers of the standard on top of
+// the reads of Token. Enumeration requires the token to implement the enumerable extension.
+type KRC721Token interface {
+	Token
+	BalanceOf(ctx context.Context, owner common.Address) (*big.Int, error)
+	OwnerOf(ctx context.Context, tokenID *big.Int) (common.Address, error)
+	TokenURI(ctx context.Context, tokenID *big.Int) (string, error)
+	TokenByIndex(ctx context.Context, index *big.Int) (*big.Int, error)
+	TokenOfOwnerByIndex(ctx context.Context, owner common.Address, index *big.Int) (*big.Int, error)
+	GetApproved(ctx context.Context, tokenID *big.Int) (common.Address, error)
+	IsApprovedForAll(ctx context.Context, owner, operator common.Address) (bool, error)
+
+	Approve(opts *bind.TransactOpts, to common.Address, tokenID *big.Int) (*types.Transaction, error)
+	SetApprovalForAll(opts *bind.TransactOpts, operator common.Address, approved bool) (*types.Transaction, error)
+	TransferFrom(opts *bind.TransactOpts, from, to common.Address, tokenID *big.Int) (*types.Transaction, error)
+	// SafeTransferFrom transfers tokenID and checks that a contract receiver accepts it, data is passed to its
+	// onERC721Received and may be nil
+	SafeTransferFrom(opts *bind.TransactOpts, from, to common.Address, tokenID *big.Int, data []byte) (*types.Transaction, error)
+
+	FilterTransfer(opts *bind.FilterOpts, from, to []common.Address, tokenID []*big.Int) (*KRC721TransferIterator, error)
+	WatchTransfer(opts *bind.WatchOpts, sink chan<- *KRC721Transfer, from, to []common.Address, tokenID []*big.Int) (event.Subscription, error)
+
+	// Metadata fetches the JSON metadata at the URI of tokenID
+	Metadata(ctx context.Context, tokenID *big.Int) (*KRC721Metadata, error)
+	SetMetadataFetcher(fetcher MetadataFetcher)
+}
+
+// KRC721Metadata is the JSON metadata of a token, as described by the metadata extension of KRC721
+type KRC721Metadata struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Image       string            `json:"image"`
+	ExternalURL string            `json:"external_url,omitempty"`
+	Attributes  []KRC721Attribute `json:"attributes,omitempty"`
+
+	// Raw is the fetched document, with the fields not decoded above
+	Raw json.RawMessage `json:"-"`
+}
+
+type KRC721Attribute struct {
+	TraitType   string      `json:"trait_type"`
+	Value       interface{} `json:"value"`
+	DisplayType string      `json:"display_type,omitempty"`
+}
+
+type krc721Token struct {
+	*token
+	contract *KRC721Contract
+
+	mtx     sync.Mutex
+	fetcher MetadataFetcher
+}
+
+// NewKRC721Token binds the KRC721 token deployed at address, metadata are fetched with DefaultMetadataFetcher
+func NewKRC721Token(node Node, address string) (KRC721Token, error) {
+	krc721ABI, err := KRC721ABI()
+	if err != nil {
+		return nil, err
+	}
+	contract := NewBoundContract(node, krc721ABI, common.HexToAddress(address))
+	return &krc721Token{
+		token: &token{
+			node:    node,
+			c:       &Contract{Abi: krc721ABI, ContractAddress: contract.ContractAddress},
+			krcType: TokenTypeKRC721,
+		},
+		contract: &KRC721Contract{contract},
+		fetcher:  DefaultMetadataFetcher,
+	}, nil
+}
+
+func (t *krc721Token) BalanceOf(ctx context.Context, owner common.Address) (*big.Int, error) {
+	return t.contract.BalanceOf(&bind.CallOpts{Context: ctx}, owner)
+}
+
+func (t *krc721Token) OwnerOf(ctx context.Context, tokenID *big.Int) (common.Address, error) {
+	return t.contract.OwnerOf(&bind.CallOpts{Context: ctx}, tokenID)
+}
+
+func (t *krc721Token) TokenURI(ctx context.Context, tokenID *big.Int) (string, error) {
+	return t.contract.TokenURI(&bind.CallOpts{Context: ctx}, tokenID)
+}
+
+func (t *krc721Token) TokenByIndex(ctx context.Context, index *big.Int) (*big.Int, error) {
+	return t.contract.TokenByIndex(&bind.CallOpts{Context: ctx}, index)
+}
+
+func (t *krc721Token) TokenOfOwnerByIndex(ctx context.Context, owner common.Address, index *big.Int) (*big.Int, error) {
+	return t.contract.TokenOfOwnerByIndex(&bind.CallOpts{Context: ctx}, owner, index)
+}
+
+func (t *krc721Token) GetApproved(ctx context.Context, tokenID *big.Int) (common.Address, error) {
+	return t.contract.GetApproved(&bind.CallOpts{Context: ctx}, tokenID)
+}
+
+func (t *krc721Token) IsApprovedForAll(ctx context.Context, owner, operator common.Address) (bool, error) {
+	return t.contract.IsApprovedForAll(&bind.CallOpts{Context: ctx}, owner, operator)
+}
+
+func (t *krc721Token) Approve(opts *bind.TransactOpts, to common.Address, tokenID *big.Int) (*types.Transaction, error) {
+	return t.contract.Approve(opts, to, tokenID)
+}
+
+func (t *krc721Token) SetApprovalForAll(opts *bind.TransactOpts, operator common.Address, approved bool) (*types.Transaction, error) {
+	return t.contract.SetApprovalForAll(opts, operator, approved)
+}
+
+func (t *krc721Token) TransferFrom(opts *bind.TransactOpts, from, to common.Address, tokenID *big.Int) (*types.Transaction, error) {
+	return t.contract.TransferFrom(opts, from, to, tokenID)
+}
+
+func (t *krc721Token) SafeTransferFrom(opts *bind.TransactOpts, from, to common.Address, tokenID *big.Int, data []byte) (*types.Transaction, error) {
+	if data == nil {
+		return t.contract.SafeTransferFrom(opts, from, to, tokenID)
+	}
+	return t.contract.SafeTransferFrom0(opts, from, to, tokenID, data)
+}
+
+func (t *krc721Token) FilterTransfer(opts *bind.FilterOpts, from, to []common.Address, tokenID []*big.Int) (*KRC721TransferIterator, error) {
+	return t.contract.FilterTransfer(opts, from, to, tokenID)
+}
+
+func (t *krc721Token) WatchTransfer(opts *bind.WatchOpts, sink chan<- *KRC721Transfer, from, to []common.Address, tokenID []*big.Int) (event.Subscription, error) {
+	return t.contract.WatchTransfer(opts, sink, from, to, tokenID)
+}
+
+func (t *krc721Token) SetMetadataFetcher(fetcher MetadataFetcher) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	t.fetcher = fetcher
+}
+
+func (t *krc721Token) Metadata(ctx context.Context, tokenID *big.Int) (*KRC721Metadata, error) {
+	uri, err := t.TokenURI(ctx, tokenID)
+	if err != nil {
+		return nil, err
+	}
+	t.mtx.Lock()
+	fetcher := t.fetcher
+	t.mtx.Unlock()
+	data, err := fetcher.FetchMetadata(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	var metadata KRC721Metadata
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		return nil, err
+	}
+	metadata.Raw = data
+	return &metadata, nil
+}
@@ -0,0 +1,390 @@
+// Code generated by kaibind. DO NOT EDIT.
+
+package kardia
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/kardiachain/go-kardia/lib/abi"
+	"github.com/kardiachain/go-kardia/lib/abi/bind"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/event"
+	"github.com/kardiachain/go-kardia/types"
+
+	"github.com/kardiachain/go-kaiclient/kardia/smc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = abi.JSON
+	_ = bind.NewBoundContract
+	_ = common.Big1
+	_ = event.NewSubscription
+	_ = types.BloomLookup
+)
+
+// KRC721Contract is a typed binding of the contract, on top of BoundContract.
+type KRC721Contract struct {
+	*BoundContract
+}
+
+// NewKRC721Contract binds the contract deployed at address.
+func NewKRC721Contract(node Node, address common.Address) (*KRC721Contract, error) {
+	parsed, err := abi.JSON(strings.NewReader(smc.KRC721ABI))
+	if err != nil {
+		return nil, err
+	}
+	return &KRC721Contract{NewBoundContract(node, &parsed, address)}, nil
+}
+
+// DeployKRC721Contract deploys the contract and binds it.
+func DeployKRC721Contract(auth *bind.TransactOpts, node Node) (common.Address, *types.Transaction, *KRC721Contract, error) {
+	parsed, err := abi.JSON(strings.NewReader(smc.KRC721ABI))
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	address, tx, _, err := bind.DeployContract(auth, parsed, common.FromHex(smc.KRC721Bytecode), node)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	c := &KRC721Contract{NewBoundContract(node, &parsed, address)}
+	c.BoundContract.Bytecode = smc.KRC721Bytecode
+	return address, tx, c, nil
+}
+
+// BalanceOf calls function balanceOf(address owner) view returns(uint256 balance).
+func (c *KRC721Contract) BalanceOf(opts *bind.CallOpts, owner common.Address) (*big.Int, error) {
+	var out *big.Int
+	err := c.BoundContract.Call(opts, &out, "balanceOf", owner)
+	return out, err
+}
+
+// GetApproved calls function getApproved(uint256 tokenId) view returns(address operator).
+func (c *KRC721Contract) GetApproved(opts *bind.CallOpts, tokenId *big.Int) (common.Address, error) {
+	var out common.Address
+	err := c.BoundContract.Call(opts, &out, "getApproved", tokenId)
+	return out, err
+}
+
+// IsApprovedForAll calls function isApprovedForAll(address owner, address operator) view returns(bool).
+func (c *KRC721Contract) IsApprovedForAll(opts *bind.CallOpts, owner common.Address, operator common.Address) (bool, error) {
+	var out bool
+	err := c.BoundContract.Call(opts, &out, "isApprovedForAll", owner, operator)
+	return out, err
+}
+
+// Name calls function name() view returns(string).
+func (c *KRC721Contract) Name(opts *bind.CallOpts) (string, error) {
+	var out string
+	err := c.BoundContract.Call(opts, &out, "name")
+	return out, err
+}
+
+// OwnerOf calls function ownerOf(uint256 tokenId) view returns(address owner).
+func (c *KRC721Contract) OwnerOf(opts *bind.CallOpts, tokenId *big.Int) (common.Address, error) {
+	var out common.Address
+	err := c.BoundContract.Call(opts, &out, "ownerOf", tokenId)
+	return out, err
+}
+
+// SupportsInterface calls function supportsInterface(bytes4 interfaceId) view returns(bool).
+func (c *KRC721Contract) SupportsInterface(opts *bind.CallOpts, interfaceId [4]byte) (bool, error) {
+	var out bool
+	err := c.BoundContract.Call(opts, &out, "supportsInterface", interfaceId)
+	return out, err
+}
+
+// Symbol calls function symbol() view returns(string).
+func (c *KRC721Contract) Symbol(opts *bind.CallOpts) (string, error) {
+	var out string
+	err := c.BoundContract.Call(opts, &out, "symbol")
+	return out, err
+}
+
+// TokenByIndex calls function tokenByIndex(uint256 index) view returns(uint256).
+func (c *KRC721Contract) TokenByIndex(opts *bind.CallOpts, index *big.Int) (*big.Int, error) {
+	var out *big.Int
+	err := c.BoundContract.Call(opts, &out, "tokenByIndex", index)
+	return out, err
+}
+
+// TokenOfOwnerByIndex calls function tokenOfOwnerByIndex(address owner, uint256 index) view returns(uint256).
+func (c *KRC721Contract) TokenOfOwnerByIndex(opts *bind.CallOpts, owner common.Address, index *big.Int) (*big.Int, error) {
+	var out *big.Int
+	err := c.BoundContract.Call(opts, &out, "tokenOfOwnerByIndex", owner, index)
+	return out, err
+}
+
+// TokenURI calls function tokenURI(uint256 tokenId) view returns(string).
+func (c *KRC721Contract) TokenURI(opts *bind.CallOpts, tokenId *big.Int) (string, error) {
+	var out string
+	err := c.BoundContract.Call(opts, &out, "tokenURI", tokenId)
+	return out, err
+}
+
+// TotalSupply calls function totalSupply() view returns(uint256).
+func (c *KRC721Contract) TotalSupply(opts *bind.CallOpts) (*big.Int, error) {
+	var out *big.Int
+	err := c.BoundContract.Call(opts, &out, "totalSupply")
+	return out, err
+}
+
+// Approve sends a transaction calling function approve(address to, uint256 tokenId) returns().
+func (c *KRC721Contract) Approve(opts *bind.TransactOpts, to common.Address, tokenId *big.Int) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "approve", to, tokenId)
+}
+
+// SafeTransferFrom sends a transaction calling function safeTransferFrom(address from, address to, uint256 tokenId) returns().
+func (c *KRC721Contract) SafeTransferFrom(opts *bind.TransactOpts, from common.Address, to common.Address, tokenId *big.Int) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "safeTransferFrom", from, to, tokenId)
+}
+
+// SafeTransferFrom0 sends a transaction calling function safeTransferFrom(address from, address to, uint256 tokenId, bytes data) returns().
+func (c *KRC721Contract) SafeTransferFrom0(opts *bind.TransactOpts, from common.Address, to common.Address, tokenId *big.Int, data []byte) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "safeTransferFrom0", from, to, tokenId, data)
+}
+
+// SetApprovalForAll sends a transaction calling function setApprovalForAll(address operator, bool _approved) returns().
+func (c *KRC721Contract) SetApprovalForAll(opts *bind.TransactOpts, operator common.Address, approved bool) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "setApprovalForAll", operator, approved)
+}
+
+// TransferFrom sends a transaction calling function transferFrom(address from, address to, uint256 tokenId) returns().
+func (c *KRC721Contract) TransferFrom(opts *bind.TransactOpts, from common.Address, to common.Address, tokenId *big.Int) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "transferFrom", from, to, tokenId)
+}
+
+// KRC721Approval is the Approval event of KRC721Contract.
+type KRC721Approval struct {
+	Owner    common.Address
+	Approved common.Address
+	TokenId  *big.Int
+	Raw      types.Log
+}
+
+// KRC721ApprovalIterator iterates over the Approval events returned by KRC721Contract.FilterApproval.
+type KRC721ApprovalIterator struct {
+	// Event is the event the iterator is at
+	Event *KRC721Approval
+
+	*EventIterator
+}
+
+// FilterApproval iterates over past logs of event Approval(address indexed owner, address indexed approved, uint256 indexed tokenId).
+func (c *KRC721Contract) FilterApproval(opts *bind.FilterOpts, owner []common.Address, approved []common.Address, tokenId []*big.Int) (*KRC721ApprovalIterator, error) {
+	var ownerRule []interface{}
+	for _, item := range owner {
+		ownerRule = append(ownerRule, item)
+	}
+	var approvedRule []interface{}
+	for _, item := range approved {
+		approvedRule = append(approvedRule, item)
+	}
+	var tokenIdRule []interface{}
+	for _, item := range tokenId {
+		tokenIdRule = append(tokenIdRule, item)
+	}
+	it := new(KRC721ApprovalIterator)
+	var err error
+	it.EventIterator, err = c.BoundContract.FilterEvent(opts, "Approval", func(log types.Log) error {
+		ev, err := c.ParseApproval(log)
+		it.Event = ev
+		return err
+	}, ownerRule, approvedRule, tokenIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return it, nil
+}
+
+// WatchApproval sends new logs of event Approval(address indexed owner, address indexed approved, uint256 indexed tokenId) to sink.
+func (c *KRC721Contract) WatchApproval(opts *bind.WatchOpts, sink chan<- *KRC721Approval, owner []common.Address, approved []common.Address, tokenId []*big.Int) (event.Subscription, error) {
+	var ownerRule []interface{}
+	for _, item := range owner {
+		ownerRule = append(ownerRule, item)
+	}
+	var approvedRule []interface{}
+	for _, item := range approved {
+		approvedRule = append(approvedRule, item)
+	}
+	var tokenIdRule []interface{}
+	for _, item := range tokenId {
+		tokenIdRule = append(tokenIdRule, item)
+	}
+	return c.BoundContract.WatchEvent(opts, "Approval", func(log types.Log, quit <-chan struct{}) error {
+		ev, err := c.ParseApproval(log)
+		if err != nil {
+			return err
+		}
+		select {
+		case sink <- ev:
+		case <-quit:
+		}
+		return nil
+	}, ownerRule, approvedRule, tokenIdRule)
+}
+
+// ParseApproval unpacks a log of event Approval(address indexed owner, address indexed approved, uint256 indexed tokenId).
+func (c *KRC721Contract) ParseApproval(log types.Log) (*KRC721Approval, error) {
+	ev := new(KRC721Approval)
+	if err := c.BoundContract.UnpackLog(ev, "Approval", log); err != nil {
+		return nil, err
+	}
+	ev.Raw = log
+	return ev, nil
+}
+
+// KRC721ApprovalForAll is the ApprovalForAll event of KRC721Contract.
+type KRC721ApprovalForAll struct {
+	Owner    common.Address
+	Operator common.Address
+	Approved bool
+	Raw      types.Log
+}
+
+// KRC721ApprovalForAllIterator iterates over the ApprovalForAll events returned by KRC721Contract.FilterApprovalForAll.
+type KRC721ApprovalForAllIterator struct {
+	// Event is the event the iterator is at
+	Event *KRC721ApprovalForAll
+
+	*EventIterator
+}
+
+// FilterApprovalForAll iterates over past logs of event ApprovalForAll(address indexed owner, address indexed operator, bool approved).
+func (c *KRC721Contract) FilterApprovalForAll(opts *bind.FilterOpts, owner []common.Address, operator []common.Address) (*KRC721ApprovalForAllIterator, error) {
+	var ownerRule []interface{}
+	for _, item := range owner {
+		ownerRule = append(ownerRule, item)
+	}
+	var operatorRule []interface{}
+	for _, item := range operator {
+		operatorRule = append(operatorRule, item)
+	}
+	it := new(KRC721ApprovalForAllIterator)
+	var err error
+	it.EventIterator, err = c.BoundContract.FilterEvent(opts, "ApprovalForAll", func(log types.Log) error {
+		ev, err := c.ParseApprovalForAll(log)
+		it.Event = ev
+		return err
+	}, ownerRule, operatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return it, nil
+}
+
+// WatchApprovalForAll sends new logs of event ApprovalForAll(address indexed owner, address indexed operator, bool approved) to sink.
+func (c *KRC721Contract) WatchApprovalForAll(opts *bind.WatchOpts, sink chan<- *KRC721ApprovalForAll, owner []common.Address, operator []common.Address) (event.Subscription, error) {
+	var ownerRule []interface{}
+	for _, item := range owner {
+		ownerRule = append(ownerRule, item)
+	}
+	var operatorRule []interface{}
+	for _, item := range operator {
+		operatorRule = append(operatorRule, item)
+	}
+	return c.BoundContract.WatchEvent(opts, "ApprovalForAll", func(log types.Log, quit <-chan struct{}) error {
+		ev, err := c.ParseApprovalForAll(log)
+		if err != nil {
+			return err
+		}
+		select {
+		case sink <- ev:
+		case <-quit:
+		}
+		return nil
+	}, ownerRule, operatorRule)
+}
+
+// ParseApprovalForAll unpacks a log of event ApprovalForAll(address indexed owner, address indexed operator, bool approved).
+func (c *KRC721Contract) ParseApprovalForAll(log types.Log) (*KRC721ApprovalForAll, error) {
+	ev := new(KRC721ApprovalForAll)
+	if err := c.BoundContract.UnpackLog(ev, "ApprovalForAll", log); err != nil {
+		return nil, err
+	}
+	ev.Raw = log
+	return ev, nil
+}
+
+// KRC721Transfer is the Transfer event of KRC721Contract.
+type KRC721Transfer struct {
+	From    common.Address
+	To      common.Address
+	TokenId *big.Int
+	Raw     types.Log
+}
+
+// KRC721TransferIterator iterates over the Transfer events returned by KRC721Contract.FilterTransfer.
+type KRC721TransferIterator struct {
+	// Event is the event the iterator is at
+	Event *KRC721Transfer
+
+	*EventIterator
+}
+
+// FilterTransfer iterates over past logs of event Transfer(address indexed from, address indexed to, uint256 indexed tokenId).
+func (c *KRC721Contract) FilterTransfer(opts *bind.FilterOpts, from []common.Address, to []common.Address, tokenId []*big.Int) (*KRC721TransferIterator, error) {
+	var fromRule []interface{}
+	for _, item := range from {
+		fromRule = append(fromRule, item)
+	}
+	var toRule []interface{}
+	for _, item := range to {
+		toRule = append(toRule, item)
+	}
+	var tokenIdRule []interface{}
+	for _, item := range tokenId {
+		tokenIdRule = append(tokenIdRule, item)
+	}
+	it := new(KRC721TransferIterator)
+	var err error
+	it.EventIterator, err = c.BoundContract.FilterEvent(opts, "Transfer", func(log types.Log) error {
+		ev, err := c.ParseTransfer(log)
+		it.Event = ev
+		return err
+	}, fromRule, toRule, tokenIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return it, nil
+}
+
+// WatchTransfer sends new logs of event Transfer(address indexed from, address indexed to, uint256 indexed tokenId) to sink.
+func (c *KRC721Contract) WatchTransfer(opts *bind.WatchOpts, sink chan<- *KRC721Transfer, from []common.Address, to []common.Address, tokenId []*big.Int) (event.Subscription, error) {
+	var fromRule []interface{}
+	for _, item := range from {
+		fromRule = append(fromRule, item)
+	}
+	var toRule []interface{}
+	for _, item := range to {
+		toRule = append(toRule, item)
+	}
+	var tokenIdRule []interface{}
+	for _, item := range tokenId {
+		tokenIdRule = append(tokenIdRule, item)
+	}
+	return c.BoundContract.WatchEvent(opts, "Transfer", func(log types.Log, quit <-chan struct{}) error {
+		ev, err := c.ParseTransfer(log)
+		if err != nil {
+			return err
+		}
+		select {
+		case sink <- ev:
+		case <-quit:
+		}
+		return nil
+	}, fromRule, toRule, tokenIdRule)
+}
+
+// ParseTransfer unpacks a log of event Transfer(address indexed from, address indexed to, uint256 indexed tokenId).
+func (c *KRC721Contract) ParseTransfer(log types.Log) (*KRC721Transfer, error) {
+	ev := new(KRC721Transfer)
+	if err := c.BoundContract.UnpackLog(ev, "Transfer", log); err != nil {
+		return nil, err
+	}
+	ev.Raw = log
+	return ev, nil
+}
@@ -0,0 +1,253 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package kardia
+package kardia
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kardiachain/go-kardia/lib/abi"
+	"github.com/kardiachain/go-kardia/lib/abi/bind"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kardiachain/go-kaiclient/kardia/internal/evmasm"
+)
+
+// testKRC721MintABI is the mint method of the test token of smc.KRC721Bytecode
+const testKRC721MintABI = `[{"type":"function","name":"mint","stateMutability":"nonpayable","inputs":[{"name":"to","type":"address"},{"name":"tokenId","type":"uint256"},{"name":"uri","type":"string"}],"outputs":[]}]`
+
+const testKRC721Metadata = `{"name":"Kitty #%d","description":"A test kitty","image":"ipfs://QmKitty","attributes":[{"trait_type":"color","value":"orange"}],"edition":1}`
+
+// setupSimulatedKRC721 deploys the KRC721 test token and mints the given URIs to the test account, as tokens 1, 2...
+func setupSimulatedKRC721(t *testing.T, uris ...string) (Node, *bind.TransactOpts, KRC721Token, func()) {
+	b, node, auth := setupSimulatedNode(t)
+	ctx := context.Background()
+	address, txHash, err := node.DeployKRC721(auth)
+	assert.Nil(t, err)
+	_, err = node.WaitMined(ctx, txHash.Hex())
+	assert.Nil(t, err)
+
+	mintABI, err := abi.JSON(strings.NewReader(testKRC721MintABI))
+	assert.Nil(t, err)
+	minter := NewBoundContract(node, &mintABI, address)
+	for i, uri := range uris {
+		tx, err := minter.Transact(auth, "mint", auth.From, big.NewInt(int64(i+1)), uri)
+		assert.Nil(t, err)
+		_, err = node.WaitMined(ctx, tx.Hash().Hex())
+		assert.Nil(t, err)
+	}
+	token, err := NewKRC721Token(node, address.Hex())
+	assert.Nil(t, err)
+	return node, auth, token, b.Close
+}
+
+func TestKRC721Token_Enumeration(t *testing.T) {
+	longURI := "https://nft.kardiachain.io/metadata/" + strings.Repeat("kitty", 10) + ".json"
+	_, auth, token, closeFn := setupSimulatedKRC721(t, "ipfs://QmToken1", longURI, "")
+	defer closeFn()
+	ctx := context.Background()
+
+	info, err := token.KRC721Info(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "Kardia Test NFT", info.Name)
+	assert.Equal(t, "KTN", info.Symbol)
+	assert.Equal(t, big.NewInt(3), info.TotalSupply)
+
+	balance, err := token.BalanceOf(ctx, auth.From)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(3), balance)
+	owner, err := token.OwnerOf(ctx, big.NewInt(2))
+	assert.Nil(t, err)
+	assert.Equal(t, auth.From, owner)
+	for i, expected := range []string{"ipfs://QmToken1", longURI, ""} {
+		uri, err := token.TokenURI(ctx, big.NewInt(int64(i+1)))
+		assert.Nil(t, err)
+		assert.Equal(t, expected, uri)
+	}
+	for i := int64(0); i < 3; i++ {
+		id, err := token.TokenByIndex(ctx, big.NewInt(i))
+		assert.Nil(t, err)
+		assert.Equal(t, big.NewInt(i+1), id)
+		id, err = token.TokenOfOwnerByIndex(ctx, auth.From, big.NewInt(i))
+		assert.Nil(t, err)
+		assert.Equal(t, big.NewInt(i+1), id)
+	}
+
+	_, err = token.OwnerOf(ctx, big.NewInt(4))
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "KRC721: nonexistent token")
+	_, err = token.TokenByIndex(ctx, big.NewInt(3))
+	assert.NotNil(t, err)
+	_, err = token.TokenURI(ctx, big.NewInt(4))
+	assert.NotNil(t, err)
+}
+
+func TestKRC721Token_Transfers(t *testing.T) {
+	node, auth, token, closeFn := setupSimulatedKRC721(t, "1", "2", "3")
+	defer closeFn()
+	ctx := context.Background()
+	receiver := common.HexToAddress("0x0000000000000000000000000000000000c0ffee")
+	operator := common.HexToAddress("0x000000000000000000000000000000000000beef")
+
+	tx, err := token.TransferFrom(auth, auth.From, receiver, big.NewInt(1))
+	assert.Nil(t, err)
+	receipt, err := node.WaitMined(ctx, tx.Hash().Hex())
+	assert.Nil(t, err)
+	owner, err := token.OwnerOf(ctx, big.NewInt(1))
+	assert.Nil(t, err)
+	assert.Equal(t, receiver, owner)
+	// the last token of the sender takes the index of the transferred one
+	for i, expected := range []int64{3, 2} {
+		id, err := token.TokenOfOwnerByIndex(ctx, auth.From, big.NewInt(int64(i)))
+		assert.Nil(t, err)
+		assert.Equal(t, big.NewInt(expected), id)
+	}
+	id, err := token.TokenOfOwnerByIndex(ctx, receiver, big.NewInt(0))
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(1), id)
+
+	transfers, err := token.FilterTransfer(&bind.FilterOpts{Start: receipt.BlockHeight}, nil, nil, []*big.Int{big.NewInt(1)})
+	assert.Nil(t, err)
+	defer transfers.Close()
+	assert.True(t, transfers.Next())
+	assert.Equal(t, auth.From, transfers.Event.From)
+	assert.Equal(t, receiver, transfers.Event.To)
+	assert.False(t, transfers.Next())
+
+	tx, err = token.Approve(auth, receiver, big.NewInt(2))
+	assert.Nil(t, err)
+	_, err = node.WaitMined(ctx, tx.Hash().Hex())
+	assert.Nil(t, err)
+	approved, err := token.GetApproved(ctx, big.NewInt(2))
+	assert.Nil(t, err)
+	assert.Equal(t, receiver, approved)
+
+	tx, err = token.SetApprovalForAll(auth, operator, true)
+	assert.Nil(t, err)
+	_, err = node.WaitMined(ctx, tx.Hash().Hex())
+	assert.Nil(t, err)
+	isOperator, err := token.IsApprovedForAll(ctx, auth.From, operator)
+	assert.Nil(t, err)
+	assert.True(t, isOperator)
+
+	// safe transfers to accounts succeed, the token itself is not a receiver
+	tx, err = token.SafeTransferFrom(auth, auth.From, receiver, big.NewInt(2), []byte("gift"))
+	assert.Nil(t, err)
+	_, err = node.WaitMined(ctx, tx.Hash().Hex())
+	assert.Nil(t, err)
+	approved, err = token.GetApproved(ctx, big.NewInt(2))
+	assert.Nil(t, err)
+	assert.Equal(t, common.Address{}, approved)
+	info, err := token.KRC721Info(ctx)
+	assert.Nil(t, err)
+	_, err = token.SafeTransferFrom(auth, auth.From, info.Address, big.NewInt(3), nil)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "KRC721: transfer to non receiver")
+
+	// a contract accepting every token
+	p := evmasm.NewProgram()
+	p.ReturnWord(evmasm.SelectorWord("onERC721Received(address,address,uint256,bytes)"))
+	runtime, err := p.Bytes()
+	assert.Nil(t, err)
+	holder, tx, _, err := bind.DeployContract(auth, abi.ABI{}, evmasm.Deployment(runtime), node)
+	assert.Nil(t, err)
+	_, err = node.WaitMined(ctx, tx.Hash().Hex())
+	assert.Nil(t, err)
+	tx, err = token.SafeTransferFrom(auth, auth.From, holder, big.NewInt(3), nil)
+	assert.Nil(t, err)
+	_, err = node.WaitMined(ctx, tx.Hash().Hex())
+	assert.Nil(t, err)
+	owner, err = token.OwnerOf(ctx, big.NewInt(3))
+	assert.Nil(t, err)
+	assert.Equal(t, holder, owner)
+
+	_, err = token.TransferFrom(auth, receiver, auth.From, big.NewInt(1))
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "KRC721: not owner nor approved")
+}
+
+func TestKRC721Token_Metadata(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ipfs/QmKitty1" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintf(w, testKRC721Metadata, 1)
+	}))
+	defer server.Close()
+	dataURI := "data:application/json;base64," + base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf(testKRC721Metadata, 2)))
+	_, _, token, closeFn := setupSimulatedKRC721(t, "ipfs://QmKitty1", dataURI, server.URL+"/missing.json")
+	defer closeFn()
+	ctx := context.Background()
+	token.SetMetadataFetcher(&HTTPMetadataFetcher{IPFSGateway: server.URL + "/ipfs/"})
+
+	metadata, err := token.Metadata(ctx, big.NewInt(1))
+	assert.Nil(t, err)
+	assert.Equal(t, "Kitty #1", metadata.Name)
+	assert.Equal(t, "ipfs://QmKitty", metadata.Image)
+	assert.Equal(t, []KRC721Attribute{{TraitType: "color", Value: "orange"}}, metadata.Attributes)
+	assert.Contains(t, string(metadata.Raw), `"edition":1`)
+
+	metadata, err = token.Metadata(ctx, big.NewInt(2))
+	assert.Nil(t, err)
+	assert.Equal(t, "Kitty #2", metadata.Name)
+
+	_, err = token.Metadata(ctx, big.NewInt(3))
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "404")
+
+	token.SetMetadataFetcher(MetadataFetcherFunc(func(ctx context.Context, uri string) ([]byte, error) {
+		return []byte(`{"name":"` + uri + `"}`), nil
+	}))
+	metadata, err = token.Metadata(ctx, big.NewInt(1))
+	assert.Nil(t, err)
+	assert.Equal(t, "ipfs://QmKitty1", metadata.Name)
+}
+
+func TestHTTPMetadataFetcher(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(strings.Repeat("a", 100)))
+	}))
+	defer server.Close()
+	ctx := context.Background()
+
+	fetcher := &HTTPMetadataFetcher{MaxSize: 100}
+	data, err := fetcher.FetchMetadata(ctx, server.URL)
+	assert.Nil(t, err)
+	assert.Len(t, data, 100)
+	fetcher.MaxSize = 99
+	_, err = fetcher.FetchMetadata(ctx, server.URL)
+	assert.Equal(t, ErrMetadataTooLarge, err)
+
+	data, err = fetcher.FetchMetadata(ctx, `data:application/json,{"name":"a%20b"}`)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"name":"a b"}`, string(data))
+	for _, uri := range []string{"ipfs://QmKitty", "ar://kitty", "data:application/json"} {
+		_, err = fetcher.FetchMetadata(ctx, uri)
+		assert.True(t, errors.Is(err, ErrUnsupportedURI), uri)
+	}
+}
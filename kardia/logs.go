@@ -0,0 +1,130 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package kardia
+package kardia
+
+import (
+	"context"
+
+	"github.com/kardiachain/go-kardia"
+	"github.com/kardiachain/go-kardia/types"
+	"go.uber.org/zap"
+)
+
+const (
+	// MaxLogsBlockRange is the widest block range sent in a single kai_getLogs request.
+	// Wider queries are split into consecutive chunks of this size.
+	MaxLogsBlockRange uint64 = 5000
+)
+
+// FilterLogs executes a filter query against kai_getLogs and returns all matching logs.
+// FromBlock 0 is the genesis block and ToBlock 0 the latest block, ranges wider than MaxLogsBlockRange
+// are fetched chunk by chunk.
+func (n *node) FilterLogs(ctx context.Context, query kardia.FilterQuery) ([]types.Log, error) {
+	if query.BlockHash != nil {
+		return n.getLogs(ctx, query)
+	}
+	// the genesis block has no logs and the node treats fromBlock 0 as latest, start from the first block instead
+	fromBlock := query.FromBlock
+	if fromBlock == 0 {
+		fromBlock = 1
+	}
+	toBlock := query.ToBlock
+	if toBlock == 0 {
+		latest, err := n.LatestBlockNumber(ctx)
+		if err != nil {
+			return nil, err
+		}
+		toBlock = latest
+	}
+	if fromBlock > toBlock {
+		return nil, ErrInvalidBlockRange
+	}
+
+	var logs []types.Log
+	for from := fromBlock; from <= toBlock; from += MaxLogsBlockRange {
+		to := from + MaxLogsBlockRange - 1
+		if to > toBlock || to < from {
+			to = toBlock
+		}
+		chunk := query
+		chunk.FromBlock, chunk.ToBlock = from, to
+		result, err := n.getLogs(ctx, chunk)
+		if err != nil {
+			n.lgr.Error("Get logs error", zap.Uint64("From", from), zap.Uint64("To", to), zap.Error(err))
+			return nil, err
+		}
+		logs = append(logs, result...)
+		if to == toBlock {
+			break
+		}
+	}
+	return logs, nil
+}
+
+func (n *node) getLogs(ctx context.Context, query kardia.FilterQuery) ([]types.Log, error) {
+	arg, err := toFilterArg(query)
+	if err != nil {
+		return nil, err
+	}
+	var result []types.Log
+	if err := n.client.CallContext(ctx, &result, "kai_getLogs", arg); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// toFilterArg converts a filter query into kai_getLogs/kai_subscribe arguments.
+// Block heights are sent as plain numbers, 0 as fromBlock means genesis block and 0 as toBlock latest block.
+func toFilterArg(q kardia.FilterQuery) (interface{}, error) {
+	arg := map[string]interface{}{}
+	if len(q.Addresses) > 0 {
+		arg["address"] = q.Addresses
+	}
+	if len(q.Topics) > 0 {
+		topics := make([]interface{}, len(q.Topics))
+		for i, t := range q.Topics {
+			// an empty position matches any topic
+			if len(t) == 0 {
+				topics[i] = nil
+				continue
+			}
+			topics[i] = t
+		}
+		arg["topics"] = topics
+	}
+	if q.BlockHash != nil {
+		if q.FromBlock != 0 || q.ToBlock != 0 {
+			return nil, ErrInvalidFilterQuery
+		}
+		arg["blockHash"] = *q.BlockHash
+		return arg, nil
+	}
+	// the node treats fromBlock 0 as latest, the genesis block has no logs
+	if q.FromBlock == 0 {
+		arg["fromBlock"] = 1
+	} else {
+		arg["fromBlock"] = q.FromBlock
+	}
+	if q.ToBlock == 0 {
+		arg["toBlock"] = "latest"
+	} else {
+		arg["toBlock"] = q.ToBlock
+	}
+	return arg, nil
+}
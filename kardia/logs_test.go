@@ -0,0 +1,134 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package kardia
+package kardia
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kardiachain/go-kardia"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/mainchain/filters"
+	"github.com/kardiachain/go-kardia/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockLogsAPI struct {
+	latest  uint64
+	queries []filters.FilterCriteria
+}
+
+func (api *mockLogsAPI) BlockNumber() uint64 {
+	return api.latest
+}
+
+// GetLogs returns one log per requested range, tagged with the range start
+func (api *mockLogsAPI) GetLogs(crit filters.FilterCriteria) ([]*types.Log, error) {
+	api.queries = append(api.queries, crit)
+	log := &types.Log{
+		BlockHeight: crit.FromBlock,
+		Data:        common.Bytes{0x01},
+	}
+	if len(crit.Addresses) > 0 {
+		log.Address = crit.Addresses[0]
+	}
+	if crit.BlockHash != nil {
+		log.BlockHash = *crit.BlockHash
+	}
+	return []*types.Log{log}, nil
+}
+
+func TestLogs_FilterLogs(t *testing.T) {
+	api := &mockLogsAPI{latest: 12000}
+	node, err := setupMockNodeInstance(map[string]interface{}{"kai": api})
+	assert.Nil(t, err)
+
+	addr := common.HexToAddress("0x9e003D7e05E19514aa76DcFF4EB2443522677288")
+	topicA, topicB, topicC := common.HexToHash("0x0a"), common.HexToHash("0x0b"), common.HexToHash("0x0c")
+	logs, err := node.FilterLogs(context.Background(), kardia.FilterQuery{
+		FromBlock: 100,
+		ToBlock:   200,
+		Addresses: []common.Address{addr},
+		Topics:    [][]common.Hash{{topicA, topicB}, {}, {topicC}},
+	})
+	assert.Nil(t, err)
+	assert.Len(t, logs, 1)
+	assert.Equal(t, addr, logs[0].Address)
+	assert.Equal(t, uint64(100), logs[0].BlockHeight)
+
+	crit := api.queries[0]
+	assert.Equal(t, uint64(200), crit.ToBlock)
+	assert.Equal(t, [][]common.Hash{{topicA, topicB}, nil, {topicC}}, crit.Topics)
+}
+
+func TestLogs_FilterLogsByBlockHash(t *testing.T) {
+	api := &mockLogsAPI{}
+	node, err := setupMockNodeInstance(map[string]interface{}{"kai": api})
+	assert.Nil(t, err)
+
+	hash := common.HexToHash("0x01")
+	logs, err := node.FilterLogs(context.Background(), kardia.FilterQuery{BlockHash: &hash})
+	assert.Nil(t, err)
+	assert.Len(t, logs, 1)
+	assert.Equal(t, hash, logs[0].BlockHash)
+
+	_, err = node.FilterLogs(context.Background(), kardia.FilterQuery{BlockHash: &hash, FromBlock: 1})
+	assert.Equal(t, ErrInvalidFilterQuery, err)
+}
+
+func TestLogs_FilterLogsChunked(t *testing.T) {
+	api := &mockLogsAPI{latest: 2*MaxLogsBlockRange + 10}
+	node, err := setupMockNodeInstance(map[string]interface{}{"kai": api})
+	assert.Nil(t, err)
+
+	logs, err := node.FilterLogs(context.Background(), kardia.FilterQuery{FromBlock: 1})
+	assert.Nil(t, err)
+	assert.Len(t, logs, 3)
+	assert.Len(t, api.queries, 3)
+	assert.Equal(t, uint64(1), api.queries[0].FromBlock)
+	assert.Equal(t, MaxLogsBlockRange, api.queries[0].ToBlock)
+	assert.Equal(t, MaxLogsBlockRange+1, api.queries[1].FromBlock)
+	assert.Equal(t, 2*MaxLogsBlockRange+1, api.queries[2].FromBlock)
+	assert.Equal(t, api.latest, api.queries[2].ToBlock)
+
+	_, err = node.FilterLogs(context.Background(), kardia.FilterQuery{FromBlock: 10, ToBlock: 5})
+	assert.Equal(t, ErrInvalidBlockRange, err)
+}
+
+func TestLogs_FilterLogsFromGenesis(t *testing.T) {
+	api := &mockLogsAPI{latest: 20}
+	node, err := setupMockNodeInstance(map[string]interface{}{"kai": api})
+	assert.Nil(t, err)
+
+	// FromBlock 0 is the genesis block, with or without ToBlock
+	_, err = node.FilterLogs(context.Background(), kardia.FilterQuery{})
+	assert.Nil(t, err)
+	_, err = node.FilterLogs(context.Background(), kardia.FilterQuery{ToBlock: 10})
+	assert.Nil(t, err)
+	assert.Len(t, api.queries, 2)
+	assert.Equal(t, uint64(1), api.queries[0].FromBlock)
+	assert.Equal(t, api.latest, api.queries[0].ToBlock)
+	assert.Equal(t, uint64(1), api.queries[1].FromBlock)
+	assert.Equal(t, uint64(10), api.queries[1].ToBlock)
+
+	arg, err := toFilterArg(kardia.FilterQuery{})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, arg.(map[string]interface{})["fromBlock"])
+	assert.Equal(t, "latest", arg.(map[string]interface{})["toBlock"])
+}
@@ -0,0 +1,120 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package kardia
+package kardia
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	neturl "net/url"
+	"strings"
+)
+
+// MetadataFetcher fetches the metadata document at a token URI
+type MetadataFetcher interface {
+	FetchMetadata(ctx context.Context, uri string) ([]byte, error)
+}
+
+// MetadataFetcherFunc is a function implementing MetadataFetcher
+type MetadataFetcherFunc func(ctx context.Context, uri string) ([]byte, error)
+
+func (f MetadataFetcherFunc) FetchMetadata(ctx context.Context, uri string) ([]byte, error) {
+	return f(ctx, uri)
+}
+
+// DefaultMetadataFetcher is the fetcher of the tokens created by NewKRC721Token
+var DefaultMetadataFetcher MetadataFetcher = &HTTPMetadataFetcher{IPFSGateway: "https://ipfs.io/ipfs/"}
+
+// defaultMaxMetadataSize is the default HTTPMetadataFetcher.MaxSize
+const defaultMaxMetadataSize = 1 << 20
+
+// HTTPMetadataFetcher fetches http and https URIs, ipfs URIs through an IPFS gateway and inline data URIs
+type HTTPMetadataFetcher struct {
+	// Client sends the requests, http.DefaultClient if nil
+	Client *http.Client
+	// IPFSGateway is the URL prefix ipfs://<path> URIs are fetched from, e.g. https://ipfs.io/ipfs/
+	IPFSGateway string
+	// MaxSize is the maximum size of a document, 1MB if 0
+	MaxSize int64
+}
+
+func (f *HTTPMetadataFetcher) FetchMetadata(ctx context.Context, uri string) ([]byte, error) {
+	switch {
+	case strings.HasPrefix(uri, "data:"):
+		return decodeDataURI(uri)
+	case strings.HasPrefix(uri, "ipfs://"):
+		if f.IPFSGateway == "" {
+			return nil, fmt.Errorf("%w: %s, no IPFS gateway", ErrUnsupportedURI, uri)
+		}
+		path := strings.TrimPrefix(strings.TrimPrefix(uri, "ipfs://"), "ipfs/")
+		uri = strings.TrimSuffix(f.IPFSGateway, "/") + "/" + path
+	case strings.HasPrefix(uri, "http://"), strings.HasPrefix(uri, "https://"):
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedURI, uri)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	client := f.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch metadata %s: %s", uri, resp.Status)
+	}
+	maxSize := f.MaxSize
+	if maxSize == 0 {
+		maxSize = defaultMaxMetadataSize
+	}
+	data, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxSize {
+		return nil, ErrMetadataTooLarge
+	}
+	return data, nil
+}
+
+// decodeDataURI returns the content of a data:[<mediatype>][;base64],<data> URI
+func decodeDataURI(uri string) ([]byte, error) {
+	comma := strings.IndexByte(uri, ',')
+	if comma < 0 {
+		return nil, fmt.Errorf("%w: malformed data URI", ErrUnsupportedURI)
+	}
+	header, data := uri[len("data:"):comma], uri[comma+1:]
+	if strings.HasSuffix(header, ";base64") {
+		return base64.StdEncoding.DecodeString(data)
+	}
+	unescaped, err := neturl.PathUnescape(data)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(unescaped), nil
+}
@@ -0,0 +1,105 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package kardia
+package kardia
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kardiachain/go-kardia/lib/abi"
+	"github.com/kardiachain/go-kardia/lib/common"
+
+	"github.com/kardiachain/go-kaiclient/kardia/smc"
+)
+
+// Multicall aggregates contract calls into one call of a Multicall2 or Multicall3 aggregator contract,
+// through its tryAggregate method
+type Multicall struct {
+	node    Node
+	abi     *abi.ABI
+	address common.Address
+}
+
+type multicallCall struct {
+	Target   common.Address
+	CallData []byte
+}
+
+// NewMulticall binds the aggregator deployed at address
+func NewMulticall(node Node, address string) (*Multicall, error) {
+	multicallABI, err := abi.JSON(strings.NewReader(smc.MulticallABI))
+	if err != nil {
+		return nil, err
+	}
+	return &Multicall{
+		node:    node,
+		abi:     &multicallABI,
+		address: common.HexToAddress(address),
+	}, nil
+}
+
+// Aggregate executes calls in one call of the aggregator, against the block selected by ctx. Only the To and Data
+// fields of calls are used, the aggregator is the sender of the calls. The results are in the order of calls,
+// a failed call has a *RevertError decoded from its revert data.
+func (m *Multicall) Aggregate(ctx context.Context, calls []SMCCallArgs) ([]*CallResult, error) {
+	if len(calls) == 0 {
+		return nil, nil
+	}
+	aggregated := make([]multicallCall, len(calls))
+	for i, call := range calls {
+		if call.To == nil {
+			return nil, fmt.Errorf("call %d: %w", i, ErrMissingCallTarget)
+		}
+		aggregated[i] = multicallCall{Target: common.HexToAddress(*call.To), CallData: common.FromHex(call.Data)}
+	}
+	payload, err := m.abi.Pack("tryAggregate", false, aggregated)
+	if err != nil {
+		return nil, err
+	}
+	res, err := m.node.KardiaCall(ctx, ConstructCallArgs(m.address.Hex(), payload))
+	if err != nil {
+		return nil, err
+	}
+	var out []struct {
+		Success    bool
+		ReturnData []byte
+	}
+	if err := m.abi.UnpackIntoInterface(&out, "tryAggregate", res); err != nil {
+		return nil, err
+	}
+	if len(out) != len(calls) {
+		return nil, fmt.Errorf("multicall: %d results for %d calls", len(out), len(calls))
+	}
+
+	results := make([]*CallResult, len(calls))
+	for i, r := range out {
+		if r.Success {
+			results[i] = &CallResult{Data: r.ReturnData}
+			continue
+		}
+		revert := &RevertError{Data: r.ReturnData}
+		(*RevertDecoder)(nil).decodeData(revert)
+		if input, err := m.node.DecodeInputData(*calls[i].To, calls[i].Data); err == nil && input != nil {
+			revert.Method, revert.Input = input.MethodName, input
+		}
+		results[i] = &CallResult{Err: revert}
+	}
+	return results, nil
+}
@@ -0,0 +1,83 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package kardia
+package kardia
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMulticall_Aggregate(t *testing.T) {
+	node, auth, token, closeFn := setupSimulatedKRC721(t, "1", "2")
+	defer closeFn()
+	ctx := context.Background()
+	address, txHash, err := node.DeployMulticall(auth)
+	assert.Nil(t, err)
+	_, err = node.WaitMined(ctx, txHash.Hex())
+	assert.Nil(t, err)
+	multicall, err := NewMulticall(node, address.Hex())
+	assert.Nil(t, err)
+	calls := testKRC721Calls(t, token.ABI(), token.(*krc721Token).c.ContractAddress, auth.From)
+
+	results, err := multicall.Aggregate(ctx, calls)
+	assert.Nil(t, err)
+	batched, err := node.BatchKardiaCall(ctx, calls)
+	assert.Nil(t, err)
+	assert.Len(t, results, len(calls))
+	for i, result := range results {
+		if i == 2 {
+			var revertErr *RevertError
+			assert.True(t, errors.As(result.Err, &revertErr))
+			assert.Equal(t, "KRC721: nonexistent token", revertErr.Reason)
+			assert.NotEmpty(t, revertErr.Data)
+			continue
+		}
+		assert.Nil(t, result.Err)
+		assert.Equal(t, batched[i].Data, result.Data)
+	}
+
+	// calls are executed against the block of ctx
+	height, err := node.LatestBlockNumber(ctx)
+	assert.Nil(t, err)
+	receiver := common.HexToAddress("0x0000000000000000000000000000000000c0ffee")
+	tx, err := token.TransferFrom(auth, auth.From, receiver, big.NewInt(1))
+	assert.Nil(t, err)
+	_, err = node.WaitMined(ctx, tx.Hash().Hex())
+	assert.Nil(t, err)
+	var owners []common.Address
+	for _, c := range []context.Context{ctx, WithBlock(ctx, BlockAtHeight(height))} {
+		results, err := multicall.Aggregate(c, calls[1:2])
+		assert.Nil(t, err)
+		var owner common.Address
+		assert.Nil(t, token.ABI().UnpackIntoInterface(&owner, "ownerOf", results[0].Data))
+		owners = append(owners, owner)
+	}
+	assert.Equal(t, []common.Address{receiver, auth.From}, owners)
+
+	_, err = multicall.Aggregate(ctx, []SMCCallArgs{{Data: "0x"}})
+	assert.True(t, errors.Is(err, ErrMissingCallTarget))
+	results, err = multicall.Aggregate(ctx, nil)
+	assert.Nil(t, err)
+	assert.Len(t, results, 0)
+}
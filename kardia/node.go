@@ -22,11 +22,10 @@ import (
 	"context"
 	"math/big"
 	"strings"
+	"sync"
 
 	"github.com/kardiachain/go-kardia"
 	"github.com/kardiachain/go-kardia/lib/abi/bind"
-	"github.com/kardiachain/go-kardia/lib/event"
-	"github.com/kardiachain/go-kardia/types"
 	"go.uber.org/zap"
 
 	"github.com/kardiachain/go-kardia/lib/abi"
@@ -52,41 +51,55 @@ type Node interface {
 	IReceipt
 	IContract
 	IStaking
+	IStakingTx
+	IParams
+	IRewards
+	IUptime
+	ISlash
 	ITx
 	ISubscription
+	IGas
+	IToken
+	IBatch
 
 	IValidator
+	IValidatorTx
 	IDelegator
 
 	bind.ContractCaller
+	bind.PendingContractCaller
 	bind.ContractTransactor
 	bind.ContractBackend
 
 	// For test/dev network only, please use with careful
 	DeployKRC20(auth *bind.TransactOpts) (common.Address, common.Hash, error)
-}
-
-func (n *node) FilterLogs(ctx context.Context, query kardia.FilterQuery) ([]types.Log, error) {
-	panic("implement me")
-}
-
-func (n *node) SubscribeFilterLogs(ctx context.Context, query kardia.FilterQuery, ch chan<- types.Log) (event.Subscription, error) {
-	panic("implement me")
+	DeployKRC721(auth *bind.TransactOpts) (common.Address, common.Hash, error)
+	DeployKRC1155(auth *bind.TransactOpts) (common.Address, common.Hash, error)
+	DeployMulticall(auth *bind.TransactOpts) (common.Address, common.Hash, error)
 }
 
 type node struct {
 	client *rpc.Client
-	isLive bool
 	url    string
 
+	// healthStatus is set by HealthMonitor, accessed atomically
+	healthStatus int32
+
 	lgr *zap.Logger
 
+	// gasMu guards the gas settings, which may be changed while transactions are sent
+	gasMu            sync.RWMutex
+	gasPriceStrategy GasPriceStrategy
+	gasLimitMargin   uint64
+
 	// SMC
 	stakingSMC   *Contract
 	validatorSMC *Contract
 	paramsSMC    *Contract
 	krc20SMC     *Contract
 	krc721SMC    *Contract
+
+	tokenTypes tokenTypeCache
 }
 
 func (n *node) Url() string {
@@ -95,28 +108,33 @@ func (n *node) Url() string {
 
 //ContractTransactor
 func (n *node) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
-	panic("implement me")
+	return n.Code(WithBlock(ctx, PendingBlock), account.Hex())
 }
 
 func (n *node) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
 	return n.NonceAt(ctx, account.String())
 }
 
-func (n *node) SuggestGasPrice(ctx context.Context) (uint64, error) {
-	panic("implement me")
-}
-
-func (n *node) EstimateGas(ctx context.Context, call kardia.CallMsg) (gas uint64, err error) {
-	panic("implement me")
-}
-
 //ContractCaller
+// CodeAt returns the contract code at blockNumber, 0 means the block selected by ctx (latest by default)
 func (n *node) CodeAt(ctx context.Context, contract common.Address, blockNumber uint64) ([]byte, error) {
-	panic("implement me")
+	if blockNumber != 0 {
+		ctx = WithBlock(ctx, BlockAtHeight(blockNumber))
+	}
+	return n.Code(ctx, contract.Hex())
 }
 
+// CallContract executes call at blockNumber, 0 means the block selected by ctx (latest by default)
 func (n *node) CallContract(ctx context.Context, call kardia.CallMsg, blockNumber uint64) ([]byte, error) {
-	panic("implement me")
+	if blockNumber != 0 {
+		ctx = WithBlock(ctx, BlockAtHeight(blockNumber))
+	}
+	return n.KardiaCall(ctx, toCallArgs(call))
+}
+
+//PendingContractCaller
+func (n *node) PendingCallContract(ctx context.Context, call kardia.CallMsg) ([]byte, error) {
+	return n.KardiaCall(WithBlock(ctx, PendingBlock), toCallArgs(call))
 }
 
 func NewNode(url string, lgr *zap.Logger) (Node, error) {
@@ -179,10 +197,6 @@ func (n *node) setupSMC() error {
 	return nil
 }
 
-func (n *node) IsAlive() bool {
-	return true
-}
-
 func (n *node) NodeInfo(ctx context.Context) (*NodeInfo, error) {
 	var (
 		node  *NodeInfo
@@ -209,11 +223,13 @@ func (n *node) NodeInfo(ctx context.Context) (*NodeInfo, error) {
 	return node, nil
 }
 
+// KardiaCall executes a contract call against the block selected by ctx, latest by default.
+// A *RevertError is returned if the call is reverted.
 func (n *node) KardiaCall(ctx context.Context, args SMCCallArgs) ([]byte, error) {
 	var result common.Bytes
-	err := n.client.CallContext(ctx, &result, "kai_kardiaCall", args, "latest")
+	err := n.client.CallContext(ctx, &result, "kai_kardiaCall", args, BlockFromContext(ctx).arg())
 	if err != nil {
-		return nil, err
+		return nil, n.asRevertError(err, args, nil)
 	}
 	return result, nil
 }
@@ -19,43 +19,145 @@
 package kardia
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/kardiachain/go-kardia"
+	"github.com/kardiachain/go-kardia/lib/abi/bind"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/event"
+	kai "github.com/kardiachain/go-kardia/mainchain"
+	"github.com/kardiachain/go-kardia/rpc"
+	"github.com/kardiachain/go-kardia/types"
 	"go.uber.org/zap"
+
+	"github.com/kardiachain/go-kaiclient/metrics"
+)
+
+const (
+	// BalanceRoundRobin spreads reads evenly across public nodes
+	BalanceRoundRobin BalanceStrategy = iota
+	// BalanceLeastLatency sends reads to the public node with the lowest average latency
+	BalanceLeastLatency
+)
+
+const (
+	defaultFailureCooldown = 30 * time.Second
 )
 
-//Nodes like utils struct, which allow user pass trustedNodeUrl and publicNodeUrl
-//for better balancing/performance without stress a specific node
+type BalanceStrategy int
+
+// Nodes like utils struct, which allow user pass trustedNodeUrl and publicNodeUrl
+// for better balancing/performance without stress a specific node.
+// Reads are balanced across healthy public nodes, writes and nonce/gas/subscription
+// calls are sent to trusted nodes. Both fail over to the next endpoint on connection errors.
+// Nodes reported unhealthy by HealthMonitor are skipped.
 type Nodes interface {
+	Node
+	HealthMonitor() *HealthMonitor
+	// Close stops the health monitor and closes the connections to the nodes
+	Close()
+}
+
+type endpoint struct {
+	Node
+	trusted bool
+
+	mu          sync.Mutex
+	latency     metrics.AverageDuration
+	failedUntil time.Time
+}
+
+func (e *endpoint) record(duration time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.latency.Add(duration)
+}
+
+func (e *endpoint) avgLatency() int64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.latency.RollingAvg
+}
+
+func (e *endpoint) fail(cooldown time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.failedUntil = time.Now().Add(cooldown)
+}
+
+func (e *endpoint) available() bool {
+	if !e.IsAlive() {
+		return false
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return time.Now().After(e.failedUntil)
 }
 
 type nodes struct {
-	trusted []Node
-	public  []Node
+	trusted []*endpoint
+	public  []*endpoint
 
 	total  uint64
 	logger *zap.Logger
+
+	strategy        BalanceStrategy
+	failureCooldown time.Duration
+	monitor         *HealthMonitor
+
+	counter uint64
+
+	tokenTypes tokenTypeCache
 }
 
 type NodesConfig struct {
 	TrustedNodeUrls []string
 	PublicNodeUrls  []string
 
+	// Strategy used to balance reads between public nodes, default BalanceRoundRobin
+	Strategy BalanceStrategy
+	// MaxBlockLag excludes nodes lagging more than MaxBlockLag blocks behind the highest known head,
+	// 0 disables lag detection
+	MaxBlockLag uint64
+	// Network excludes nodes reporting another NodeInfo.Network, empty accepts any network
+	Network string
+	// HealthCheckInterval is the interval between two health checks, default 10s
+	HealthCheckInterval time.Duration
+	// FailureCooldown is how long a node is skipped after a connection error, default 30s
+	FailureCooldown time.Duration
+
 	Logger *zap.Logger
 }
 
 func NewNodes(cfg NodesConfig) (Nodes, error) {
-	nodes := &nodes{}
+	if cfg.Logger == nil {
+		cfg.Logger = zap.L()
+	}
+	nodes := &nodes{
+		logger:          cfg.Logger,
+		strategy:        cfg.Strategy,
+		failureCooldown: cfg.FailureCooldown,
+	}
+	if nodes.failureCooldown == 0 {
+		nodes.failureCooldown = defaultFailureCooldown
+	}
 	for id, url := range cfg.TrustedNodeUrls {
 		lgr := cfg.Logger.
 			With(zap.String("type", "trusted")).
 			With(zap.Int("id", id))
 		n, err := NewNode(url, lgr)
 		if err != nil {
-			zap.S().Warn("cannot connect to url", url)
+			cfg.Logger.Warn("cannot connect to url", zap.String("url", url), zap.Error(err))
 			continue
 		}
-		nodes.trusted = append(nodes.trusted, n)
+		nodes.trusted = append(nodes.trusted, &endpoint{Node: n, trusted: true})
 		nodes.total++
 	}
 
@@ -65,10 +167,10 @@ func NewNodes(cfg NodesConfig) (Nodes, error) {
 			With(zap.Int("id", id))
 		n, err := NewNode(url, lgr)
 		if err != nil {
-			zap.S().Warn("cannot connect to url", url)
+			cfg.Logger.Warn("cannot connect to url", zap.String("url", url), zap.Error(err))
 			continue
 		}
-		nodes.trusted = append(nodes.trusted, n)
+		nodes.public = append(nodes.public, &endpoint{Node: n})
 		nodes.total++
 	}
 
@@ -76,5 +178,901 @@ func NewNodes(cfg NodesConfig) (Nodes, error) {
 		return nil, fmt.Errorf("no node available")
 	}
 
+	var monitored []Node
+	for _, e := range nodes.all() {
+		monitored = append(monitored, e.Node)
+	}
+	nodes.monitor = NewHealthMonitor(HealthConfig{
+		Interval:    cfg.HealthCheckInterval,
+		MaxBlockLag: cfg.MaxBlockLag,
+		Network:     cfg.Network,
+		Logger:      cfg.Logger,
+	}, monitored...)
+	nodes.monitor.Start()
+
 	return nodes, nil
 }
+
+// readCandidates returns healthy public nodes ordered by the balance strategy,
+// followed by trusted nodes as fallback.
+func (ns *nodes) readCandidates() []*endpoint {
+	var public []*endpoint
+	for _, e := range ns.public {
+		if e.available() {
+			public = append(public, e)
+		}
+	}
+	if len(public) > 1 {
+		switch ns.strategy {
+		case BalanceLeastLatency:
+			sort.SliceStable(public, func(i, j int) bool { return public[i].avgLatency() < public[j].avgLatency() })
+		default:
+			start := int(atomic.AddUint64(&ns.counter, 1) % uint64(len(public)))
+			public = append(public[start:], public[:start]...)
+		}
+	}
+	candidates := public
+	for _, e := range ns.trusted {
+		if e.available() {
+			candidates = append(candidates, e)
+		}
+	}
+	return ns.orAll(candidates)
+}
+
+// trustedCandidates returns healthy trusted nodes in configured order, so writes stick to
+// the same node while it is healthy. Public nodes are used only without trusted nodes.
+func (ns *nodes) trustedCandidates() []*endpoint {
+	pool := ns.trusted
+	if len(pool) == 0 {
+		pool = ns.public
+	}
+	var candidates []*endpoint
+	for _, e := range pool {
+		if e.available() {
+			candidates = append(candidates, e)
+		}
+	}
+	if len(candidates) == 0 {
+		return pool
+	}
+	return candidates
+}
+
+// orAll returns every node when none is healthy, a last resort is better than failing without trying
+func (ns *nodes) orAll(candidates []*endpoint) []*endpoint {
+	if len(candidates) > 0 {
+		return candidates
+	}
+	all := make([]*endpoint, 0, ns.total)
+	all = append(all, ns.public...)
+	return append(all, ns.trusted...)
+}
+
+// isFailoverError reports whether err is caused by the endpoint itself rather than by the request,
+// responses from the node (JSON-RPC errors, not found) are returned to the caller as is.
+func isFailoverError(err error) bool {
+	var (
+		rpcErr    rpc.Error
+		txFailure *TxFailedError
+		txSent    *TxSentError
+	)
+	switch {
+	case errors.As(err, &rpcErr),
+		errors.As(err, &txFailure),
+		errors.As(err, &txSent),
+		errors.Is(err, kardia.NotFound),
+		errors.Is(err, ErrEmptyList),
+		errors.Is(err, ErrTxNotFailed),
+		errors.Is(err, ErrRevertNotReproduced),
+		errors.Is(err, context.Canceled),
+		errors.Is(err, context.DeadlineExceeded):
+		return false
+	}
+	return true
+}
+
+func (ns *nodes) call(ctx context.Context, candidates []*endpoint, fn func(n Node) error) error {
+	err := ErrNoNodeAvailable
+	for _, e := range candidates {
+		start := time.Now()
+		err = fn(e.Node)
+		e.record(time.Since(start))
+		if err == nil || !isFailoverError(err) || ctx.Err() != nil {
+			return err
+		}
+		ns.logger.Warn("Node failed, trying next one", zap.String("url", e.Url()), zap.Error(err))
+		e.fail(ns.failureCooldown)
+	}
+	return err
+}
+
+// read runs fn on public nodes with failover
+func (ns *nodes) read(ctx context.Context, fn func(n Node) error) error {
+	return ns.call(ctx, ns.readCandidates(), fn)
+}
+
+// trustedCall runs fn on trusted nodes with failover
+func (ns *nodes) trustedCall(ctx context.Context, fn func(n Node) error) error {
+	return ns.call(ctx, ns.trustedCandidates(), fn)
+}
+
+// primary returns the node used for calls without network access
+func (ns *nodes) primary() Node {
+	if len(ns.trusted) > 0 {
+		return ns.trusted[0].Node
+	}
+	return ns.public[0].Node
+}
+
+func (ns *nodes) all() []*endpoint {
+	return append(append([]*endpoint{}, ns.trusted...), ns.public...)
+}
+
+// Url returns the url of the node writes are currently sent to
+func (ns *nodes) Url() string {
+	candidates := ns.trustedCandidates()
+	return candidates[0].Url()
+}
+
+// HealthMonitor returns the monitor checking every node of the pool
+func (ns *nodes) HealthMonitor() *HealthMonitor {
+	return ns.monitor
+}
+
+func (ns *nodes) Close() {
+	if ns.monitor != nil {
+		ns.monitor.Stop()
+	}
+	for _, e := range ns.all() {
+		if n, ok := e.Node.(*node); ok {
+			n.client.Close()
+		}
+	}
+}
+
+// IsAlive returns true if any node is alive
+func (ns *nodes) IsAlive() bool {
+	for _, e := range ns.all() {
+		if e.IsAlive() {
+			return true
+		}
+	}
+	return false
+}
+
+func (ns *nodes) DecodeInputData(to string, input string) (*FunctionCall, error) {
+	return ns.primary().DecodeInputData(to, input)
+}
+
+func (ns *nodes) StakingContact(ctx context.Context) *Contract {
+	return ns.primary().StakingContact(ctx)
+}
+
+func (ns *nodes) ValidatorContact(ctx context.Context) *Contract {
+	return ns.primary().ValidatorContact(ctx)
+}
+
+func (ns *nodes) SetGasPriceStrategy(strategy GasPriceStrategy) {
+	for _, e := range ns.all() {
+		e.SetGasPriceStrategy(strategy)
+	}
+}
+
+func (ns *nodes) SetGasLimitMargin(percent uint64) {
+	for _, e := range ns.all() {
+		e.SetGasLimitMargin(percent)
+	}
+}
+
+func (ns *nodes) DeployKRC20(auth *bind.TransactOpts) (common.Address, common.Hash, error) {
+	var (
+		address common.Address
+		txHash  common.Hash
+	)
+	err := ns.trustedCall(context.Background(), func(n Node) (err error) {
+		address, txHash, err = n.DeployKRC20(auth)
+		return err
+	})
+	return address, txHash, err
+}
+
+func (ns *nodes) DeployKRC721(auth *bind.TransactOpts) (common.Address, common.Hash, error) {
+	var (
+		address common.Address
+		txHash  common.Hash
+	)
+	err := ns.trustedCall(context.Background(), func(n Node) (err error) {
+		address, txHash, err = n.DeployKRC721(auth)
+		return err
+	})
+	return address, txHash, err
+}
+
+func (ns *nodes) DeployKRC1155(auth *bind.TransactOpts) (common.Address, common.Hash, error) {
+	var (
+		address common.Address
+		txHash  common.Hash
+	)
+	err := ns.trustedCall(context.Background(), func(n Node) (err error) {
+		address, txHash, err = n.DeployKRC1155(auth)
+		return err
+	})
+	return address, txHash, err
+}
+
+func (ns *nodes) DeployMulticall(auth *bind.TransactOpts) (common.Address, common.Hash, error) {
+	var (
+		address common.Address
+		txHash  common.Hash
+	)
+	err := ns.trustedCall(context.Background(), func(n Node) (err error) {
+		address, txHash, err = n.DeployMulticall(auth)
+		return err
+	})
+	return address, txHash, err
+}
+
+func (ns *nodes) DetectTokenType(ctx context.Context, address string) (int, error) {
+	return ns.tokenTypes.detect(ctx, ns, address)
+}
+
+func (ns *nodes) NodeInfo(ctx context.Context) (*NodeInfo, error) {
+	var result *NodeInfo
+	err := ns.read(ctx, func(n Node) (err error) {
+		result, err = n.NodeInfo(ctx)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) GetCirculatingSupply(ctx context.Context) (*big.Int, error) {
+	var result *big.Int
+	err := ns.read(ctx, func(n Node) (err error) {
+		result, err = n.GetCirculatingSupply(ctx)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) KardiaCall(ctx context.Context, args SMCCallArgs) ([]byte, error) {
+	var result []byte
+	err := ns.read(ctx, func(n Node) (err error) {
+		result, err = n.KardiaCall(ctx, args)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) BatchCall(ctx context.Context, elems []rpc.BatchElem) error {
+	return ns.read(ctx, func(n Node) error {
+		return n.BatchCall(ctx, elems)
+	})
+}
+
+func (ns *nodes) BatchKardiaCall(ctx context.Context, calls []SMCCallArgs) ([]*CallResult, error) {
+	var result []*CallResult
+	err := ns.read(ctx, func(n Node) (err error) {
+		result, err = n.BatchKardiaCall(ctx, calls)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) Balance(ctx context.Context, addressHash string) (string, error) {
+	var result string
+	err := ns.read(ctx, func(n Node) (err error) {
+		result, err = n.Balance(ctx, addressHash)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) StorageAt(ctx context.Context, addressHash string, key string) ([]byte, error) {
+	var result []byte
+	err := ns.read(ctx, func(n Node) (err error) {
+		result, err = n.StorageAt(ctx, addressHash, key)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) Code(ctx context.Context, addressHash string) (common.Bytes, error) {
+	var result common.Bytes
+	err := ns.read(ctx, func(n Node) (err error) {
+		result, err = n.Code(ctx, addressHash)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) NonceAt(ctx context.Context, addressHash string) (uint64, error) {
+	var result uint64
+	err := ns.trustedCall(ctx, func(n Node) (err error) {
+		result, err = n.NonceAt(ctx, addressHash)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) LatestBlockNumber(ctx context.Context) (uint64, error) {
+	var result uint64
+	err := ns.read(ctx, func(n Node) (err error) {
+		result, err = n.LatestBlockNumber(ctx)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) BlockByHash(ctx context.Context, hash string) (*Block, error) {
+	var result *Block
+	err := ns.read(ctx, func(n Node) (err error) {
+		result, err = n.BlockByHash(ctx, hash)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) BlockByHeight(ctx context.Context, height uint64) (*Block, error) {
+	var result *Block
+	err := ns.read(ctx, func(n Node) (err error) {
+		result, err = n.BlockByHeight(ctx, height)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) BlockHeaderByHash(ctx context.Context, hash string) (*Header, error) {
+	var result *Header
+	err := ns.read(ctx, func(n Node) (err error) {
+		result, err = n.BlockHeaderByHash(ctx, hash)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) BlockHeaderByNumber(ctx context.Context, number uint64) (*Header, error) {
+	var result *Header
+	err := ns.read(ctx, func(n Node) (err error) {
+		result, err = n.BlockHeaderByNumber(ctx, number)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) GetValidators(ctx context.Context, height uint64) (*types.ValidatorSet, error) {
+	var result *types.ValidatorSet
+	err := ns.read(ctx, func(n Node) (err error) {
+		result, err = n.GetValidators(ctx, height)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) GetCommit(ctx context.Context, height uint64) (*types.Commit, error) {
+	var result *types.Commit
+	err := ns.read(ctx, func(n Node) (err error) {
+		result, err = n.GetCommit(ctx, height)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) GetProof(ctx context.Context, address common.Address, storageKeys []string, height uint64) (*kai.AccountResult, error) {
+	var result *kai.AccountResult
+	err := ns.read(ctx, func(n Node) (err error) {
+		result, err = n.GetProof(ctx, address, storageKeys, height)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) FullHeaderByNumber(ctx context.Context, height uint64) (*FullHeader, error) {
+	var result *FullHeader
+	err := ns.read(ctx, func(n Node) (err error) {
+		result, err = n.FullHeaderByNumber(ctx, height)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) TotalStakedAmount(ctx context.Context) (*big.Int, error) {
+	var result *big.Int
+	err := ns.read(ctx, func(n Node) (err error) {
+		result, err = n.TotalStakedAmount(ctx)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) ValidatorSMCAddresses(ctx context.Context) ([]common.Address, error) {
+	var result []common.Address
+	err := ns.read(ctx, func(n Node) (err error) {
+		result, err = n.ValidatorSMCAddresses(ctx)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) GetTransaction(ctx context.Context, hash string) (*Transaction, error) {
+	var result *Transaction
+	err := ns.read(ctx, func(n Node) (err error) {
+		result, err = n.GetTransaction(ctx, hash)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) GetTransactionReceipt(ctx context.Context, txHash string) (*Receipt, error) {
+	var result *Receipt
+	err := ns.read(ctx, func(n Node) (err error) {
+		result, err = n.GetTransactionReceipt(ctx, txHash)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) TxRevertReason(ctx context.Context, txHash string, d *RevertDecoder) (*RevertError, error) {
+	var result *RevertError
+	err := ns.read(ctx, func(n Node) (err error) {
+		result, err = n.TxRevertReason(ctx, txHash, d)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) WaitMined(ctx context.Context, txHash string) (*Receipt, error) {
+	var result *Receipt
+	err := ns.read(ctx, func(n Node) (err error) {
+		result, err = n.WaitMined(ctx, txHash)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) WaitConfirmed(ctx context.Context, txHash string, confirmations uint64) (*Receipt, error) {
+	var result *Receipt
+	err := ns.read(ctx, func(n Node) (err error) {
+		result, err = n.WaitConfirmed(ctx, txHash, confirmations)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	return ns.trustedCall(ctx, func(n Node) error {
+		return n.SendTransaction(ctx, tx)
+	})
+}
+
+func (ns *nodes) SendRawTransaction(ctx context.Context, tx *types.Transaction) error {
+	return ns.trustedCall(ctx, func(n Node) error {
+		return n.SendRawTransaction(ctx, tx)
+	})
+}
+
+func (ns *nodes) KaiSubscribe(ctx context.Context, channel interface{}, args ...interface{}) (*rpc.ClientSubscription, error) {
+	var result *rpc.ClientSubscription
+	err := ns.trustedCall(ctx, func(n Node) (err error) {
+		result, err = n.KaiSubscribe(ctx, channel, args...)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (*rpc.ClientSubscription, error) {
+	var result *rpc.ClientSubscription
+	err := ns.trustedCall(ctx, func(n Node) (err error) {
+		result, err = n.SubscribeNewHead(ctx, ch)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) SubscribeFilterLogs(ctx context.Context, query kardia.FilterQuery, ch chan<- types.Log) (event.Subscription, error) {
+	var result event.Subscription
+	err := ns.trustedCall(ctx, func(n Node) (err error) {
+		result, err = n.SubscribeFilterLogs(ctx, query, ch)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) FilterLogs(ctx context.Context, query kardia.FilterQuery) ([]types.Log, error) {
+	var result []types.Log
+	err := ns.read(ctx, func(n Node) (err error) {
+		result, err = n.FilterLogs(ctx, query)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) GasPrice(ctx context.Context) (*big.Int, error) {
+	var result *big.Int
+	err := ns.trustedCall(ctx, func(n Node) (err error) {
+		result, err = n.GasPrice(ctx)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) SuggestGasPrice(ctx context.Context) (uint64, error) {
+	var result uint64
+	err := ns.trustedCall(ctx, func(n Node) (err error) {
+		result, err = n.SuggestGasPrice(ctx)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) EstimateGas(ctx context.Context, call kardia.CallMsg) (uint64, error) {
+	var result uint64
+	err := ns.trustedCall(ctx, func(n Node) (err error) {
+		result, err = n.EstimateGas(ctx, call)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	var result uint64
+	err := ns.trustedCall(ctx, func(n Node) (err error) {
+		result, err = n.PendingNonceAt(ctx, account)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	var result []byte
+	err := ns.trustedCall(ctx, func(n Node) (err error) {
+		result, err = n.PendingCodeAt(ctx, account)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) PendingCallContract(ctx context.Context, call kardia.CallMsg) ([]byte, error) {
+	var result []byte
+	err := ns.trustedCall(ctx, func(n Node) (err error) {
+		result, err = n.PendingCallContract(ctx, call)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) CodeAt(ctx context.Context, contract common.Address, blockNumber uint64) ([]byte, error) {
+	var result []byte
+	err := ns.read(ctx, func(n Node) (err error) {
+		result, err = n.CodeAt(ctx, contract, blockNumber)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) CallContract(ctx context.Context, call kardia.CallMsg, blockNumber uint64) ([]byte, error) {
+	var result []byte
+	err := ns.read(ctx, func(n Node) (err error) {
+		result, err = n.CallContract(ctx, call, blockNumber)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) ValidatorInfo(ctx context.Context, validatorSMCAddress string) (*Validator, error) {
+	var result *Validator
+	err := ns.read(ctx, func(n Node) (err error) {
+		result, err = n.ValidatorInfo(ctx, validatorSMCAddress)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) SigningInfo(ctx context.Context, validatorSMCAddress string) (*SigningInfo, error) {
+	var result *SigningInfo
+	err := ns.read(ctx, func(n Node) (err error) {
+		result, err = n.SigningInfo(ctx, validatorSMCAddress)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) DelegatorAddresses(ctx context.Context, validatorSMCAddress string) ([]common.Address, error) {
+	var result []common.Address
+	err := ns.read(ctx, func(n Node) (err error) {
+		result, err = n.DelegatorAddresses(ctx, validatorSMCAddress)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) DelegatorsWithShare(ctx context.Context, validatorSMCAddress string) ([]*DelegatorWithShare, error) {
+	var result []*DelegatorWithShare
+	err := ns.read(ctx, func(n Node) (err error) {
+		result, err = n.DelegatorsWithShare(ctx, validatorSMCAddress)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) DelegationRewards(ctx context.Context, validatorSMCAddr, delegatorAddress string) (*big.Int, error) {
+	var result *big.Int
+	err := ns.read(ctx, func(n Node) (err error) {
+		result, err = n.DelegationRewards(ctx, validatorSMCAddr, delegatorAddress)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) DelegatorStakedAmount(ctx context.Context, validatorSMCAddress, delegatorAddress string) (*big.Int, error) {
+	var result *big.Int
+	err := ns.read(ctx, func(n Node) (err error) {
+		result, err = n.DelegatorStakedAmount(ctx, validatorSMCAddress, delegatorAddress)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) ValidatorCommission(ctx context.Context, valSmcAddr string) (*Commission, error) {
+	var result *Commission
+	err := ns.read(ctx, func(n Node) (err error) {
+		result, err = n.ValidatorCommission(ctx, valSmcAddr)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) SlashEvents(ctx context.Context, validatorSMCAddress string) ([]*SlashEvents, error) {
+	var result []*SlashEvents
+	err := ns.read(ctx, func(n Node) (err error) {
+		result, err = n.SlashEvents(ctx, validatorSMCAddress)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) Validators(ctx context.Context) ([]*Validator, error) {
+	var result []*Validator
+	err := ns.read(ctx, func(n Node) (err error) {
+		result, err = n.Validators(ctx)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) Validator(ctx context.Context, validatorSMCAddress string) (*Validator, error) {
+	var result *Validator
+	err := ns.read(ctx, func(n Node) (err error) {
+		result, err = n.Validator(ctx, validatorSMCAddress)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) ValidatorSets(ctx context.Context) ([]common.Address, error) {
+	var result []common.Address
+	err := ns.read(ctx, func(n Node) (err error) {
+		result, err = n.ValidatorSets(ctx)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) SMCAddressOfValidator(ctx context.Context, validatorAddress string) (common.Address, error) {
+	var result common.Address
+	err := ns.read(ctx, func(n Node) (err error) {
+		result, err = n.SMCAddressOfValidator(ctx, validatorAddress)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) ValidatorAddressOfSMC(ctx context.Context, validatorSMCAddress string) (common.Address, error) {
+	var result common.Address
+	err := ns.read(ctx, func(n Node) (err error) {
+		result, err = n.ValidatorAddressOfSMC(ctx, validatorSMCAddress)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) UnbondedRecords(ctx context.Context, validatorSMCAddress, delegatorAddress string) (*UnbondedRecord, error) {
+	var result *UnbondedRecord
+	err := ns.read(ctx, func(n Node) (err error) {
+		result, err = n.UnbondedRecords(ctx, validatorSMCAddress, delegatorAddress)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) Delegate(ctx context.Context, opts *bind.TransactOpts, validatorSMCAddress common.Address, amount *big.Int) (*StakingReceipt, error) {
+	var result *StakingReceipt
+	err := ns.trustedCall(ctx, func(n Node) (err error) {
+		result, err = n.Delegate(ctx, opts, validatorSMCAddress, amount)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) Undelegate(ctx context.Context, opts *bind.TransactOpts, validatorSMCAddress common.Address, amount *big.Int) (*StakingReceipt, error) {
+	var result *StakingReceipt
+	err := ns.trustedCall(ctx, func(n Node) (err error) {
+		result, err = n.Undelegate(ctx, opts, validatorSMCAddress, amount)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) Withdraw(ctx context.Context, opts *bind.TransactOpts, validatorSMCAddress common.Address) (*StakingReceipt, error) {
+	var result *StakingReceipt
+	err := ns.trustedCall(ctx, func(n Node) (err error) {
+		result, err = n.Withdraw(ctx, opts, validatorSMCAddress)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) WithdrawReward(ctx context.Context, opts *bind.TransactOpts, validatorSMCAddress common.Address) (*StakingReceipt, error) {
+	var result *StakingReceipt
+	err := ns.trustedCall(ctx, func(n Node) (err error) {
+		result, err = n.WithdrawReward(ctx, opts, validatorSMCAddress)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) WithdrawCommission(ctx context.Context, opts *bind.TransactOpts, validatorSMCAddress common.Address) (*StakingReceipt, error) {
+	var result *StakingReceipt
+	err := ns.trustedCall(ctx, func(n Node) (err error) {
+		result, err = n.WithdrawCommission(ctx, opts, validatorSMCAddress)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) ChainParams(ctx context.Context) (*ChainParams, error) {
+	var result *ChainParams
+	err := ns.read(ctx, func(n Node) (err error) {
+		result, err = n.ChainParams(ctx)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) Proposals(ctx context.Context) ([]*Proposal, error) {
+	var result []*Proposal
+	err := ns.read(ctx, func(n Node) (err error) {
+		result, err = n.Proposals(ctx)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) GovernanceActions(ctx context.Context, fromBlock, toBlock uint64) ([]*GovernanceAction, error) {
+	var result []*GovernanceAction
+	err := ns.read(ctx, func(n Node) (err error) {
+		result, err = n.GovernanceActions(ctx, fromBlock, toBlock)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) StakingYield(ctx context.Context) (*StakingYield, error) {
+	var result *StakingYield
+	err := ns.read(ctx, func(n Node) (err error) {
+		result, err = n.StakingYield(ctx)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) ValidatorAPR(ctx context.Context, validatorSMCAddress common.Address) (*big.Int, error) {
+	var result *big.Int
+	err := ns.read(ctx, func(n Node) (err error) {
+		result, err = n.ValidatorAPR(ctx, validatorSMCAddress)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) ProjectRewards(ctx context.Context, validatorSMCAddress common.Address, amount *big.Int, period time.Duration) (*big.Int, error) {
+	var result *big.Int
+	err := ns.read(ctx, func(n Node) (err error) {
+		result, err = n.ProjectRewards(ctx, validatorSMCAddress, amount, period)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) DelegatorPortfolio(ctx context.Context, delegator common.Address) (*DelegatorPortfolio, error) {
+	var result *DelegatorPortfolio
+	err := ns.read(ctx, func(n Node) (err error) {
+		result, err = n.DelegatorPortfolio(ctx, delegator)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) BlockSignatures(ctx context.Context, height uint64) ([]*BlockSignature, error) {
+	var result []*BlockSignature
+	err := ns.read(ctx, func(n Node) (err error) {
+		result, err = n.BlockSignatures(ctx, height)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) Uptime(ctx context.Context, fromBlock, toBlock uint64) ([]*ValidatorUptime, error) {
+	var result []*ValidatorUptime
+	err := ns.read(ctx, func(n Node) (err error) {
+		result, err = n.Uptime(ctx, fromBlock, toBlock)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) SlashHistory(ctx context.Context, validatorSMCAddress common.Address) ([]*Slash, error) {
+	var result []*Slash
+	err := ns.read(ctx, func(n Node) (err error) {
+		result, err = n.SlashHistory(ctx, validatorSMCAddress)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) CreateValidator(ctx context.Context, opts *bind.TransactOpts, args CreateValidatorArgs) (common.Address, *StakingReceipt, error) {
+	var (
+		address common.Address
+		result  *StakingReceipt
+	)
+	err := ns.trustedCall(ctx, func(n Node) (err error) {
+		address, result, err = n.CreateValidator(ctx, opts, args)
+		return err
+	})
+	return address, result, err
+}
+
+func (ns *nodes) UpdateCommissionRate(ctx context.Context, opts *bind.TransactOpts, validatorSMCAddress common.Address, rate *big.Int) (*StakingReceipt, error) {
+	var result *StakingReceipt
+	err := ns.trustedCall(ctx, func(n Node) (err error) {
+		result, err = n.UpdateCommissionRate(ctx, opts, validatorSMCAddress, rate)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) UpdateName(ctx context.Context, opts *bind.TransactOpts, validatorSMCAddress common.Address, name string) (*StakingReceipt, error) {
+	var result *StakingReceipt
+	err := ns.trustedCall(ctx, func(n Node) (err error) {
+		result, err = n.UpdateName(ctx, opts, validatorSMCAddress, name)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) UpdateSigner(ctx context.Context, opts *bind.TransactOpts, validatorSMCAddress common.Address, signer common.Address) (*StakingReceipt, error) {
+	var result *StakingReceipt
+	err := ns.trustedCall(ctx, func(n Node) (err error) {
+		result, err = n.UpdateSigner(ctx, opts, validatorSMCAddress, signer)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) Start(ctx context.Context, opts *bind.TransactOpts, validatorSMCAddress common.Address) (*StakingReceipt, error) {
+	var result *StakingReceipt
+	err := ns.trustedCall(ctx, func(n Node) (err error) {
+		result, err = n.Start(ctx, opts, validatorSMCAddress)
+		return err
+	})
+	return result, err
+}
+
+func (ns *nodes) Unjail(ctx context.Context, opts *bind.TransactOpts, validatorSMCAddress common.Address) (*StakingReceipt, error) {
+	var result *StakingReceipt
+	err := ns.trustedCall(ctx, func(n Node) (err error) {
+		result, err = n.Unjail(ctx, opts, validatorSMCAddress)
+		return err
+	})
+	return result, err
+}
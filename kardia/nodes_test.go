@@ -0,0 +1,183 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package kardia
+package kardia
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/types"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// mockEndpointAPI counts requests served by one endpoint
+type mockEndpointAPI struct {
+	mu      sync.Mutex
+	head    uint64
+	network string
+	calls   int
+	txs     int
+}
+
+func (api *mockEndpointAPI) BlockNumber() uint64 {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	api.calls++
+	return api.head
+}
+
+func (api *mockEndpointAPI) NodeInfo() *NodeInfo {
+	return &NodeInfo{Network: api.network}
+}
+
+func (api *mockEndpointAPI) Peers() []*PeerInfo {
+	return nil
+}
+
+func (api *mockEndpointAPI) GetBlockByNumber(height uint64) (*Block, error) {
+	return nil, errors.New("block not found")
+}
+
+func (api *mockEndpointAPI) SendRawTransaction(tx string) string {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	api.txs++
+	return common.Hash{}.Hex()
+}
+
+func setupMockEndpoint(t *testing.T, head uint64, trusted bool) (*endpoint, *mockEndpointAPI) {
+	api := &mockEndpointAPI{head: head, network: "kai-mainnet"}
+	n, err := setupMockNodeInstance(map[string]interface{}{"kai": api, "tx": api, "node": api})
+	assert.Nil(t, err)
+	return &endpoint{Node: n, trusted: trusted}, api
+}
+
+func setupMockNodes(trusted, public []*endpoint) *nodes {
+	return &nodes{
+		trusted:         trusted,
+		public:          public,
+		total:           uint64(len(trusted) + len(public)),
+		logger:          zap.NewNop(),
+		failureCooldown: defaultFailureCooldown,
+	}
+}
+
+func TestNodes_RoundRobin(t *testing.T) {
+	trusted, trustedAPI := setupMockEndpoint(t, 10, true)
+	public1, api1 := setupMockEndpoint(t, 10, false)
+	public2, api2 := setupMockEndpoint(t, 10, false)
+	ns := setupMockNodes([]*endpoint{trusted}, []*endpoint{public1, public2})
+
+	for i := 0; i < 4; i++ {
+		_, err := ns.LatestBlockNumber(context.Background())
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, 2, api1.calls)
+	assert.Equal(t, 2, api2.calls)
+	assert.Equal(t, 0, trustedAPI.calls)
+}
+
+func TestNodes_TrustedWrites(t *testing.T) {
+	trusted, trustedAPI := setupMockEndpoint(t, 10, true)
+	public, publicAPI := setupMockEndpoint(t, 10, false)
+	ns := setupMockNodes([]*endpoint{trusted}, []*endpoint{public})
+
+	tx := types.NewTransaction(0, common.HexToAddress("0x01"), big.NewInt(0), 21000, big.NewInt(1), nil)
+	assert.Nil(t, ns.SendRawTransaction(context.Background(), tx))
+	assert.Equal(t, 1, trustedAPI.txs)
+	assert.Equal(t, 0, publicAPI.txs)
+	assert.Equal(t, trusted.Url(), ns.Url())
+}
+
+func TestNodes_Failover(t *testing.T) {
+	public1, _ := setupMockEndpoint(t, 10, false)
+	public2, api2 := setupMockEndpoint(t, 10, false)
+	ns := setupMockNodes(nil, []*endpoint{public1, public2})
+	public1.Node.(*node).client.Close()
+
+	for i := 0; i < 3; i++ {
+		head, err := ns.LatestBlockNumber(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, uint64(10), head)
+	}
+	assert.Equal(t, 3, api2.calls)
+	assert.False(t, public1.available())
+
+	// errors returned by the node itself are not retried
+	_, err := ns.BlockByHeight(context.Background(), 1)
+	assert.NotNil(t, err)
+	assert.True(t, public2.available())
+}
+
+func TestNodes_MaxBlockLag(t *testing.T) {
+	behind, behindAPI := setupMockEndpoint(t, 50, false)
+	synced, _ := setupMockEndpoint(t, 100, false)
+	ns := setupMockNodes(nil, []*endpoint{behind, synced})
+	ns.monitor = NewHealthMonitor(HealthConfig{MaxBlockLag: 5, Logger: zap.NewNop()}, behind.Node, synced.Node)
+	ns.monitor.Check(context.Background())
+
+	for i := 0; i < 4; i++ {
+		head, err := ns.LatestBlockNumber(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, uint64(100), head)
+	}
+	// only the health check reached the lagging node
+	assert.Equal(t, 1, behindAPI.calls)
+}
+
+func TestNodes_SentTxNotResent(t *testing.T) {
+	trusted1, _ := setupMockEndpoint(t, 10, true)
+	trusted2, _ := setupMockEndpoint(t, 10, true)
+	ns := setupMockNodes([]*endpoint{trusted1, trusted2}, nil)
+
+	// the connection drops while waiting for the receipt of a sent transaction
+	attempts := 0
+	err := ns.trustedCall(context.Background(), func(n Node) error {
+		attempts++
+		return &TxSentError{Hash: common.HexToHash("0x01"), Err: errors.New("connection reset by peer")}
+	})
+	var txSent *TxSentError
+	assert.True(t, errors.As(err, &txSent))
+	assert.Equal(t, 1, attempts)
+	assert.True(t, trusted1.available())
+}
+
+func TestNodes_Close(t *testing.T) {
+	trusted, _ := setupMockEndpoint(t, 10, true)
+	public, _ := setupMockEndpoint(t, 10, false)
+	ns := setupMockNodes([]*endpoint{trusted}, []*endpoint{public})
+	ns.monitor = NewHealthMonitor(HealthConfig{Logger: zap.NewNop()}, trusted.Node, public.Node)
+	ns.monitor.Start()
+
+	ns.Close()
+	_, err := ns.LatestBlockNumber(context.Background())
+	assert.NotNil(t, err)
+	select {
+	case <-ns.monitor.quit:
+	default:
+		t.Fatal("health monitor not stopped")
+	}
+	// closing twice is safe
+	ns.Close()
+}
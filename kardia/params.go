@@ -0,0 +1,441 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package kardia
+package kardia
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/kardiachain/go-kardia/lib/common"
+	"go.uber.org/zap"
+)
+
+// IParams reads the parameters of the params contract and its governance
+type IParams interface {
+	// ChainParams reads all the parameters in one batch request, against the block selected by ctx
+	ChainParams(ctx context.Context) (*ChainParams, error)
+	// Proposals returns the governance proposals with their results, against the block selected by ctx.
+	// The results of pending proposals are the votes of the validator set of that block.
+	Proposals(ctx context.Context) ([]*Proposal, error)
+	// GovernanceActions returns the successful addProposal, addVote and confirmProposal transactions
+	// of blocks fromBlock to toBlock. The contract does not keep the changes of a proposal in its state,
+	// so they are only known from its addProposal action.
+	GovernanceActions(ctx context.Context, fromBlock, toBlock uint64) ([]*GovernanceAction, error)
+}
+
+// ParamKey is a parameter of the params contract, in the order of its ParamKey enum
+type ParamKey uint8
+
+const (
+	ParamBaseProposerReward ParamKey = iota
+	ParamBonusProposerReward
+	ParamMaxProposers
+	ParamDowntimeJailDuration
+	ParamSlashFractionDowntime
+	ParamUnbondingTime
+	ParamSlashFractionDoubleSign
+	ParamSignedBlockWindow
+	ParamMinSignedPerWindow
+	ParamMinStake
+	ParamMinValidatorStake
+	ParamMinAmountChangeName
+	ParamMinSelfDelegation
+	ParamInflationRateChange
+	ParamGoalBonded
+	ParamBlocksPerYear
+	ParamInflationMax
+	ParamInflationMin
+	ParamDeposit
+	ParamVotingPeriod
+)
+
+var paramKeyNames = []string{
+	"baseProposerReward", "bonusProposerReward", "maxProposers",
+	"downtimeJailDuration", "slashFractionDowntime", "unbondingTime", "slashFractionDoubleSign", "signedBlockWindow",
+	"minSignedPerWindow", "minStake", "minValidatorStake", "minAmountChangeName", "minSelfDelegation",
+	"inflationRateChange", "goalBonded", "blocksPerYear", "inflationMax", "inflationMin",
+	"deposit", "votingPeriod",
+}
+
+func (k ParamKey) String() string {
+	if int(k) < len(paramKeyNames) {
+		return paramKeyNames[k]
+	}
+	return fmt.Sprintf("ParamKey(%d)", uint8(k))
+}
+
+// getter returns the getter method of the parameter, the governance parameters have none
+func (k ParamKey) getter() string {
+	if k >= ParamDeposit {
+		return ""
+	}
+	name := k.String()
+	return "get" + strings.ToUpper(name[:1]) + name[1:]
+}
+
+// ChainParams are the parameters of the params contract. Rates and fractions are in 1e18 units, 1e18 is 100%.
+type ChainParams struct {
+	// staking
+	BaseProposerReward  *big.Int
+	BonusProposerReward *big.Int
+	MaxProposers        uint64
+
+	// validators
+	DowntimeJailDuration    time.Duration
+	SlashFractionDowntime   *big.Int
+	UnbondingTime           time.Duration
+	SlashFractionDoubleSign *big.Int
+	SignedBlockWindow       uint64
+	MinSignedPerWindow      *big.Int
+	MinStake                *big.Int
+	MinValidatorStake       *big.Int
+	MinAmountChangeName     *big.Int
+	MinSelfDelegation       *big.Int
+
+	// minting
+	InflationRateChange *big.Int
+	GoalBonded          *big.Int
+	BlocksPerYear       uint64
+	InflationMax        *big.Int
+	InflationMin        *big.Int
+
+	// governance
+	ProposalDeposit *big.Int
+	VotingPeriod    time.Duration
+}
+
+// ProposalStatus is the status of a governance proposal
+type ProposalStatus uint8
+
+const (
+	ProposalPending ProposalStatus = iota
+	ProposalPassed
+	ProposalRejected
+)
+
+func (s ProposalStatus) String() string {
+	switch s {
+	case ProposalPending:
+		return "pending"
+	case ProposalPassed:
+		return "passed"
+	case ProposalRejected:
+		return "rejected"
+	}
+	return fmt.Sprintf("ProposalStatus(%d)", uint8(s))
+}
+
+// VoteOption is the vote of a validator signer on a proposal
+type VoteOption uint8
+
+const (
+	VoteAbstain VoteOption = iota
+	VoteYes
+	VoteNo
+)
+
+func (o VoteOption) String() string {
+	switch o {
+	case VoteAbstain:
+		return "abstain"
+	case VoteYes:
+		return "yes"
+	case VoteNo:
+		return "no"
+	}
+	return fmt.Sprintf("VoteOption(%d)", uint8(o))
+}
+
+// Proposal is a governance proposal of the params contract. A proposal passes when more than 2/3 of
+// the voting power votes yes, it can be confirmed once its voting period is over.
+type Proposal struct {
+	ID        uint64
+	Proposer  common.Address
+	StartTime time.Time
+	EndTime   time.Time
+	Deposit   *big.Int
+	Status    ProposalStatus
+
+	// voting powers of the votes
+	Yes     *big.Int
+	No      *big.Int
+	Abstain *big.Int
+}
+
+// ParamChange is a change of parameter proposed to the governance
+type ParamChange struct {
+	Key   ParamKey
+	Value *big.Int
+}
+
+// GovernanceAction is a transaction sent to the governance of the params contract
+type GovernanceAction struct {
+	// Method is addProposal, addVote or confirmProposal
+	Method      string
+	ProposalID  uint64
+	From        common.Address
+	TxHash      common.Hash
+	BlockHeight uint64
+	Time        time.Time
+
+	// Changes and Deposit are set for addProposal
+	Changes []ParamChange
+	Deposit *big.Int
+	// Option is set for addVote
+	Option VoteOption
+}
+
+func (n *node) ChainParams(ctx context.Context) (*ChainParams, error) {
+	keys := make([]ParamKey, len(paramKeyNames))
+	calls := make([]SMCCallArgs, len(keys))
+	for i := range keys {
+		keys[i] = ParamKey(i)
+		var (
+			payload []byte
+			err     error
+		)
+		if getter := keys[i].getter(); getter != "" {
+			payload, err = n.paramsSMC.Abi.Pack(getter)
+		} else {
+			payload, err = n.paramsSMC.Abi.Pack("getParam", uint8(keys[i]))
+		}
+		if err != nil {
+			return nil, err
+		}
+		calls[i] = ConstructCallArgs(n.paramsSMC.ContractAddress.Hex(), payload)
+	}
+	results, err := n.BatchKardiaCall(ctx, calls)
+	if err != nil {
+		n.lgr.Error("ChainParams batch call error: ", zap.Error(err))
+		return nil, err
+	}
+	values := make([]*big.Int, len(keys))
+	for i, result := range results {
+		if result.Err != nil {
+			return nil, result.Err
+		}
+		method := keys[i].getter()
+		if method == "" {
+			method = "getParam"
+		}
+		if err := n.paramsSMC.Abi.UnpackIntoInterface(&values[i], method, result.Data); err != nil {
+			n.lgr.Error("Error unpacking param", zap.Stringer("Key", keys[i]), zap.Error(err))
+			return nil, err
+		}
+	}
+	seconds := func(v *big.Int) time.Duration {
+		return time.Duration(v.Int64()) * time.Second
+	}
+	return &ChainParams{
+		BaseProposerReward:      values[ParamBaseProposerReward],
+		BonusProposerReward:     values[ParamBonusProposerReward],
+		MaxProposers:            values[ParamMaxProposers].Uint64(),
+		DowntimeJailDuration:    seconds(values[ParamDowntimeJailDuration]),
+		SlashFractionDowntime:   values[ParamSlashFractionDowntime],
+		UnbondingTime:           seconds(values[ParamUnbondingTime]),
+		SlashFractionDoubleSign: values[ParamSlashFractionDoubleSign],
+		SignedBlockWindow:       values[ParamSignedBlockWindow].Uint64(),
+		MinSignedPerWindow:      values[ParamMinSignedPerWindow],
+		MinStake:                values[ParamMinStake],
+		MinValidatorStake:       values[ParamMinValidatorStake],
+		MinAmountChangeName:     values[ParamMinAmountChangeName],
+		MinSelfDelegation:       values[ParamMinSelfDelegation],
+		InflationRateChange:     values[ParamInflationRateChange],
+		GoalBonded:              values[ParamGoalBonded],
+		BlocksPerYear:           values[ParamBlocksPerYear].Uint64(),
+		InflationMax:            values[ParamInflationMax],
+		InflationMin:            values[ParamInflationMin],
+		ProposalDeposit:         values[ParamDeposit],
+		VotingPeriod:            seconds(values[ParamVotingPeriod]),
+	}, nil
+}
+
+func (n *node) Proposals(ctx context.Context) ([]*Proposal, error) {
+	count, err := n.proposalCount(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// the state and the results of every proposal are loaded in one batch
+	methods := []string{"proposals", "getProposalResults"}
+	var calls []SMCCallArgs
+	for id := uint64(0); id < count; id++ {
+		for _, method := range methods {
+			payload, err := n.paramsSMC.Abi.Pack(method, new(big.Int).SetUint64(id))
+			if err != nil {
+				return nil, err
+			}
+			calls = append(calls, ConstructCallArgs(n.paramsSMC.ContractAddress.Hex(), payload))
+		}
+	}
+	results, err := n.BatchKardiaCall(ctx, calls)
+	if err != nil {
+		n.lgr.Error("Proposals batch call error: ", zap.Error(err))
+		return nil, err
+	}
+	proposals := make([]*Proposal, count)
+	for id := range proposals {
+		var (
+			state struct {
+				Proposer  common.Address
+				StartTime *big.Int
+				EndTime   *big.Int
+				Deposit   *big.Int
+				Status    uint8
+			}
+			votes struct {
+				Yes     *big.Int
+				No      *big.Int
+				Abstain *big.Int
+			}
+		)
+		for j, out := range []interface{}{&state, &votes} {
+			result := results[id*len(methods)+j]
+			if result.Err != nil {
+				return nil, result.Err
+			}
+			if err := n.paramsSMC.Abi.UnpackIntoInterface(out, methods[j], result.Data); err != nil {
+				n.lgr.Error("Error unpacking proposal", zap.String("method", methods[j]), zap.Error(err))
+				return nil, err
+			}
+		}
+		proposals[id] = &Proposal{
+			ID:        uint64(id),
+			Proposer:  state.Proposer,
+			StartTime: time.Unix(state.StartTime.Int64(), 0),
+			EndTime:   time.Unix(state.EndTime.Int64(), 0),
+			Deposit:   state.Deposit,
+			Status:    ProposalStatus(state.Status),
+			Yes:       votes.Yes,
+			No:        votes.No,
+			Abstain:   votes.Abstain,
+		}
+	}
+	return proposals, nil
+}
+
+func (n *node) GovernanceActions(ctx context.Context, fromBlock, toBlock uint64) ([]*GovernanceAction, error) {
+	if fromBlock > toBlock {
+		return nil, ErrInvalidBlockRange
+	}
+	var actions []*GovernanceAction
+	for height := fromBlock; height <= toBlock; height++ {
+		block, err := n.BlockByHeight(ctx, height)
+		if err != nil {
+			return nil, err
+		}
+		failed := make(map[string]bool)
+		for _, receipt := range block.Receipts {
+			if receipt.Status != ReceiptStatusSuccessful {
+				failed[receipt.TransactionHash] = true
+			}
+		}
+		var (
+			blockActions []*GovernanceAction
+			added        uint64
+		)
+		for _, tx := range block.Txs {
+			if failed[tx.Hash] || common.HexToAddress(tx.To) != n.paramsSMC.ContractAddress {
+				continue
+			}
+			action, err := n.decodeGovernanceTx(tx)
+			if err != nil {
+				return nil, err
+			}
+			if action == nil {
+				continue
+			}
+			if action.Method == "addProposal" {
+				added++
+			}
+			action.Time = block.Time
+			blockActions = append(blockActions, action)
+		}
+		if added > 0 {
+			// the proposals added in the block are the last ones of its state
+			count, err := n.proposalCount(WithBlock(ctx, BlockAtHeight(height)))
+			if err != nil {
+				return nil, err
+			}
+			id := count - added
+			for _, action := range blockActions {
+				if action.Method == "addProposal" {
+					action.ProposalID = id
+					id++
+				}
+			}
+		}
+		actions = append(actions, blockActions...)
+	}
+	return actions, nil
+}
+
+// decodeGovernanceTx decodes a transaction sent to the params contract, it returns nil for the other methods
+func (n *node) decodeGovernanceTx(tx *Transaction) (*GovernanceAction, error) {
+	data := common.FromHex(tx.InputData)
+	if len(data) < 4 {
+		return nil, nil
+	}
+	method, err := n.paramsSMC.Abi.MethodById(data[:4])
+	if err != nil {
+		return nil, nil
+	}
+	switch method.RawName {
+	case "addProposal", "addVote", "confirmProposal":
+	default:
+		return nil, nil
+	}
+	args, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, fmt.Errorf("decode %s input of tx %s: %w", method.RawName, tx.Hash, err)
+	}
+	action := &GovernanceAction{
+		Method:      method.RawName,
+		From:        common.HexToAddress(tx.From),
+		TxHash:      common.HexToHash(tx.Hash),
+		BlockHeight: tx.BlockNumber,
+	}
+	switch method.RawName {
+	case "addProposal":
+		keys, values := args[0].([]uint8), args[1].([]*big.Int)
+		if len(keys) != len(values) {
+			return nil, fmt.Errorf("decode %s input of tx %s: %d keys for %d values", method.RawName, tx.Hash, len(keys), len(values))
+		}
+		for i := range keys {
+			action.Changes = append(action.Changes, ParamChange{Key: ParamKey(keys[i]), Value: values[i]})
+		}
+		action.Deposit, _ = new(big.Int).SetString(tx.Value, 10)
+	case "addVote":
+		action.ProposalID = args[0].(*big.Int).Uint64()
+		action.Option = VoteOption(args[1].(uint8))
+	case "confirmProposal":
+		action.ProposalID = args[0].(*big.Int).Uint64()
+	}
+	return action, nil
+}
+
+func (n *node) proposalCount(ctx context.Context) (uint64, error) {
+	count, err := n.paramsValue(ctx, "allProposal")
+	if err != nil {
+		return 0, err
+	}
+	return count.Uint64(), nil
+}
@@ -0,0 +1,426 @@
+// Code generated by kaibind. DO NOT EDIT.
+
+package kardia
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/kardiachain/go-kardia/lib/abi"
+	"github.com/kardiachain/go-kardia/lib/abi/bind"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/event"
+	"github.com/kardiachain/go-kardia/types"
+
+	"github.com/kardiachain/go-kaiclient/kardia/smc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = abi.JSON
+	_ = bind.NewBoundContract
+	_ = common.Big1
+	_ = event.NewSubscription
+	_ = types.BloomLookup
+)
+
+// ParamsContract is a typed binding of the contract, on top of BoundContract.
+type ParamsContract struct {
+	*BoundContract
+}
+
+// NewParamsContract binds the contract deployed at address.
+func NewParamsContract(node Node, address common.Address) (*ParamsContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(smc.ParamsABI))
+	if err != nil {
+		return nil, err
+	}
+	return &ParamsContract{NewBoundContract(node, &parsed, address)}, nil
+}
+
+// AllProposal calls function allProposal() view returns(uint256).
+func (c *ParamsContract) AllProposal(opts *bind.CallOpts) (*big.Int, error) {
+	var out *big.Int
+	err := c.BoundContract.Call(opts, &out, "allProposal")
+	return out, err
+}
+
+// GetBaseProposerReward calls function getBaseProposerReward() view returns(uint256).
+func (c *ParamsContract) GetBaseProposerReward(opts *bind.CallOpts) (*big.Int, error) {
+	var out *big.Int
+	err := c.BoundContract.Call(opts, &out, "getBaseProposerReward")
+	return out, err
+}
+
+// GetBlocksPerYear calls function getBlocksPerYear() view returns(uint256).
+func (c *ParamsContract) GetBlocksPerYear(opts *bind.CallOpts) (*big.Int, error) {
+	var out *big.Int
+	err := c.BoundContract.Call(opts, &out, "getBlocksPerYear")
+	return out, err
+}
+
+// GetBonusProposerReward calls function getBonusProposerReward() view returns(uint256).
+func (c *ParamsContract) GetBonusProposerReward(opts *bind.CallOpts) (*big.Int, error) {
+	var out *big.Int
+	err := c.BoundContract.Call(opts, &out, "getBonusProposerReward")
+	return out, err
+}
+
+// GetDowntimeJailDuration calls function getDowntimeJailDuration() view returns(uint256).
+func (c *ParamsContract) GetDowntimeJailDuration(opts *bind.CallOpts) (*big.Int, error) {
+	var out *big.Int
+	err := c.BoundContract.Call(opts, &out, "getDowntimeJailDuration")
+	return out, err
+}
+
+// GetGoalBonded calls function getGoalBonded() view returns(uint256).
+func (c *ParamsContract) GetGoalBonded(opts *bind.CallOpts) (*big.Int, error) {
+	var out *big.Int
+	err := c.BoundContract.Call(opts, &out, "getGoalBonded")
+	return out, err
+}
+
+// GetInflationMax calls function getInflationMax() view returns(uint256).
+func (c *ParamsContract) GetInflationMax(opts *bind.CallOpts) (*big.Int, error) {
+	var out *big.Int
+	err := c.BoundContract.Call(opts, &out, "getInflationMax")
+	return out, err
+}
+
+// GetInflationMin calls function getInflationMin() view returns(uint256).
+func (c *ParamsContract) GetInflationMin(opts *bind.CallOpts) (*big.Int, error) {
+	var out *big.Int
+	err := c.BoundContract.Call(opts, &out, "getInflationMin")
+	return out, err
+}
+
+// GetInflationRateChange calls function getInflationRateChange() view returns(uint256).
+func (c *ParamsContract) GetInflationRateChange(opts *bind.CallOpts) (*big.Int, error) {
+	var out *big.Int
+	err := c.BoundContract.Call(opts, &out, "getInflationRateChange")
+	return out, err
+}
+
+// GetMaxProposers calls function getMaxProposers() view returns(uint256 maxProposers).
+func (c *ParamsContract) GetMaxProposers(opts *bind.CallOpts) (*big.Int, error) {
+	var out *big.Int
+	err := c.BoundContract.Call(opts, &out, "getMaxProposers")
+	return out, err
+}
+
+// GetMinAmountChangeName calls function getMinAmountChangeName() view returns(uint256).
+func (c *ParamsContract) GetMinAmountChangeName(opts *bind.CallOpts) (*big.Int, error) {
+	var out *big.Int
+	err := c.BoundContract.Call(opts, &out, "getMinAmountChangeName")
+	return out, err
+}
+
+// GetMinSelfDelegation calls function getMinSelfDelegation() view returns(uint256).
+func (c *ParamsContract) GetMinSelfDelegation(opts *bind.CallOpts) (*big.Int, error) {
+	var out *big.Int
+	err := c.BoundContract.Call(opts, &out, "getMinSelfDelegation")
+	return out, err
+}
+
+// GetMinSignedPerWindow calls function getMinSignedPerWindow() view returns(uint256).
+func (c *ParamsContract) GetMinSignedPerWindow(opts *bind.CallOpts) (*big.Int, error) {
+	var out *big.Int
+	err := c.BoundContract.Call(opts, &out, "getMinSignedPerWindow")
+	return out, err
+}
+
+// GetMinStake calls function getMinStake() view returns(uint256).
+func (c *ParamsContract) GetMinStake(opts *bind.CallOpts) (*big.Int, error) {
+	var out *big.Int
+	err := c.BoundContract.Call(opts, &out, "getMinStake")
+	return out, err
+}
+
+// GetMinValidatorStake calls function getMinValidatorStake() view returns(uint256).
+func (c *ParamsContract) GetMinValidatorStake(opts *bind.CallOpts) (*big.Int, error) {
+	var out *big.Int
+	err := c.BoundContract.Call(opts, &out, "getMinValidatorStake")
+	return out, err
+}
+
+// GetParam calls function getParam(uint8 key) view returns(uint256).
+func (c *ParamsContract) GetParam(opts *bind.CallOpts, key uint8) (*big.Int, error) {
+	var out *big.Int
+	err := c.BoundContract.Call(opts, &out, "getParam", key)
+	return out, err
+}
+
+// ParamsGetProposalResultsOutput is the output of ParamsContract.GetProposalResults.
+type ParamsGetProposalResultsOutput struct {
+	Yes     *big.Int
+	No      *big.Int
+	Abstain *big.Int
+}
+
+// GetProposalResults calls function getProposalResults(uint256 proposalId) view returns(uint256 yes, uint256 no, uint256 abstain).
+func (c *ParamsContract) GetProposalResults(opts *bind.CallOpts, proposalId *big.Int) (*ParamsGetProposalResultsOutput, error) {
+	out := make([]interface{}, 3)
+	if err := c.BoundContract.Call(opts, &out, "getProposalResults", proposalId); err != nil {
+		return nil, err
+	}
+	return &ParamsGetProposalResultsOutput{
+		Yes:     *abi.ConvertType(out[0], new(*big.Int)).(**big.Int),
+		No:      *abi.ConvertType(out[1], new(*big.Int)).(**big.Int),
+		Abstain: *abi.ConvertType(out[2], new(*big.Int)).(**big.Int),
+	}, nil
+}
+
+// GetSignedBlockWindow calls function getSignedBlockWindow() view returns(uint256).
+func (c *ParamsContract) GetSignedBlockWindow(opts *bind.CallOpts) (*big.Int, error) {
+	var out *big.Int
+	err := c.BoundContract.Call(opts, &out, "getSignedBlockWindow")
+	return out, err
+}
+
+// GetSlashFractionDoubleSign calls function getSlashFractionDoubleSign() view returns(uint256).
+func (c *ParamsContract) GetSlashFractionDoubleSign(opts *bind.CallOpts) (*big.Int, error) {
+	var out *big.Int
+	err := c.BoundContract.Call(opts, &out, "getSlashFractionDoubleSign")
+	return out, err
+}
+
+// GetSlashFractionDowntime calls function getSlashFractionDowntime() view returns(uint256).
+func (c *ParamsContract) GetSlashFractionDowntime(opts *bind.CallOpts) (*big.Int, error) {
+	var out *big.Int
+	err := c.BoundContract.Call(opts, &out, "getSlashFractionDowntime")
+	return out, err
+}
+
+// GetUnbondingTime calls function getUnbondingTime() view returns(uint256).
+func (c *ParamsContract) GetUnbondingTime(opts *bind.CallOpts) (*big.Int, error) {
+	var out *big.Int
+	err := c.BoundContract.Call(opts, &out, "getUnbondingTime")
+	return out, err
+}
+
+// ParamsMintParamsOutput is the output of ParamsContract.MintParams.
+type ParamsMintParamsOutput struct {
+	InflationRateChange *big.Int
+	GoalBonded          *big.Int
+	BlocksPerYear       *big.Int
+	InflationMax        *big.Int
+	InflationMin        *big.Int
+}
+
+// MintParams calls function mintParams() view returns(uint256 inflationRateChange, uint256 goalBonded, uint256 blocksPerYear, uint256 inflationMax, uint256 inflationMin).
+func (c *ParamsContract) MintParams(opts *bind.CallOpts) (*ParamsMintParamsOutput, error) {
+	out := make([]interface{}, 5)
+	if err := c.BoundContract.Call(opts, &out, "mintParams"); err != nil {
+		return nil, err
+	}
+	return &ParamsMintParamsOutput{
+		InflationRateChange: *abi.ConvertType(out[0], new(*big.Int)).(**big.Int),
+		GoalBonded:          *abi.ConvertType(out[1], new(*big.Int)).(**big.Int),
+		BlocksPerYear:       *abi.ConvertType(out[2], new(*big.Int)).(**big.Int),
+		InflationMax:        *abi.ConvertType(out[3], new(*big.Int)).(**big.Int),
+		InflationMin:        *abi.ConvertType(out[4], new(*big.Int)).(**big.Int),
+	}, nil
+}
+
+// Owner calls function owner() view returns(address).
+func (c *ParamsContract) Owner(opts *bind.CallOpts) (common.Address, error) {
+	var out common.Address
+	err := c.BoundContract.Call(opts, &out, "owner")
+	return out, err
+}
+
+// ParamsProposalsOutput is the output of ParamsContract.Proposals.
+type ParamsProposalsOutput struct {
+	Proposer  common.Address
+	StartTime *big.Int
+	EndTime   *big.Int
+	Deposit   *big.Int
+	Status    uint8
+}
+
+// Proposals calls function proposals(uint256 ) view returns(address proposer, uint256 startTime, uint256 endTime, uint256 deposit, uint8 status).
+func (c *ParamsContract) Proposals(opts *bind.CallOpts, arg0 *big.Int) (*ParamsProposalsOutput, error) {
+	out := make([]interface{}, 5)
+	if err := c.BoundContract.Call(opts, &out, "proposals", arg0); err != nil {
+		return nil, err
+	}
+	return &ParamsProposalsOutput{
+		Proposer:  *abi.ConvertType(out[0], new(common.Address)).(*common.Address),
+		StartTime: *abi.ConvertType(out[1], new(*big.Int)).(**big.Int),
+		EndTime:   *abi.ConvertType(out[2], new(*big.Int)).(**big.Int),
+		Deposit:   *abi.ConvertType(out[3], new(*big.Int)).(**big.Int),
+		Status:    *abi.ConvertType(out[4], new(uint8)).(*uint8),
+	}, nil
+}
+
+// ParamsStakingParamsOutput is the output of ParamsContract.StakingParams.
+type ParamsStakingParamsOutput struct {
+	BaseProposerReward  *big.Int
+	BonusProposerReward *big.Int
+	MaxProposers        *big.Int
+}
+
+// StakingParams calls function stakingParams() view returns(uint256 baseProposerReward, uint256 bonusProposerReward, uint256 maxProposers).
+func (c *ParamsContract) StakingParams(opts *bind.CallOpts) (*ParamsStakingParamsOutput, error) {
+	out := make([]interface{}, 3)
+	if err := c.BoundContract.Call(opts, &out, "stakingParams"); err != nil {
+		return nil, err
+	}
+	return &ParamsStakingParamsOutput{
+		BaseProposerReward:  *abi.ConvertType(out[0], new(*big.Int)).(**big.Int),
+		BonusProposerReward: *abi.ConvertType(out[1], new(*big.Int)).(**big.Int),
+		MaxProposers:        *abi.ConvertType(out[2], new(*big.Int)).(**big.Int),
+	}, nil
+}
+
+// ParamsValidatorParamsOutput is the output of ParamsContract.ValidatorParams.
+type ParamsValidatorParamsOutput struct {
+	DowntimeJailDuration    *big.Int
+	SlashFractionDowntime   *big.Int
+	UnbondingTime           *big.Int
+	SlashFractionDoubleSign *big.Int
+	SignedBlockWindow       *big.Int
+	MinSignedPerWindow      *big.Int
+	MinStake                *big.Int
+	MinValidatorStake       *big.Int
+	MinAmountChangeName     *big.Int
+	MinSelfDelegation       *big.Int
+}
+
+// ValidatorParams calls function validatorParams() view returns(uint256 downtimeJailDuration, uint256 slashFractionDowntime, uint256 unbondingTime, uint256 slashFractionDoubleSign, uint256 signedBlockWindow, uint256 minSignedPerWindow, uint256 minStake, uint256 minValidatorStake, uint256 minAmountChangeName, uint256 minSelfDelegation).
+func (c *ParamsContract) ValidatorParams(opts *bind.CallOpts) (*ParamsValidatorParamsOutput, error) {
+	out := make([]interface{}, 10)
+	if err := c.BoundContract.Call(opts, &out, "validatorParams"); err != nil {
+		return nil, err
+	}
+	return &ParamsValidatorParamsOutput{
+		DowntimeJailDuration:    *abi.ConvertType(out[0], new(*big.Int)).(**big.Int),
+		SlashFractionDowntime:   *abi.ConvertType(out[1], new(*big.Int)).(**big.Int),
+		UnbondingTime:           *abi.ConvertType(out[2], new(*big.Int)).(**big.Int),
+		SlashFractionDoubleSign: *abi.ConvertType(out[3], new(*big.Int)).(**big.Int),
+		SignedBlockWindow:       *abi.ConvertType(out[4], new(*big.Int)).(**big.Int),
+		MinSignedPerWindow:      *abi.ConvertType(out[5], new(*big.Int)).(**big.Int),
+		MinStake:                *abi.ConvertType(out[6], new(*big.Int)).(**big.Int),
+		MinValidatorStake:       *abi.ConvertType(out[7], new(*big.Int)).(**big.Int),
+		MinAmountChangeName:     *abi.ConvertType(out[8], new(*big.Int)).(**big.Int),
+		MinSelfDelegation:       *abi.ConvertType(out[9], new(*big.Int)).(**big.Int),
+	}, nil
+}
+
+// AddProposal sends a transaction calling function addProposal(uint8[] keys, uint256[] values) payable returns(uint256).
+func (c *ParamsContract) AddProposal(opts *bind.TransactOpts, keys []uint8, values []*big.Int) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "addProposal", keys, values)
+}
+
+// AddVote sends a transaction calling function addVote(uint256 proposalId, uint8 option) returns().
+func (c *ParamsContract) AddVote(opts *bind.TransactOpts, proposalId *big.Int, option uint8) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "addVote", proposalId, option)
+}
+
+// ConfirmProposal sends a transaction calling function confirmProposal(uint256 proposalId) returns().
+func (c *ParamsContract) ConfirmProposal(opts *bind.TransactOpts, proposalId *big.Int) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "confirmProposal", proposalId)
+}
+
+// RenounceOwnership sends a transaction calling function renounceOwnership() returns().
+func (c *ParamsContract) RenounceOwnership(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "renounceOwnership")
+}
+
+// TransferOwnership sends a transaction calling function transferOwnership(address newOwner) returns().
+func (c *ParamsContract) TransferOwnership(opts *bind.TransactOpts, newOwner common.Address) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "transferOwnership", newOwner)
+}
+
+// UpdateBaseReward sends a transaction calling function updateBaseReward(uint256 _baseProposerReward, uint256 _bonusProposerReward) returns().
+func (c *ParamsContract) UpdateBaseReward(opts *bind.TransactOpts, baseProposerReward *big.Int, bonusProposerReward *big.Int) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "updateBaseReward", baseProposerReward, bonusProposerReward)
+}
+
+// UpdateMaxValidator sends a transaction calling function updateMaxValidator(uint256 _maxProposers) returns().
+func (c *ParamsContract) UpdateMaxValidator(opts *bind.TransactOpts, maxProposers *big.Int) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "updateMaxValidator", maxProposers)
+}
+
+// UpdateMintParams sends a transaction calling function updateMintParams(uint256 _inflationRateChange, uint256 _goalBonded, uint256 _blocksPerYear, uint256 _inflationMax, uint256 _inflationMin) returns().
+func (c *ParamsContract) UpdateMintParams(opts *bind.TransactOpts, inflationRateChange *big.Int, goalBonded *big.Int, blocksPerYear *big.Int, inflationMax *big.Int, inflationMin *big.Int) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "updateMintParams", inflationRateChange, goalBonded, blocksPerYear, inflationMax, inflationMin)
+}
+
+// UpdateValidatorParams sends a transaction calling function updateValidatorParams(uint256 _downtimeJailDuration, uint256 _slashFractionDowntime, uint256 _unbondingTime, uint256 _slashFractionDoubleSign, uint256 _signedBlockWindow, uint256 _minSignedPerWindow, uint256 _minStake, uint256 _minValidatorStake, uint256 _minAmountChangeName, uint256 _minSelfDelegation) returns().
+func (c *ParamsContract) UpdateValidatorParams(opts *bind.TransactOpts, downtimeJailDuration *big.Int, slashFractionDowntime *big.Int, unbondingTime *big.Int, slashFractionDoubleSign *big.Int, signedBlockWindow *big.Int, minSignedPerWindow *big.Int, minStake *big.Int, minValidatorStake *big.Int, minAmountChangeName *big.Int, minSelfDelegation *big.Int) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "updateValidatorParams", downtimeJailDuration, slashFractionDowntime, unbondingTime, slashFractionDoubleSign, signedBlockWindow, minSignedPerWindow, minStake, minValidatorStake, minAmountChangeName, minSelfDelegation)
+}
+
+// ParamsOwnershipTransferred is the OwnershipTransferred event of ParamsContract.
+type ParamsOwnershipTransferred struct {
+	PreviousOwner common.Address
+	NewOwner      common.Address
+	Raw           types.Log
+}
+
+// ParamsOwnershipTransferredIterator iterates over the OwnershipTransferred events returned by ParamsContract.FilterOwnershipTransferred.
+type ParamsOwnershipTransferredIterator struct {
+	// Event is the event the iterator is at
+	Event *ParamsOwnershipTransferred
+
+	*EventIterator
+}
+
+// FilterOwnershipTransferred iterates over past logs of event OwnershipTransferred(address indexed previousOwner, address indexed newOwner).
+func (c *ParamsContract) FilterOwnershipTransferred(opts *bind.FilterOpts, previousOwner []common.Address, newOwner []common.Address) (*ParamsOwnershipTransferredIterator, error) {
+	var previousOwnerRule []interface{}
+	for _, item := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, item)
+	}
+	var newOwnerRule []interface{}
+	for _, item := range newOwner {
+		newOwnerRule = append(newOwnerRule, item)
+	}
+	it := new(ParamsOwnershipTransferredIterator)
+	var err error
+	it.EventIterator, err = c.BoundContract.FilterEvent(opts, "OwnershipTransferred", func(log types.Log) error {
+		ev, err := c.ParseOwnershipTransferred(log)
+		it.Event = ev
+		return err
+	}, previousOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return it, nil
+}
+
+// WatchOwnershipTransferred sends new logs of event OwnershipTransferred(address indexed previousOwner, address indexed newOwner) to sink.
+func (c *ParamsContract) WatchOwnershipTransferred(opts *bind.WatchOpts, sink chan<- *ParamsOwnershipTransferred, previousOwner []common.Address, newOwner []common.Address) (event.Subscription, error) {
+	var previousOwnerRule []interface{}
+	for _, item := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, item)
+	}
+	var newOwnerRule []interface{}
+	for _, item := range newOwner {
+		newOwnerRule = append(newOwnerRule, item)
+	}
+	return c.BoundContract.WatchEvent(opts, "OwnershipTransferred", func(log types.Log, quit <-chan struct{}) error {
+		ev, err := c.ParseOwnershipTransferred(log)
+		if err != nil {
+			return err
+		}
+		select {
+		case sink <- ev:
+		case <-quit:
+		}
+		return nil
+	}, previousOwnerRule, newOwnerRule)
+}
+
+// ParseOwnershipTransferred unpacks a log of event OwnershipTransferred(address indexed previousOwner, address indexed newOwner).
+func (c *ParamsContract) ParseOwnershipTransferred(log types.Log) (*ParamsOwnershipTransferred, error) {
+	ev := new(ParamsOwnershipTransferred)
+	if err := c.BoundContract.UnpackLog(ev, "OwnershipTransferred", log); err != nil {
+		return nil, err
+	}
+	ev.Raw = log
+	return ev, nil
+}
@@ -0,0 +1,133 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package kardia
+package kardia
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/kardiachain/go-kardia/lib/abi/bind"
+	"github.com/kardiachain/go-kardia/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimulated_ChainParams(t *testing.T) {
+	b, node, auth := setupSimulatedNode(t)
+	defer b.Close()
+	ctx := context.Background()
+
+	params, err := node.ChainParams(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(20), params.MaxProposers)
+	assert.Equal(t, 7*24*time.Hour, params.UnbondingTime)
+	assert.Equal(t, time.Hour, params.DowntimeJailDuration)
+	assert.Equal(t, uint64(10000), params.SignedBlockWindow)
+	assert.Equal(t, FloatToBigInt(25000, 18), params.MinStake)
+	assert.Equal(t, FloatToBigInt(12500000, 18), params.MinValidatorStake)
+	assert.Equal(t, FloatToBigInt(0.25, 18), params.SlashFractionDoubleSign)
+	assert.Equal(t, uint64(6220800), params.BlocksPerYear)
+	assert.Equal(t, FloatToBigInt(500000, 18), params.ProposalDeposit)
+	assert.Equal(t, 30*24*time.Hour, params.VotingPeriod)
+	proposals, err := node.Proposals(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(proposals))
+	// BlockAtHeight(0) selects the latest block, the historical reads need a block after genesis
+	_, err = b.Commit()
+	assert.Nil(t, err)
+	before, err := node.LatestBlockNumber(ctx)
+	assert.Nil(t, err)
+
+	staking, err := NewStakingContract(node, node.StakingContact(ctx).ContractAddress)
+	assert.Nil(t, err)
+	paramsAddress, err := staking.Params(&bind.CallOpts{Context: ctx})
+	assert.Nil(t, err)
+	contract, err := NewParamsContract(node, paramsAddress)
+	assert.Nil(t, err)
+	send := func(value *big.Int, transact func(opts *bind.TransactOpts) (*types.Transaction, error)) {
+		opts := *auth
+		opts.Value = value
+		tx, err := transact(&opts)
+		assert.Nil(t, err)
+		receipt, err := node.WaitMined(ctx, tx.Hash().Hex())
+		assert.Nil(t, err)
+		assert.Equal(t, ReceiptStatusSuccessful, receipt.Status)
+	}
+	send(params.ProposalDeposit, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return contract.AddProposal(opts, []uint8{uint8(ParamMinStake)}, []*big.Int{FloatToBigInt(30000, 18)})
+	})
+	send(nil, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return contract.AddVote(opts, big.NewInt(0), uint8(VoteYes))
+	})
+	proposals, err = node.Proposals(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(proposals))
+	assert.Equal(t, ProposalPending, proposals[0].Status)
+	assert.Equal(t, auth.From, proposals[0].Proposer)
+	assert.Equal(t, params.VotingPeriod, proposals[0].EndTime.Sub(proposals[0].StartTime))
+	assert.Equal(t, 1, proposals[0].Yes.Sign())
+	assert.Equal(t, 0, proposals[0].No.Sign())
+
+	// the proposal is confirmed once its voting period is over
+	assert.Nil(t, b.AdjustTime(31*24*time.Hour))
+	_, err = b.Commit()
+	assert.Nil(t, err)
+	send(nil, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return contract.ConfirmProposal(opts, big.NewInt(0))
+	})
+	proposals, err = node.Proposals(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, ProposalPassed, proposals[0].Status)
+	params, err = node.ChainParams(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, FloatToBigInt(30000, 18), params.MinStake)
+	params, err = node.ChainParams(WithBlock(ctx, BlockAtHeight(before)))
+	assert.Nil(t, err)
+	assert.Equal(t, FloatToBigInt(25000, 18), params.MinStake)
+	proposals, err = node.Proposals(WithBlock(ctx, BlockAtHeight(before)))
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(proposals))
+
+	latest, err := node.LatestBlockNumber(ctx)
+	assert.Nil(t, err)
+	actions, err := node.GovernanceActions(ctx, before+1, latest)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(actions))
+	for i, method := range []string{"addProposal", "addVote", "confirmProposal"} {
+		assert.Equal(t, method, actions[i].Method)
+		assert.Equal(t, uint64(0), actions[i].ProposalID)
+		assert.Equal(t, auth.From, actions[i].From)
+	}
+	assert.Equal(t, []ParamChange{{Key: ParamMinStake, Value: FloatToBigInt(30000, 18)}}, actions[0].Changes)
+	assert.Equal(t, FloatToBigInt(500000, 18), actions[0].Deposit)
+	assert.Equal(t, VoteYes, actions[1].Option)
+	_, err = node.GovernanceActions(ctx, latest, before)
+	assert.Equal(t, ErrInvalidBlockRange, err)
+}
+
+func TestParamKey_String(t *testing.T) {
+	assert.Equal(t, "minStake", ParamMinStake.String())
+	assert.Equal(t, "getMinStake", ParamMinStake.getter())
+	assert.Equal(t, "getInflationMin", ParamInflationMin.getter())
+	assert.Equal(t, "", ParamVotingPeriod.getter())
+	assert.Equal(t, "ParamKey(20)", ParamKey(20).String())
+	assert.Equal(t, "passed", ProposalPassed.String())
+	assert.Equal(t, "no", VoteNo.String())
+}
@@ -26,6 +26,7 @@ import (
 
 	"github.com/kardiachain/go-kardia/lib/abi"
 	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/types"
 )
 
 type IReceipt interface {
@@ -124,6 +125,25 @@ func UnpackLog(log *Log, smcABI *abi.ABI) (*Log, error) {
 	return log, nil
 }
 
+// toTypesLog converts a log of a receipt into the log unpacked by contract bindings
+func toTypesLog(log *Log) types.Log {
+	topics := make([]common.Hash, len(log.Topics))
+	for i, topic := range log.Topics {
+		topics[i] = common.HexToHash(topic)
+	}
+	return types.Log{
+		Address:     common.HexToAddress(log.Address),
+		Topics:      topics,
+		Data:        common.FromHex(log.Data),
+		BlockHeight: log.BlockHeight,
+		TxHash:      common.HexToHash(log.TxHash),
+		TxIndex:     log.TxIndex,
+		BlockHash:   common.HexToHash(log.BlockHash),
+		Index:       log.Index,
+		Removed:     log.Removed,
+	}
+}
+
 // UnpackLogIntoMap unpacks a retrieved log into the provided map.
 func unpackLogIntoMap(a *abi.ABI, out map[string]interface{}, eventName string, log *Log) error {
 	lgr, _ := zap.NewDevelopment()
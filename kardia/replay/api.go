@@ -0,0 +1,141 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package replay
+package replay
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/kardiachain/go-kardia/rpc"
+)
+
+// The services below declare the methods called by the kardia client, params are kept
+// raw so that they are replayed or forwarded exactly as sent.
+
+type kaiAPI struct {
+	s *Server
+}
+
+func (api *kaiAPI) BlockNumber(ctx context.Context) (json.RawMessage, error) {
+	return api.s.call(ctx, "kai_blockNumber")
+}
+
+func (api *kaiAPI) GetBlockByNumber(ctx context.Context, height json.RawMessage) (json.RawMessage, error) {
+	return api.s.call(ctx, "kai_getBlockByNumber", height)
+}
+
+func (api *kaiAPI) GetBlockByHash(ctx context.Context, hash json.RawMessage) (json.RawMessage, error) {
+	return api.s.call(ctx, "kai_getBlockByHash", hash)
+}
+
+func (api *kaiAPI) GetBlockHeaderByNumber(ctx context.Context, height json.RawMessage) (json.RawMessage, error) {
+	return api.s.call(ctx, "kai_getBlockHeaderByNumber", height)
+}
+
+func (api *kaiAPI) GetBlockHeaderByHash(ctx context.Context, hash json.RawMessage) (json.RawMessage, error) {
+	return api.s.call(ctx, "kai_getBlockHeaderByHash", hash)
+}
+
+func (api *kaiAPI) GetValidatorSet(ctx context.Context, height json.RawMessage) (json.RawMessage, error) {
+	return api.s.call(ctx, "kai_getValidatorSet", height)
+}
+
+func (api *kaiAPI) GetCommit(ctx context.Context, height json.RawMessage) (json.RawMessage, error) {
+	return api.s.call(ctx, "kai_getCommit", height)
+}
+
+func (api *kaiAPI) GetProof(ctx context.Context, address, keys, block json.RawMessage) (json.RawMessage, error) {
+	return api.s.call(ctx, "kai_getProof", address, keys, block)
+}
+
+func (api *kaiAPI) GasPrice(ctx context.Context) (json.RawMessage, error) {
+	return api.s.call(ctx, "kai_gasPrice")
+}
+
+func (api *kaiAPI) EstimateGas(ctx context.Context, args, block json.RawMessage) (json.RawMessage, error) {
+	return api.s.call(ctx, "kai_estimateGas", args, block)
+}
+
+func (api *kaiAPI) KardiaCall(ctx context.Context, args, block json.RawMessage) (json.RawMessage, error) {
+	return api.s.call(ctx, "kai_kardiaCall", args, block)
+}
+
+func (api *kaiAPI) GetLogs(ctx context.Context, criteria json.RawMessage) (json.RawMessage, error) {
+	return api.s.call(ctx, "kai_getLogs", criteria)
+}
+
+func (api *kaiAPI) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
+	return api.s.subscribe(ctx, json.RawMessage(`"newHeads"`))
+}
+
+func (api *kaiAPI) Logs(ctx context.Context, criteria json.RawMessage) (*rpc.Subscription, error) {
+	return api.s.subscribe(ctx, json.RawMessage(`"logs"`), criteria)
+}
+
+type accountAPI struct {
+	s *Server
+}
+
+func (api *accountAPI) Balance(ctx context.Context, address, block json.RawMessage) (json.RawMessage, error) {
+	return api.s.call(ctx, "account_balance", address, block)
+}
+
+func (api *accountAPI) Nonce(ctx context.Context, address json.RawMessage) (json.RawMessage, error) {
+	return api.s.call(ctx, "account_nonce", address)
+}
+
+func (api *accountAPI) NonceAtHeight(ctx context.Context, address, block json.RawMessage) (json.RawMessage, error) {
+	return api.s.call(ctx, "account_nonceAtHeight", address, block)
+}
+
+func (api *accountAPI) GetCode(ctx context.Context, address, block json.RawMessage) (json.RawMessage, error) {
+	return api.s.call(ctx, "account_getCode", address, block)
+}
+
+func (api *accountAPI) GetStorageAt(ctx context.Context, address, key, block json.RawMessage) (json.RawMessage, error) {
+	return api.s.call(ctx, "account_getStorageAt", address, key, block)
+}
+
+type txAPI struct {
+	s *Server
+}
+
+func (api *txAPI) GetTransaction(ctx context.Context, hash json.RawMessage) (json.RawMessage, error) {
+	return api.s.call(ctx, "tx_getTransaction", hash)
+}
+
+func (api *txAPI) GetTransactionReceipt(ctx context.Context, hash json.RawMessage) (json.RawMessage, error) {
+	return api.s.call(ctx, "tx_getTransactionReceipt", hash)
+}
+
+func (api *txAPI) SendRawTransaction(ctx context.Context, tx json.RawMessage) (json.RawMessage, error) {
+	return api.s.call(ctx, "tx_sendRawTransaction", tx)
+}
+
+type nodeAPI struct {
+	s *Server
+}
+
+func (api *nodeAPI) NodeInfo(ctx context.Context) (json.RawMessage, error) {
+	return api.s.call(ctx, "node_nodeInfo")
+}
+
+func (api *nodeAPI) Peers(ctx context.Context) (json.RawMessage, error) {
+	return api.s.call(ctx, "node_peers")
+}
@@ -0,0 +1,167 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package replay provides a local JSON-RPC server which replays responses recorded from
+// a KardiaChain node, over HTTP and websocket, and a recorder capturing them.
+package replay
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"sync"
+)
+
+// Fixture is a recorded request and its response
+type Fixture struct {
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params,omitempty"`
+	Result json.RawMessage   `json:"result,omitempty"`
+	Error  *FixtureError     `json:"error,omitempty"`
+	// Notifications are sent in order to kai_subscribe subscriptions
+	Notifications []json.RawMessage `json:"notifications,omitempty"`
+}
+
+// FixtureError is a recorded JSON-RPC error, it is returned as is on replay
+type FixtureError struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+func (e *FixtureError) Error() string {
+	return e.Message
+}
+
+func (e *FixtureError) ErrorCode() int {
+	return e.Code
+}
+
+func (e *FixtureError) ErrorData() interface{} {
+	return e.Data
+}
+
+// Fixtures is a set of recorded requests. A request recorded several times is replayed
+// in the recorded order, the last response is repeated afterwards.
+type Fixtures struct {
+	mu      sync.Mutex
+	entries []*Fixture
+	// served counts responses replayed per request
+	served map[string]int
+}
+
+func NewFixtures(entries ...*Fixture) *Fixtures {
+	return &Fixtures{entries: entries, served: make(map[string]int)}
+}
+
+// LoadFixtures reads fixtures saved by Save
+func LoadFixtures(path string) (*Fixtures, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var entries []*Fixture
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("invalid fixtures %s: %w", path, err)
+	}
+	return NewFixtures(entries...), nil
+}
+
+// Save writes fixtures as an indented JSON array
+func (f *Fixtures) Save(path string) error {
+	f.mu.Lock()
+	data, err := json.MarshalIndent(f.entries, "", "  ")
+	f.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// Entries returns a copy of the fixtures in recorded order
+func (f *Fixtures) Entries() []*Fixture {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*Fixture{}, f.entries...)
+}
+
+// Add appends a fixture
+func (f *Fixtures) Add(fixture *Fixture) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.entries = append(f.entries, fixture)
+}
+
+// Find returns the next fixture recorded for method and params, nil if none was recorded
+func (f *Fixtures) Find(method string, params []json.RawMessage) *Fixture {
+	key := requestKey(method, params)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var matches []*Fixture
+	for _, fixture := range f.entries {
+		if requestKey(fixture.Method, fixture.Params) == key {
+			matches = append(matches, fixture)
+		}
+	}
+	if len(matches) == 0 {
+		return nil
+	}
+	served := f.served[key]
+	f.served[key]++
+	if served >= len(matches) {
+		served = len(matches) - 1
+	}
+	return matches[served]
+}
+
+// requestKey identifies a request by its method and canonical params,
+// object keys are sorted and trailing null params dropped
+func requestKey(method string, params []json.RawMessage) string {
+	params = trimParams(params)
+	canonical := make([]string, len(params))
+	for i, param := range params {
+		var v interface{}
+		if err := json.Unmarshal(param, &v); err != nil {
+			canonical[i] = string(bytes.TrimSpace(param))
+			continue
+		}
+		data, _ := json.Marshal(v)
+		canonical[i] = string(data)
+	}
+	return method + "(" + strings.Join(canonical, ",") + ")"
+}
+
+// trimParams drops trailing params which were not sent
+func trimParams(params []json.RawMessage) []json.RawMessage {
+	for len(params) > 0 {
+		last := bytes.TrimSpace(params[len(params)-1])
+		if len(last) != 0 && !bytes.Equal(last, []byte("null")) {
+			break
+		}
+		params = params[:len(params)-1]
+	}
+	return params
+}
+
+// addNotification appends a notification recorded for the subscription fixture
+func (f *Fixtures) addNotification(fixture *Fixture, notification json.RawMessage) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	fixture.Notifications = append(fixture.Notifications, notification)
+}
@@ -0,0 +1,221 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package replay
+package replay
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/kardiachain/go-kardia/rpc"
+)
+
+// ErrCodeNoFixture is the JSON-RPC error code returned for requests without fixture
+const ErrCodeNoFixture = -32099
+
+// Server serves fixtures over HTTP at URL and over websocket at WSURL. In record mode
+// requests are forwarded to a node and its responses are recorded into Fixtures.
+type Server struct {
+	*httptest.Server
+
+	fixtures *Fixtures
+	upstream *rpc.Client
+	rpc      *rpc.Server
+
+	mu      sync.Mutex
+	missing []string
+}
+
+// NewServer starts a server replaying fixtures
+func NewServer(fixtures *Fixtures) *Server {
+	return newServer(fixtures, nil)
+}
+
+// NewRecorder starts a server forwarding requests to the node at url and recording them.
+// A node created with the recorder URL has its traffic captured, see Fixtures.
+func NewRecorder(url string) (*Server, error) {
+	upstream, err := rpc.Dial(url)
+	if err != nil {
+		return nil, err
+	}
+	return newServer(NewFixtures(), upstream), nil
+}
+
+func newServer(fixtures *Fixtures, upstream *rpc.Client) *Server {
+	s := &Server{fixtures: fixtures, upstream: upstream, rpc: rpc.NewServer()}
+	services := map[string]interface{}{
+		"kai":     &kaiAPI{s},
+		"account": &accountAPI{s},
+		"tx":      &txAPI{s},
+		"node":    &nodeAPI{s},
+	}
+	for name, service := range services {
+		if err := s.rpc.RegisterName(name, service); err != nil {
+			panic(err)
+		}
+	}
+	ws := s.rpc.WebsocketHandler([]string{"*"})
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+			ws.ServeHTTP(w, r)
+			return
+		}
+		s.rpc.ServeHTTP(w, r)
+	}))
+	return s
+}
+
+// WSURL returns the websocket URL of the server, required by subscriptions
+func (s *Server) WSURL() string {
+	return "ws" + strings.TrimPrefix(s.URL, "http")
+}
+
+// Fixtures returns the replayed or recorded fixtures
+func (s *Server) Fixtures() *Fixtures {
+	return s.fixtures
+}
+
+// Missing returns the requests which had no fixture to replay
+func (s *Server) Missing() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string{}, s.missing...)
+}
+
+// Close shuts down the server and the connection to the recorded node
+func (s *Server) Close() {
+	s.Server.Close()
+	s.rpc.Stop()
+	if s.upstream != nil {
+		s.upstream.Close()
+	}
+}
+
+// call replays or records method called with params
+func (s *Server) call(ctx context.Context, method string, params ...json.RawMessage) (json.RawMessage, error) {
+	params = trimParams(params)
+	if s.upstream == nil {
+		fixture := s.fixtures.Find(method, params)
+		if fixture == nil {
+			return nil, s.noFixture(method, params)
+		}
+		if fixture.Error != nil {
+			return nil, fixture.Error
+		}
+		return fixture.Result, nil
+	}
+
+	args := make([]interface{}, len(params))
+	for i, param := range params {
+		args[i] = param
+	}
+	var result json.RawMessage
+	err := s.upstream.CallContext(ctx, &result, method, args...)
+	fixture := &Fixture{Method: method, Params: params, Result: result}
+	if err != nil {
+		var rpcErr rpc.Error
+		if !errors.As(err, &rpcErr) {
+			// transport errors are not part of the node responses
+			return nil, err
+		}
+		fixture.Result, fixture.Error = nil, asFixtureError(rpcErr)
+	}
+	s.fixtures.Add(fixture)
+	if fixture.Error != nil {
+		return nil, fixture.Error
+	}
+	return result, nil
+}
+
+// subscribe replays or records a kai_subscribe subscription
+func (s *Server) subscribe(ctx context.Context, params ...json.RawMessage) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	params = trimParams(params)
+	if s.upstream == nil {
+		fixture := s.fixtures.Find("kai_subscribe", params)
+		if fixture == nil {
+			return nil, s.noFixture("kai_subscribe", params)
+		}
+		if fixture.Error != nil {
+			return nil, fixture.Error
+		}
+		sub := notifier.CreateSubscription()
+		for _, notification := range fixture.Notifications {
+			if err := notifier.Notify(sub.ID, notification); err != nil {
+				return nil, err
+			}
+		}
+		return sub, nil
+	}
+
+	args := make([]interface{}, len(params))
+	for i, param := range params {
+		args[i] = param
+	}
+	ch := make(chan json.RawMessage)
+	upstreamSub, err := s.upstream.Subscribe(ctx, "kai", ch, args...)
+	if err != nil {
+		return nil, err
+	}
+	fixture := &Fixture{Method: "kai_subscribe", Params: params}
+	s.fixtures.Add(fixture)
+	sub := notifier.CreateSubscription()
+	go func() {
+		defer upstreamSub.Unsubscribe()
+		for {
+			select {
+			case notification := <-ch:
+				s.fixtures.addNotification(fixture, notification)
+				if err := notifier.Notify(sub.ID, notification); err != nil {
+					return
+				}
+			case <-upstreamSub.Err():
+				return
+			case <-sub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+	return sub, nil
+}
+
+func (s *Server) noFixture(method string, params []json.RawMessage) error {
+	key := requestKey(method, params)
+	s.mu.Lock()
+	s.missing = append(s.missing, key)
+	s.mu.Unlock()
+	return &FixtureError{Code: ErrCodeNoFixture, Message: "no fixture recorded for " + key}
+}
+
+func asFixtureError(err rpc.Error) *FixtureError {
+	fixtureErr := &FixtureError{Code: err.ErrorCode(), Message: err.Error()}
+	if dataErr, ok := err.(rpc.DataError); ok {
+		fixtureErr.Data = dataErr.ErrorData()
+	}
+	return fixtureErr
+}
@@ -0,0 +1,116 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package replay
+package replay
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kardiachain/go-kardia/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockRevert struct{}
+
+func (e *mockRevert) Error() string          { return "execution reverted" }
+func (e *mockRevert) ErrorCode() int         { return 3 }
+func (e *mockRevert) ErrorData() interface{} { return "0x08c379a0" }
+
+// mockKaiAPI is the upstream node, its height grows on every call
+type mockKaiAPI struct {
+	height uint64
+}
+
+func (api *mockKaiAPI) BlockNumber() uint64 {
+	api.height++
+	return api.height
+}
+
+func (api *mockKaiAPI) KardiaCall(args map[string]interface{}, block string) (string, error) {
+	return "", &mockRevert{}
+}
+
+func setupRecorder(t *testing.T) *Server {
+	upstream := rpc.NewServer()
+	assert.Nil(t, upstream.RegisterName("kai", &mockKaiAPI{}))
+	httpServer := httptest.NewServer(upstream)
+	t.Cleanup(httpServer.Close)
+	recorder, err := NewRecorder(httpServer.URL)
+	assert.Nil(t, err)
+	return recorder
+}
+
+func TestServer_RecordAndReplay(t *testing.T) {
+	ctx := context.Background()
+	recorder := setupRecorder(t)
+	client, err := rpc.Dial(recorder.URL)
+	assert.Nil(t, err)
+	var height uint64
+	for i := 0; i < 2; i++ {
+		assert.Nil(t, client.CallContext(ctx, &height, "kai_blockNumber"))
+	}
+	assert.Equal(t, uint64(2), height)
+	err = client.CallContext(ctx, nil, "kai_kardiaCall", map[string]interface{}{"to": "0x01", "data": "0x"}, "latest")
+	assert.NotNil(t, err)
+	client.Close()
+	recorder.Close()
+
+	dir, err := ioutil.TempDir("", "replay")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "fixtures.json")
+	assert.Nil(t, recorder.Fixtures().Save(path))
+	fixtures, err := LoadFixtures(path)
+	assert.Nil(t, err)
+	assert.Len(t, fixtures.Entries(), 3)
+
+	server := NewServer(fixtures)
+	defer server.Close()
+	client, err = rpc.Dial(server.URL)
+	assert.Nil(t, err)
+	defer client.Close()
+
+	// repeated requests replay in recorded order, then repeat the last response
+	for _, expected := range []uint64{1, 2, 2} {
+		assert.Nil(t, client.CallContext(ctx, &height, "kai_blockNumber"))
+		assert.Equal(t, expected, height)
+	}
+
+	// params are matched whatever the key order
+	err = client.CallContext(ctx, nil, "kai_kardiaCall", json.RawMessage(`{"data":"0x","to":"0x01"}`), "latest")
+	var dataErr rpc.DataError
+	assert.True(t, errors.As(err, &dataErr))
+	assert.Equal(t, "execution reverted", dataErr.Error())
+	assert.Equal(t, "0x08c379a0", dataErr.ErrorData())
+	var rpcErr rpc.Error
+	assert.True(t, errors.As(err, &rpcErr))
+	assert.Equal(t, 3, rpcErr.ErrorCode())
+	assert.Empty(t, server.Missing())
+
+	err = client.CallContext(ctx, nil, "kai_kardiaCall", map[string]interface{}{"to": "0x02"}, "latest")
+	assert.True(t, errors.As(err, &rpcErr))
+	assert.Equal(t, ErrCodeNoFixture, rpcErr.ErrorCode())
+	assert.Equal(t, []string{`kai_kardiaCall({"to":"0x02"},"latest")`}, server.Missing())
+}
@@ -0,0 +1,258 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package kardia
+package kardia
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kardiachain/go-kardia"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/rpc"
+	"github.com/kardiachain/go-kardia/types"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/kardiachain/go-kaiclient/kardia/replay"
+)
+
+// replayFixtures is still the recording of a simulated chain (network kai-simulated, genesis 2021-06-01),
+// not of a go-kardia node. Re-record it with TestReplay_RecordFixtures and update the replay constants.
+const replayFixtures = "replay/testdata/node.json"
+
+// TestReplay_RecordFixtures records replayFixtures from a go-kardia node through a replay.Recorder.
+// Run it with KARDIA_RECORD_URL set to the websocket endpoint of a node, e.g. wss://ws.kardiachain.io/ws,
+// and KARDIA_RECORD_TX to a contract creation transaction, then update the replay constants below.
+// Only reads are recorded, no transaction is sent.
+func TestReplay_RecordFixtures(t *testing.T) {
+	url, txHash := os.Getenv("KARDIA_RECORD_URL"), os.Getenv("KARDIA_RECORD_TX")
+	if url == "" || txHash == "" {
+		t.Skip("set KARDIA_RECORD_URL and KARDIA_RECORD_TX to record " + replayFixtures)
+	}
+	ctx := context.Background()
+	recorder, err := replay.NewRecorder(url)
+	assert.Nil(t, err)
+	defer recorder.Close()
+	node, err := NewNode(recorder.WSURL(), zap.NewNop())
+	assert.Nil(t, err)
+
+	heads := make(chan *types.Header)
+	sub, err := node.SubscribeNewHead(ctx, heads)
+	assert.Nil(t, err)
+	<-heads
+	sub.Unsubscribe()
+
+	receipt, err := node.GetTransactionReceipt(ctx, txHash)
+	assert.Nil(t, err)
+	tx, err := node.GetTransaction(ctx, txHash)
+	assert.Nil(t, err)
+	_, err = node.BlockByHeight(ctx, receipt.BlockHeight)
+	assert.Nil(t, err)
+	_, err = node.BlockHeaderByNumber(ctx, receipt.BlockHeight)
+	assert.Nil(t, err)
+	_, err = node.Balance(ctx, tx.From)
+	assert.Nil(t, err)
+	_, err = node.NodeInfo(ctx)
+	assert.Nil(t, err)
+	_, err = node.GetTransactionReceipt(ctx, testTxHash)
+	assert.Equal(t, kardia.NotFound, err)
+
+	assert.Nil(t, recorder.Fixtures().Save(replayFixtures))
+}
+
+// replay constants pin the values of the simulated chain recorded in replayFixtures
+const (
+	replayTxHash       = "0x22a195bf3b8f7bc40f4f497198bc511cf9fc066e067b43f56ddd7c686208e706"
+	replayBlockHash    = "0x01ca948f02d1830bc7114ba24675c65e92e09ad3457eed39c3e478a2cc0c0964"
+	replayContractAddr = "0x1b0B1750138462fb228fC619F834D2eE38ECDcDf"
+	replaySender       = "0x4f36A53DC32272b97Ae5FF511387E2741D727bdb"
+)
+
+var replayBlockTime = time.Date(2021, 6, 1, 0, 0, 5, 0, time.UTC)
+
+// setupReplayNode returns a node replaying replayFixtures over HTTP, or websocket if ws is set
+func setupReplayNode(t *testing.T, ws bool) (*replay.Server, Node) {
+	fixtures, err := replay.LoadFixtures(replayFixtures)
+	assert.Nil(t, err)
+	server := replay.NewServer(fixtures)
+	url := server.URL
+	if ws {
+		url = server.WSURL()
+	}
+	node, err := NewNode(url, zap.NewNop())
+	assert.Nil(t, err)
+	return server, node
+}
+
+func TestReplay_Receipt(t *testing.T) {
+	server, node := setupReplayNode(t, false)
+	defer server.Close()
+
+	receipt, err := node.GetTransactionReceipt(context.Background(), replayTxHash)
+	assert.Nil(t, err)
+	assert.Equal(t, replayBlockHash, receipt.BlockHash)
+	assert.Equal(t, uint64(1), receipt.BlockHeight)
+	assert.Equal(t, replayTxHash, receipt.TransactionHash)
+	assert.Equal(t, replaySender, receipt.From)
+	assert.Equal(t, "0x", receipt.To)
+	assert.Equal(t, uint64(1450996), receipt.GasUsed)
+	assert.Equal(t, uint64(1450996), receipt.CumulativeGasUsed)
+	assert.Equal(t, replayContractAddr, receipt.ContractAddress)
+	assert.Equal(t, ReceiptStatusSuccessful, receipt.Status)
+	assert.Empty(t, receipt.Root)
+
+	assert.Len(t, receipt.Logs, 1)
+	log := receipt.Logs[0]
+	assert.Equal(t, replayContractAddr, log.Address)
+	assert.Equal(t, []string{
+		"0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef",
+		"0x0000000000000000000000000000000000000000000000000000000000000000",
+		"0x0000000000000000000000004f36a53dc32272b97ae5ff511387e2741d727bdb",
+	}, log.Topics)
+	assert.Equal(t, "0x0000000000000000000000000000000000000001431e0fae6d7217caa0000000", log.Data)
+	assert.Equal(t, uint64(1), log.BlockHeight)
+	assert.Equal(t, replayTxHash, log.TxHash)
+	assert.Equal(t, replayBlockHash, log.BlockHash)
+	assert.Equal(t, uint(1), log.Index)
+	assert.True(t, receipt.LogsBloom.Test(common.HexToAddress(replayContractAddr).Bytes()))
+	assert.Empty(t, server.Missing())
+}
+
+func TestReplay_Transaction(t *testing.T) {
+	server, node := setupReplayNode(t, false)
+	defer server.Close()
+
+	tx, err := node.GetTransaction(context.Background(), replayTxHash)
+	assert.Nil(t, err)
+	assert.Equal(t, replayBlockHash, tx.BlockHash)
+	assert.Equal(t, uint64(1), tx.BlockNumber)
+	assert.Equal(t, replayTxHash, tx.Hash)
+	assert.Equal(t, replaySender, tx.From)
+	assert.Equal(t, "0x", tx.To)
+	assert.Equal(t, "0", tx.Value)
+	assert.Equal(t, uint64(1000000000), tx.GasPrice)
+	assert.Equal(t, uint64(1450996), tx.GasLimit)
+	assert.Equal(t, uint64(0), tx.Nonce)
+	assert.Equal(t, uint(0), tx.TransactionIndex)
+	assert.True(t, replayBlockTime.Equal(tx.Time))
+	assert.True(t, strings.HasPrefix(tx.InputData, "0x6080604052"))
+	// the node does not return receipt fields with the transaction
+	assert.Empty(t, tx.ContractAddress)
+	assert.Zero(t, tx.GasUsed)
+	assert.Empty(t, server.Missing())
+}
+
+func TestReplay_BlockAndHeader(t *testing.T) {
+	server, node := setupReplayNode(t, false)
+	defer server.Close()
+	ctx := context.Background()
+
+	block, err := node.BlockByHeight(ctx, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, replayBlockHash, block.Hash)
+	assert.Equal(t, uint64(1), block.Height)
+	assert.Equal(t, "0x55aee083069fa05983bfe575a49a3151c051c3bea00483b4619e8bf2593593c6", block.LastBlock)
+	assert.True(t, replayBlockTime.Equal(block.Time))
+	assert.Equal(t, uint64(1), block.NumTxs)
+	assert.Equal(t, uint64(50000000), block.GasLimit)
+	assert.Equal(t, uint64(1450996), block.GasUsed)
+	assert.Equal(t, "16050000000000000000", block.Rewards)
+	assert.Equal(t, "0x4f36a53dc32272b97ae5ff511387e2741d727bdb", block.ProposerAddress)
+	assert.Equal(t, "0xdc3c17201d4f9678e06636b485a0c823a85509f7a45fef75a2b603e3481e4a9c", block.DataHash)
+	assert.Equal(t, "0xcf15d733b3a731f9588203bde6b2d1e76e6e574ee898373377456355e523e424", block.ValidatorHash)
+	assert.Equal(t, block.ValidatorHash, block.NextValidatorHash)
+	assert.Equal(t, "0x446de613d62e8418ab3fb3d90542ea14b26df68667668fcc90a0053ef6900cb8", block.AppHash)
+	assert.Len(t, block.Txs, 1)
+	assert.Equal(t, replayTxHash, block.Txs[0].Hash)
+	assert.Len(t, block.Receipts, 1)
+	assert.Equal(t, replayContractAddr, block.Receipts[0].ContractAddress)
+
+	header, err := node.BlockHeaderByNumber(ctx, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, block.Hash, header.Hash)
+	assert.Equal(t, block.Height, header.Height)
+	assert.Equal(t, block.LastBlock, header.LastBlock)
+	assert.Equal(t, common.HexToHash(block.LastBlock), header.LastBlockID.Hash)
+	assert.True(t, block.Time.Equal(header.Time))
+	assert.Equal(t, block.NumTxs, header.NumTxs)
+	assert.Equal(t, block.GasUsed, header.GasUsed)
+	assert.Equal(t, block.GasLimit, header.GasLimit)
+	assert.Equal(t, block.Rewards, header.Rewards)
+	assert.Equal(t, block.ProposerAddress, header.ProposerAddress)
+	assert.Equal(t, block.DataHash, header.TxHash)
+	assert.Equal(t, block.ValidatorHash, header.ValidatorsHash)
+	assert.Equal(t, block.AppHash, header.AppHash)
+	assert.NotNil(t, header.Bloom)
+	assert.Empty(t, server.Missing())
+}
+
+func TestReplay_BalanceAndNodeInfo(t *testing.T) {
+	server, node := setupReplayNode(t, false)
+	defer server.Close()
+	ctx := context.Background()
+
+	balance, err := node.Balance(ctx, replaySender)
+	assert.Nil(t, err)
+	assert.Equal(t, "7000000000000000000000000", balance)
+	info, err := node.NodeInfo(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "kai-simulated", info.Network)
+	assert.Empty(t, info.Peers)
+	assert.Empty(t, server.Missing())
+}
+
+func TestReplay_NotFoundAndMissing(t *testing.T) {
+	server, node := setupReplayNode(t, false)
+	defer server.Close()
+	ctx := context.Background()
+
+	_, err := node.GetTransactionReceipt(ctx, testTxHash)
+	assert.Equal(t, kardia.NotFound, err)
+
+	_, err = node.BlockByHeight(ctx, 2)
+	var rpcErr rpc.Error
+	assert.True(t, errors.As(err, &rpcErr))
+	assert.Equal(t, replay.ErrCodeNoFixture, rpcErr.ErrorCode())
+	assert.Equal(t, []string{"kai_getBlockByNumber(2)"}, server.Missing())
+}
+
+func TestReplay_SubscribeNewHead(t *testing.T) {
+	server, node := setupReplayNode(t, true)
+	defer server.Close()
+
+	heads := make(chan *types.Header)
+	sub, err := node.SubscribeNewHead(context.Background(), heads)
+	assert.Nil(t, err)
+	defer sub.Unsubscribe()
+	select {
+	case head := <-heads:
+		assert.Equal(t, common.HexToHash(replayBlockHash), head.Hash())
+		assert.Equal(t, uint64(1), head.Height)
+		assert.Equal(t, uint64(1), head.NumTxs)
+		assert.Equal(t, common.HexToAddress(replaySender), head.ProposerAddress)
+	case err := <-sub.Err():
+		t.Fatal(err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no head replayed")
+	}
+}
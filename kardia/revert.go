@@ -0,0 +1,244 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package kardia
+package kardia
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/kardiachain/go-kardia/lib/abi"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/crypto"
+	"github.com/kardiachain/go-kardia/rpc"
+)
+
+const revertMsgPrefix = "execution reverted"
+
+var (
+	revertSelector = crypto.Keccak256([]byte("Error(string)"))[:4]
+	panicSelector  = crypto.Keccak256([]byte("Panic(uint256)"))[:4]
+)
+
+// RevertError is returned when a call or a transaction is reverted by the contract
+type RevertError struct {
+	// Reason is the Error(string) message, or the custom error with its arguments
+	Reason string
+	// ErrorName and ErrorArgs are set when the revert data matches a custom error
+	ErrorName string
+	ErrorArgs map[string]interface{}
+	// Data is the raw revert data, empty if the node returned only the reason
+	Data []byte
+	// Method is the name of the reverted method and Input its decoded input, if the ABI is known
+	Method string
+	Input  *FunctionCall
+
+	err error
+}
+
+func (e *RevertError) Error() string {
+	msg := revertMsgPrefix
+	if e.Reason != "" {
+		msg += ": " + e.Reason
+	}
+	if e.Method != "" {
+		msg += " (method " + e.Method + ")"
+	}
+	return msg
+}
+
+// Unwrap returns the RPC error the revert was decoded from
+func (e *RevertError) Unwrap() error {
+	return e.err
+}
+
+// RevertDecoder decodes input and custom errors of a contract. Custom errors are
+// not supported by abi.JSON, so the decoder parses the ABI JSON itself.
+type RevertDecoder struct {
+	abi    *abi.ABI
+	errors map[string]customError
+}
+
+type customError struct {
+	name   string
+	inputs abi.Arguments
+}
+
+// NewRevertDecoder parses the contract ABI JSON, including its "error" entries
+func NewRevertDecoder(abiJSON string) (*RevertDecoder, error) {
+	var fields []struct {
+		Type   string
+		Name   string
+		Inputs []abi.ArgumentMarshaling
+	}
+	if err := json.Unmarshal([]byte(abiJSON), &fields); err != nil {
+		return nil, err
+	}
+	var (
+		entries []json.RawMessage
+		d       = &RevertDecoder{errors: make(map[string]customError)}
+	)
+	if err := json.Unmarshal([]byte(abiJSON), &entries); err != nil {
+		return nil, err
+	}
+	var withoutErrors []json.RawMessage
+	for i, field := range fields {
+		if field.Type != "error" {
+			withoutErrors = append(withoutErrors, entries[i])
+			continue
+		}
+		var (
+			inputs abi.Arguments
+			types  []string
+		)
+		for _, input := range field.Inputs {
+			typ, err := abi.NewType(input.Type, input.InternalType, input.Components)
+			if err != nil {
+				return nil, err
+			}
+			inputs = append(inputs, abi.Argument{Name: input.Name, Type: typ})
+			types = append(types, typ.String())
+		}
+		signature := fmt.Sprintf("%s(%s)", field.Name, strings.Join(types, ","))
+		d.errors[string(crypto.Keccak256([]byte(signature))[:4])] = customError{name: field.Name, inputs: inputs}
+	}
+	data, err := json.Marshal(withoutErrors)
+	if err != nil {
+		return nil, err
+	}
+	contractABI, err := abi.JSON(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	d.abi = &contractABI
+	return d, nil
+}
+
+// decodeData fills the reason of e from its revert data
+func (d *RevertDecoder) decodeData(e *RevertError) {
+	if len(e.Data) < 4 {
+		return
+	}
+	selector, body := e.Data[:4], e.Data[4:]
+	switch {
+	case bytes.Equal(selector, revertSelector):
+		if reason, err := abi.UnpackRevert(e.Data); err == nil {
+			e.Reason = reason
+		}
+	case bytes.Equal(selector, panicSelector):
+		if len(body) == 32 {
+			e.Reason = fmt.Sprintf("panic: 0x%x", new(big.Int).SetBytes(body))
+		}
+	case d != nil:
+		custom, ok := d.errors[string(selector)]
+		if !ok {
+			return
+		}
+		args := make(map[string]interface{})
+		if err := custom.inputs.UnpackIntoMap(args, body); err != nil {
+			return
+		}
+		var values []string
+		for _, input := range custom.inputs {
+			values = append(values, fmt.Sprintf("%s=%v", input.Name, args[input.Name]))
+		}
+		e.ErrorName, e.ErrorArgs = custom.name, args
+		e.Reason = fmt.Sprintf("%s(%s)", custom.name, strings.Join(values, ", "))
+	}
+}
+
+// asRevertError converts err into a *RevertError if it is a revert returned by the node,
+// decoding the reason with d and the input of args with d or the staking/validator ABIs.
+// Other errors are returned as is.
+func (n *node) asRevertError(err error, args SMCCallArgs, d *RevertDecoder) error {
+	var rpcErr rpc.Error
+	if !errors.As(err, &rpcErr) || !strings.HasPrefix(rpcErr.Error(), revertMsgPrefix) {
+		return err
+	}
+	revert := &RevertError{err: err}
+	if dataErr, ok := rpcErr.(rpc.DataError); ok {
+		if data, ok := dataErr.ErrorData().(string); ok {
+			revert.Data = common.FromHex(data)
+		}
+	}
+	d.decodeData(revert)
+	if revert.Reason == "" {
+		revert.Reason = strings.TrimPrefix(strings.TrimPrefix(rpcErr.Error(), revertMsgPrefix), ": ")
+	}
+
+	var input *FunctionCall
+	if d != nil && d.abi != nil {
+		input, _ = DecodeWithABI(args.Data, d.abi)
+	} else if args.To != nil {
+		input, _ = n.DecodeInputData(*args.To, args.Data)
+	}
+	if input != nil {
+		revert.Method, revert.Input = input.MethodName, input
+	}
+	return revert
+}
+
+// TxRevertReason re-executes the failed transaction txHash as a call on the state before its block,
+// as later transactions of the block may change the result, and returns why it was reverted. d decodes custom errors and input of the called contract,
+// nil decodes Error(string) reasons and staking/validator inputs only.
+func (n *node) TxRevertReason(ctx context.Context, txHash string, d *RevertDecoder) (*RevertError, error) {
+	receipt, err := n.GetTransactionReceipt(ctx, txHash)
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status == ReceiptStatusSuccessful {
+		return nil, ErrTxNotFailed
+	}
+	tx, err := n.GetTransaction(ctx, txHash)
+	if err != nil {
+		return nil, err
+	}
+	header, err := n.BlockHeaderByNumber(ctx, tx.BlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	value, ok := new(big.Int).SetString(tx.Value, 10)
+	if !ok {
+		value = big.NewInt(0)
+	}
+	args := SMCCallArgs{
+		From:     tx.From,
+		Gas:      tx.GasLimit,
+		GasPrice: new(big.Int).SetUint64(tx.GasPrice),
+		Value:    value,
+		Data:     tx.InputData,
+	}
+	if tx.To != "" {
+		args.To = &tx.To
+	}
+	var result common.Bytes
+	err = n.client.CallContext(ctx, &result, "kai_kardiaCall", args, BlockAtHash(header.LastBlockID.Hash).arg())
+	if err == nil {
+		return nil, ErrRevertNotReproduced
+	}
+	var revert *RevertError
+	if errors.As(n.asRevertError(err, args, d), &revert) {
+		return revert, nil
+	}
+	return nil, err
+}
@@ -0,0 +1,178 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package kardia
+package kardia
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/kardiachain/go-kardia/lib/abi"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/crypto"
+	"github.com/kardiachain/go-kardia/rpc"
+	"github.com/kardiachain/go-kardia/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kardiachain/go-kaiclient/kardia/smc"
+)
+
+const testRevertABI = `[
+	{"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+	{"type":"error","name":"InsufficientBalance","inputs":[{"name":"available","type":"uint256"},{"name":"required","type":"uint256"}]}
+]`
+
+// mockRevert is a revert returned by the mock node, with data when data is set
+type mockRevert struct {
+	msg  string
+	data string
+}
+
+func (e *mockRevert) Error() string  { return e.msg }
+func (e *mockRevert) ErrorCode() int { return -32000 }
+
+type mockRevertWithData struct{ mockRevert }
+
+func (e *mockRevertWithData) ErrorData() interface{} { return e.data }
+
+// testParentBlockHash is the hash of the block before the block of the mocked transaction
+const testParentBlockHash = "0x55aee083069fa05983bfe575a49a3151c051c3bea00483b4619e8bf2593593c6"
+
+// mockRevertAPI reverts every kai_kardiaCall with err and serves one failed transaction
+type mockRevertAPI struct {
+	err    error
+	blocks []rpc.BlockHeightOrHash
+	tx     *Transaction
+}
+
+func (api *mockRevertAPI) KardiaCall(args types.CallArgsJSON, block rpc.BlockHeightOrHash) (common.Bytes, error) {
+	api.blocks = append(api.blocks, block)
+	return nil, api.err
+}
+
+func (api *mockRevertAPI) GetTransaction(hash common.Hash) *Transaction {
+	return api.tx
+}
+
+func (api *mockRevertAPI) GetBlockHeaderByNumber(height rpc.BlockHeight) *Header {
+	return &Header{Height: height.Uint64(), LastBlockID: &types.BlockID{Hash: common.HexToHash(testParentBlockHash)}}
+}
+
+func (api *mockRevertAPI) GetTransactionReceipt(hash common.Hash) *Receipt {
+	return &Receipt{TransactionHash: api.tx.Hash, BlockHeight: api.tx.BlockNumber, Status: ReceiptStatusFailed}
+}
+
+// setupMockSMC sets up staking and validator contracts without reaching the node
+func setupMockSMC(t *testing.T, n *node) {
+	stakingABI, err := abi.JSON(strings.NewReader(smc.StakingABI))
+	assert.Nil(t, err)
+	n.stakingSMC = &Contract{Abi: &stakingABI, ContractAddress: common.HexToAddress(StakingContractAddr)}
+	validatorABI, err := abi.JSON(strings.NewReader(smc.ValidatorABI))
+	assert.Nil(t, err)
+	n.validatorSMC = &Contract{Abi: &validatorABI}
+}
+
+func packRevert(t *testing.T, signature string, types []string, values ...interface{}) string {
+	var args abi.Arguments
+	for _, typ := range types {
+		abiType, err := abi.NewType(typ, "", nil)
+		assert.Nil(t, err)
+		args = append(args, abi.Argument{Type: abiType})
+	}
+	body, err := args.Pack(values...)
+	assert.Nil(t, err)
+	return common.Encode(append(crypto.Keccak256([]byte(signature))[:4], body...))
+}
+
+func TestRevert_KardiaCallReason(t *testing.T) {
+	data := packRevert(t, "Error(string)", []string{"string"}, "insufficient balance")
+	api := &mockRevertAPI{err: &mockRevertWithData{mockRevert{msg: "execution reverted", data: data}}}
+	n, err := setupMockNodeInstance(map[string]interface{}{"kai": api})
+	assert.Nil(t, err)
+	setupMockSMC(t, n)
+
+	_, err = n.KardiaCall(context.Background(), ConstructCallArgs(test1SmcAddr, []byte{0x01, 0x02, 0x03, 0x04}))
+	var revert *RevertError
+	assert.True(t, errors.As(err, &revert))
+	assert.Equal(t, "insufficient balance", revert.Reason)
+	assert.Equal(t, common.FromHex(data), revert.Data)
+	assert.Equal(t, "execution reverted: insufficient balance", revert.Error())
+
+	// the RPC error is still reachable
+	var rpcErr rpc.Error
+	assert.True(t, errors.As(err, &rpcErr))
+}
+
+func TestRevert_KardiaCallMessageOnly(t *testing.T) {
+	api := &mockRevertAPI{err: &mockRevert{msg: "execution reverted: not owner"}}
+	n, err := setupMockNodeInstance(map[string]interface{}{"kai": api})
+	assert.Nil(t, err)
+	setupMockSMC(t, n)
+
+	_, err = n.KardiaCall(context.Background(), ConstructCallArgs(test1SmcAddr, nil))
+	var revert *RevertError
+	assert.True(t, errors.As(err, &revert))
+	assert.Equal(t, "not owner", revert.Reason)
+	assert.Empty(t, revert.Data)
+
+	// other errors are untouched
+	api.err = &mockRevert{msg: "out of gas"}
+	_, err = n.KardiaCall(context.Background(), ConstructCallArgs(test1SmcAddr, nil))
+	assert.False(t, errors.As(err, &revert))
+}
+
+func TestRevert_TxRevertReason(t *testing.T) {
+	d, err := NewRevertDecoder(testRevertABI)
+	assert.Nil(t, err)
+	input, err := d.abi.Pack("transfer", common.HexToAddress(test1SmcAddr), big.NewInt(100))
+	assert.Nil(t, err)
+
+	data := packRevert(t, "InsufficientBalance(uint256,uint256)", []string{"uint256", "uint256"}, big.NewInt(10), big.NewInt(100))
+	api := &mockRevertAPI{
+		err: &mockRevertWithData{mockRevert{msg: "execution reverted", data: data}},
+		tx: &Transaction{
+			BlockNumber: 1234,
+			Hash:        testTxHash,
+			From:        test1SmcAddr,
+			To:          test1SmcAddr,
+			Value:       "0",
+			GasLimit:    100000,
+			GasPrice:    1000000000,
+			InputData:   common.Encode(input),
+		},
+	}
+	n, err := setupMockNodeInstance(map[string]interface{}{"kai": api, "tx": api})
+	assert.Nil(t, err)
+
+	revert, err := n.TxRevertReason(context.Background(), testTxHash, d)
+	assert.Nil(t, err)
+	// the call runs on the state before the block of the transaction
+	hash, ok := api.blocks[0].Hash()
+	assert.True(t, ok)
+	assert.Equal(t, common.HexToHash(testParentBlockHash), hash)
+
+	assert.Equal(t, "InsufficientBalance", revert.ErrorName)
+	assert.Equal(t, big.NewInt(10), revert.ErrorArgs["available"])
+	assert.Equal(t, big.NewInt(100), revert.ErrorArgs["required"])
+	assert.Equal(t, "InsufficientBalance(available=10, required=100)", revert.Reason)
+	assert.Equal(t, "transfer", revert.Method)
+	assert.Equal(t, "100", revert.Input.Arguments["amount"])
+}
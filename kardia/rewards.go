@@ -0,0 +1,240 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package kardia
+package kardia
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/kardiachain/go-kardia/lib/common"
+)
+
+// validatorStatusBonded is the Bonded status of the validator contract, the other ones are Unbonding and Unbonded
+const validatorStatusBonded uint8 = 2
+
+// yearDuration is the year of the APRs
+const yearDuration = 365 * 24 * time.Hour
+
+// oneDec is 1 in the 1e18 fixed point units of the staking contracts
+var oneDec = big.NewInt(1e18)
+
+// IRewards computes the staking yields from the mint parameters and the staking state.
+// Rates are in 1e18 units, 1e18 is 100%.
+type IRewards interface {
+	// StakingYield returns the inflation and the APR of the network at the block selected by ctx
+	StakingYield(ctx context.Context) (*StakingYield, error)
+	// ValidatorAPR returns the APR of the delegators of the validator after its commission, 0 if the validator
+	// is not bonded or jailed
+	ValidatorAPR(ctx context.Context, validatorSMCAddress common.Address) (*big.Int, error)
+	// ProjectRewards returns the rewards of amount delegated to the validator during period, at its current APR
+	ProjectRewards(ctx context.Context, validatorSMCAddress common.Address, amount *big.Int, period time.Duration) (*big.Int, error)
+}
+
+// StakingYield is the yield of the staking at a block
+type StakingYield struct {
+	Height      uint64
+	TotalSupply *big.Int
+	TotalBonded *big.Int
+	BondedRatio *big.Int
+	// Inflation is the annual inflation rate the block is minted with
+	Inflation *big.Int
+	// NextInflation is the inflation rate of the next block, it moves towards the goal bonded ratio
+	NextInflation   *big.Int
+	AnnualProvision *big.Int
+	// APR is the APR of the bonded tokens, before the commission of the validators
+	APR *big.Int
+}
+
+func (n *node) StakingYield(ctx context.Context) (*StakingYield, error) {
+	header, err := n.selectedHeader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// every read is pinned to the header, the latest block may change meanwhile
+	if header.Height != 0 {
+		ctx = WithBlock(ctx, BlockAtHeight(header.Height))
+	}
+	params, err := n.ChainParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+	supply, err := n.GetCirculatingSupply(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bonded, err := n.TotalStakedAmount(ctx)
+	if err != nil {
+		return nil, err
+	}
+	minted, ok := new(big.Int).SetString(header.Rewards, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid rewards %q of block %d", header.Rewards, header.Height)
+	}
+	// the block is minted before its supply is increased
+	inflation := MintedInflation(minted, new(big.Int).Sub(supply, minted), params.BlocksPerYear)
+	ratio := BondedRatio(bonded, supply)
+	provision := AnnualProvision(inflation, supply)
+	return &StakingYield{
+		Height:          header.Height,
+		TotalSupply:     supply,
+		TotalBonded:     bonded,
+		BondedRatio:     ratio,
+		Inflation:       inflation,
+		NextInflation:   NextInflationRate(inflation, ratio, params),
+		AnnualProvision: provision,
+		APR:             NetworkAPR(provision, bonded),
+	}, nil
+}
+
+func (n *node) ValidatorAPR(ctx context.Context, validatorSMCAddress common.Address) (*big.Int, error) {
+	validator, err := n.ValidatorInfo(ctx, validatorSMCAddress.Hex())
+	if err != nil {
+		return nil, err
+	}
+	if validator.Status != validatorStatusBonded || validator.Jailed {
+		return new(big.Int), nil
+	}
+	commission, err := n.ValidatorCommission(ctx, validatorSMCAddress.Hex())
+	if err != nil {
+		return nil, err
+	}
+	yield, err := n.StakingYield(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return DelegatorAPR(yield.APR, commission.Rate), nil
+}
+
+func (n *node) ProjectRewards(ctx context.Context, validatorSMCAddress common.Address, amount *big.Int, period time.Duration) (*big.Int, error) {
+	apr, err := n.ValidatorAPR(ctx, validatorSMCAddress)
+	if err != nil {
+		return nil, err
+	}
+	return ProjectedRewards(amount, apr, period), nil
+}
+
+// selectedHeader returns the header of the block selected by ctx, the pending block selects the latest one
+func (n *node) selectedHeader(ctx context.Context) (*Header, error) {
+	block := BlockFromContext(ctx)
+	switch {
+	case block.hash != nil:
+		return n.BlockHeaderByHash(ctx, block.hash.Hex())
+	case block.height != 0:
+		return n.BlockHeaderByNumber(ctx, block.height)
+	}
+	height, err := n.LatestBlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return n.BlockHeaderByNumber(ctx, height)
+}
+
+// BondedRatio returns the ratio of the supply that is bonded
+func BondedRatio(bonded, supply *big.Int) *big.Int {
+	if supply.Sign() == 0 {
+		return new(big.Int)
+	}
+	return divDec(bonded, supply)
+}
+
+// NextInflationRate returns the inflation rate the minter contract sets at the next block. Each block moves the
+// rate by InflationRateChange/BlocksPerYear, scaled by the distance of the bonded ratio to GoalBonded: it increases
+// below the goal and decreases above it, within InflationMin and InflationMax. Without GoalBonded or BlocksPerYear
+// the minter cannot move the rate, the current inflation is only clamped.
+func NextInflationRate(inflation, bondedRatio *big.Int, params *ChainParams) *big.Int {
+	rate := new(big.Int).Set(inflation)
+	if params.GoalBonded.Sign() > 0 && params.BlocksPerYear > 0 {
+		blocksPerYear := new(big.Int).SetUint64(params.BlocksPerYear)
+		ratioToGoal := divDec(bondedRatio, params.GoalBonded)
+		if ratioToGoal.Cmp(oneDec) < 0 {
+			change := mulDec(new(big.Int).Sub(oneDec, ratioToGoal), params.InflationRateChange)
+			rate.Add(inflation, change.Quo(change, blocksPerYear))
+		} else {
+			change := mulDec(new(big.Int).Sub(ratioToGoal, oneDec), params.InflationRateChange)
+			change.Quo(change, blocksPerYear)
+			rate.SetInt64(0)
+			if inflation.Cmp(change) > 0 {
+				rate.Sub(inflation, change)
+			}
+		}
+	}
+	if rate.Cmp(params.InflationMax) > 0 {
+		rate.Set(params.InflationMax)
+	}
+	if rate.Cmp(params.InflationMin) < 0 {
+		rate.Set(params.InflationMin)
+	}
+	return rate
+}
+
+// MintedInflation returns the inflation rate a block minting minted tokens out of supply was minted with.
+// The minter truncates the provision of the block, so the rate is rounded up.
+func MintedInflation(minted, supply *big.Int, blocksPerYear uint64) *big.Int {
+	if supply.Sign() == 0 {
+		return new(big.Int)
+	}
+	provision := new(big.Int).Mul(minted, new(big.Int).SetUint64(blocksPerYear))
+	provision.Mul(provision, oneDec)
+	rate, rem := new(big.Int).QuoRem(provision, supply, new(big.Int))
+	if rem.Sign() > 0 {
+		rate.Add(rate, common.Big1)
+	}
+	return rate
+}
+
+// AnnualProvision returns the tokens minted in a year at the inflation rate
+func AnnualProvision(inflation, supply *big.Int) *big.Int {
+	return mulDec(inflation, supply)
+}
+
+// NetworkAPR returns the APR of the bonded tokens, the annual provision is shared by the bonded validators
+func NetworkAPR(annualProvision, bonded *big.Int) *big.Int {
+	if bonded.Sign() == 0 {
+		return new(big.Int)
+	}
+	return divDec(annualProvision, bonded)
+}
+
+// DelegatorAPR returns the APR of the delegators of a validator taking commissionRate of the rewards
+func DelegatorAPR(networkAPR, commissionRate *big.Int) *big.Int {
+	return mulDec(networkAPR, new(big.Int).Sub(oneDec, commissionRate))
+}
+
+// ProjectedRewards returns the rewards of amount during period at apr. The rewards are not compounded,
+// they have to be withdrawn and delegated again.
+func ProjectedRewards(amount, apr *big.Int, period time.Duration) *big.Int {
+	rewards := new(big.Int).Mul(amount, apr)
+	rewards.Mul(rewards, big.NewInt(int64(period)))
+	rewards.Quo(rewards, oneDec)
+	return rewards.Quo(rewards, big.NewInt(int64(yearDuration)))
+}
+
+// mulDec multiplies the 1e18 fixed point numbers like the mulTrun of the staking contracts
+func mulDec(a, b *big.Int) *big.Int {
+	result := new(big.Int).Mul(a, b)
+	return result.Quo(result, oneDec)
+}
+
+// divDec divides the 1e18 fixed point numbers like the divTrun of the staking contracts
+func divDec(a, b *big.Int) *big.Int {
+	result := new(big.Int).Mul(a, oneDec)
+	return result.Quo(result, b)
+}
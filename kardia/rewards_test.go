@@ -0,0 +1,134 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package kardia
+package kardia
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func bigInt(s string) *big.Int {
+	v, _ := new(big.Int).SetString(s, 10)
+	return v
+}
+
+// mintParams are the default mint parameters of the params contract
+func mintParams() *ChainParams {
+	return &ChainParams{
+		InflationRateChange: bigInt("10000000000000000"),
+		GoalBonded:          bigInt("40000000000000000"),
+		BlocksPerYear:       6220800,
+		InflationMax:        bigInt("50000000000000000"),
+		InflationMin:        bigInt("19968768000000000"),
+	}
+}
+
+func TestNextInflationRate(t *testing.T) {
+	tests := []struct {
+		name        string
+		inflation   string
+		bondedRatio string
+		expected    string
+	}{
+		{"below goal", "20000000000000000", "20000000000000000", "20000000803755144"},
+		{"above goal", "20000000000000000", "80000000000000000", "19999998392489712"},
+		{"at goal", "20000000000000000", "40000000000000000", "20000000000000000"},
+		{"max", "50000000000000000", "0", "50000000000000000"},
+		{"min", "0", "0", "19968768000000000"},
+		{"min above goal", "19968768000000000", "1000000000000000000", "19968768000000000"},
+	}
+	for _, test := range tests {
+		rate := NextInflationRate(bigInt(test.inflation), bigInt(test.bondedRatio), mintParams())
+		assert.Equal(t, test.expected, rate.String(), test.name)
+	}
+
+	// without goal or blocks per year the inflation is only clamped
+	params := mintParams()
+	params.GoalBonded = new(big.Int)
+	assert.Equal(t, "20000000000000000", NextInflationRate(bigInt("20000000000000000"), bigInt("20000000000000000"), params).String())
+	assert.Equal(t, "50000000000000000", NextInflationRate(bigInt("60000000000000000"), bigInt("20000000000000000"), params).String())
+	params = mintParams()
+	params.BlocksPerYear = 0
+	assert.Equal(t, "19968768000000000", NextInflationRate(bigInt("0"), bigInt("20000000000000000"), params).String())
+}
+
+func TestStakingYieldFormulas(t *testing.T) {
+	supply := bigInt("5000000000000000000000000000")
+	bonded := bigInt("1000000000000000000000000000")
+	inflation := bigInt("20000000000000000")
+
+	assert.Equal(t, "200000000000000000", BondedRatio(bonded, supply).String())
+	assert.Equal(t, "0", BondedRatio(bonded, new(big.Int)).String())
+	provision := AnnualProvision(inflation, supply)
+	assert.Equal(t, "100000000000000000000000000", provision.String())
+	// the provision of a block is truncated, the inflation it was minted with is not
+	assert.Equal(t, inflation, MintedInflation(bigInt("16075102880658436213"), supply, 6220800))
+	assert.Equal(t, "0", MintedInflation(new(big.Int), supply, 6220800).String())
+
+	apr := NetworkAPR(provision, bonded)
+	assert.Equal(t, "100000000000000000", apr.String())
+	assert.Equal(t, "0", NetworkAPR(provision, new(big.Int)).String())
+	delegatorAPR := DelegatorAPR(apr, bigInt("100000000000000000"))
+	assert.Equal(t, "90000000000000000", delegatorAPR.String())
+
+	amount := FloatToBigInt(1000, 18)
+	assert.Equal(t, "90000000000000000000", ProjectedRewards(amount, delegatorAPR, yearDuration).String())
+	assert.Equal(t, "7397260273972602739", ProjectedRewards(amount, delegatorAPR, 30*24*time.Hour).String())
+}
+
+func TestSimulated_StakingYield(t *testing.T) {
+	b, node, _ := setupSimulatedNode(t)
+	defer b.Close()
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		_, err := b.Commit()
+		assert.Nil(t, err)
+	}
+	yield, err := node.StakingYield(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, yield.APR.Sign())
+	assert.Equal(t, 1, yield.Inflation.Cmp(FloatToBigInt(0.0199, 18)))
+	assert.Equal(t, BondedRatio(yield.TotalBonded, yield.TotalSupply), yield.BondedRatio)
+
+	// the next block is minted with the predicted inflation
+	_, err = b.Commit()
+	assert.Nil(t, err)
+	next, err := node.StakingYield(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, yield.Height+1, next.Height)
+	assert.Equal(t, yield.NextInflation, next.Inflation)
+	previous, err := node.StakingYield(WithBlock(ctx, BlockAtHeight(yield.Height)))
+	assert.Nil(t, err)
+	assert.Equal(t, yield, previous)
+
+	validators, err := node.ValidatorSMCAddresses(ctx)
+	assert.Nil(t, err)
+	apr, err := node.ValidatorAPR(ctx, validators[0])
+	assert.Nil(t, err)
+	// the commission of the validator is 10%
+	assert.Equal(t, DelegatorAPR(next.APR, FloatToBigInt(0.1, 18)), apr)
+	rewards, err := node.ProjectRewards(ctx, validators[0], FloatToBigInt(1000, 18), yearDuration)
+	assert.Nil(t, err)
+	assert.Equal(t, mulDec(FloatToBigInt(1000, 18), apr), rewards)
+}
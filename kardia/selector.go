@@ -0,0 +1,86 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package kardia
+package kardia
+
+import (
+	"context"
+
+	"github.com/kardiachain/go-kardia/lib/common"
+)
+
+// BlockSelector selects the block whose state a read is executed against.
+// The zero value selects the latest block.
+type BlockSelector struct {
+	height  uint64
+	hash    *common.Hash
+	pending bool
+}
+
+var (
+	LatestBlock  = BlockSelector{}
+	PendingBlock = BlockSelector{pending: true}
+)
+
+// BlockAtHeight selects the block at height, 0 selects the latest block
+func BlockAtHeight(height uint64) BlockSelector {
+	return BlockSelector{height: height}
+}
+
+// BlockAtHash selects the block with the given hash
+func BlockAtHash(hash common.Hash) BlockSelector {
+	return BlockSelector{hash: &hash}
+}
+
+// IsLatest returns true if the selector targets the latest block
+func (b BlockSelector) IsLatest() bool {
+	return b.height == 0 && b.hash == nil && !b.pending
+}
+
+// arg returns the block argument of account_* and kai_kardiaCall requests
+func (b BlockSelector) arg() interface{} {
+	switch {
+	case b.hash != nil:
+		return b.hash.Hex()
+	case b.pending:
+		return "pending"
+	case b.height != 0:
+		return b.height
+	default:
+		return "latest"
+	}
+}
+
+type blockSelectorKey struct{}
+
+// WithBlock returns a copy of ctx which makes every read of IAddress, IStaking, IValidator,
+// IDelegator and Token performed with it execute against the selected block state.
+//
+//	ctx := WithBlock(context.Background(), BlockAtHeight(1000000))
+//	validator, err := node.ValidatorInfo(ctx, validatorSMCAddress)
+func WithBlock(ctx context.Context, block BlockSelector) context.Context {
+	return context.WithValue(ctx, blockSelectorKey{}, block)
+}
+
+// BlockFromContext returns the block selected by WithBlock, LatestBlock otherwise
+func BlockFromContext(ctx context.Context) BlockSelector {
+	if block, ok := ctx.Value(blockSelectorKey{}).(BlockSelector); ok {
+		return block
+	}
+	return LatestBlock
+}
@@ -0,0 +1,126 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package kardia
+package kardia
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kardiachain/go-kardia"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/rpc"
+	"github.com/kardiachain/go-kardia/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockStateAPI records the block each account_* and kai_kardiaCall request is executed against
+type mockStateAPI struct {
+	blocks []rpc.BlockHeightOrHash
+}
+
+func (api *mockStateAPI) Balance(address common.Address, block rpc.BlockHeightOrHash) string {
+	api.blocks = append(api.blocks, block)
+	return "1"
+}
+
+func (api *mockStateAPI) GetCode(address common.Address, block rpc.BlockHeightOrHash) common.Bytes {
+	api.blocks = append(api.blocks, block)
+	return common.Bytes{0x60}
+}
+
+func (api *mockStateAPI) Nonce(address string) uint64 {
+	return 1
+}
+
+func (api *mockStateAPI) NonceAtHeight(address common.Address, block rpc.BlockHeightOrHash) uint64 {
+	api.blocks = append(api.blocks, block)
+	return 2
+}
+
+func (api *mockStateAPI) KardiaCall(args types.CallArgsJSON, block rpc.BlockHeightOrHash) common.Bytes {
+	api.blocks = append(api.blocks, block)
+	return common.FromHex(args.Data)
+}
+
+func (api *mockStateAPI) last() *rpc.BlockHeightOrHash {
+	return &api.blocks[len(api.blocks)-1]
+}
+
+func TestSelector_WithBlock(t *testing.T) {
+	api := &mockStateAPI{}
+	node, err := setupMockNodeInstance(map[string]interface{}{"account": api, "kai": api})
+	assert.Nil(t, err)
+	ctx := context.Background()
+	addr := common.HexToAddress(test1SmcAddr)
+
+	_, err = node.Balance(ctx, addr.Hex())
+	assert.Nil(t, err)
+	height, ok := api.last().Height()
+	assert.True(t, ok)
+	assert.Equal(t, rpc.LatestBlockHeight, height)
+
+	_, err = node.Balance(WithBlock(ctx, BlockAtHeight(100)), addr.Hex())
+	assert.Nil(t, err)
+	height, _ = api.last().Height()
+	assert.Equal(t, rpc.BlockHeight(100), height)
+
+	blockHash := common.HexToHash("0x1234")
+	_, err = node.Code(WithBlock(ctx, BlockAtHash(blockHash)), addr.Hex())
+	assert.Nil(t, err)
+	hash, ok := api.last().Hash()
+	assert.True(t, ok)
+	assert.Equal(t, blockHash, hash)
+
+	_, err = node.PendingCodeAt(ctx, addr)
+	assert.Nil(t, err)
+	height, _ = api.last().Height()
+	assert.Equal(t, rpc.PendingBlockHeight, height)
+
+	nonce, err := node.NonceAt(ctx, addr.Hex())
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), nonce)
+	nonce, err = node.NonceAt(WithBlock(ctx, BlockAtHeight(5)), addr.Hex())
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2), nonce)
+}
+
+func TestSelector_CallContract(t *testing.T) {
+	api := &mockStateAPI{}
+	node, err := setupMockNodeInstance(map[string]interface{}{"account": api, "kai": api})
+	assert.Nil(t, err)
+	ctx := context.Background()
+	addr := common.HexToAddress(test1SmcAddr)
+
+	res, err := node.CallContract(ctx, kardia.CallMsg{To: &addr, Data: []byte{0x01}}, 42)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0x01}, res)
+	height, _ := api.last().Height()
+	assert.Equal(t, rpc.BlockHeight(42), height)
+
+	// 0 falls back to the block selected by ctx
+	_, err = node.CallContract(WithBlock(ctx, BlockAtHeight(7)), kardia.CallMsg{To: &addr}, 0)
+	assert.Nil(t, err)
+	height, _ = api.last().Height()
+	assert.Equal(t, rpc.BlockHeight(7), height)
+
+	_, err = node.CodeAt(ctx, addr, 0)
+	assert.Nil(t, err)
+	height, _ = api.last().Height()
+	assert.Equal(t, rpc.LatestBlockHeight, height)
+}
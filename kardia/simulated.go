@@ -0,0 +1,45 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package kardia
+package kardia
+
+import (
+	"go.uber.org/zap"
+
+	"github.com/kardiachain/go-kaiclient/kardia/simulated"
+)
+
+// NewSimulatedNode returns a Node connected in process to the in-memory chain b,
+// to test contracts, staking and subscriptions without network access.
+//
+//	b, _ := simulated.NewBackend(simulated.Config{Alloc: alloc, AutoCommit: true})
+//	node, _ := NewSimulatedNode(b, zap.L())
+func NewSimulatedNode(b *simulated.Backend, lgr *zap.Logger) (Node, error) {
+	if lgr == nil {
+		lgr = zap.L()
+	}
+	node := &node{
+		client: b.Client(),
+		url:    "simulated",
+		lgr:    lgr,
+	}
+	if err := node.setupSMC(); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
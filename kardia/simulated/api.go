@@ -0,0 +1,514 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package simulated
+package simulated
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/kardiachain/go-kardia/configs"
+	"github.com/kardiachain/go-kardia/kvm"
+	"github.com/kardiachain/go-kardia/lib/abi"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/rlp"
+	kai "github.com/kardiachain/go-kardia/mainchain"
+	"github.com/kardiachain/go-kardia/mainchain/filters"
+	"github.com/kardiachain/go-kardia/mainchain/tx_pool"
+	"github.com/kardiachain/go-kardia/rpc"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+// revertError is returned by kai_kardiaCall and kai_estimateGas when the execution is reverted,
+// with the revert data as error data
+type revertError struct {
+	error
+	data string
+}
+
+func newRevertError(result *kvm.ExecutionResult) *revertError {
+	err := errors.New("execution reverted")
+	if reason, errUnpack := abi.UnpackRevert(result.Revert()); errUnpack == nil {
+		err = fmt.Errorf("execution reverted: %v", reason)
+	}
+	return &revertError{error: err, data: common.Encode(result.Revert())}
+}
+
+func (e *revertError) ErrorCode() int {
+	return 3
+}
+
+func (e *revertError) ErrorData() interface{} {
+	return e.data
+}
+
+// toMessage converts call arguments into a KVM message, a nil To creates a contract
+func toMessage(args types.CallArgsJSON) types.Message {
+	from := common.HexToAddress(args.From)
+	if from.Equal(common.Address{}) {
+		from = configs.GenesisDeployerAddr
+	}
+	var to *common.Address
+	if args.To != nil {
+		address := common.HexToAddress(*args.To)
+		to = &address
+	}
+	gas := args.Gas
+	if gas == 0 {
+		gas = math.MaxUint64 / 2
+	}
+	value, gasPrice := args.Value, args.GasPrice
+	if value == nil {
+		value = new(big.Int)
+	}
+	if gasPrice == nil {
+		gasPrice = new(big.Int)
+	}
+	return types.NewMessage(from, to, 0, value, gas, gasPrice, common.FromHex(args.Data), false)
+}
+
+// kaiAPI serves the kai namespace
+type kaiAPI struct {
+	b *Backend
+}
+
+func (api *kaiAPI) BlockNumber() uint64 {
+	api.b.mu.Lock()
+	defer api.b.mu.Unlock()
+	return api.b.head().block.Height()
+}
+
+func (api *kaiAPI) GetBlockByNumber(blockHeight rpc.BlockHeight) *kai.BlockJSON {
+	api.b.mu.Lock()
+	defer api.b.mu.Unlock()
+	if block := api.b.blockAt(blockHeight); block != nil {
+		return kai.NewBlockJSON(block.block, block.info)
+	}
+	return nil
+}
+
+func (api *kaiAPI) GetBlockByHash(blockHash rpc.BlockHeightOrHash) *kai.BlockJSON {
+	api.b.mu.Lock()
+	defer api.b.mu.Unlock()
+	if block := api.b.blockAtHeightOrHash(blockHash); block != nil {
+		return kai.NewBlockJSON(block.block, block.info)
+	}
+	return nil
+}
+
+func (api *kaiAPI) GetBlockHeaderByNumber(blockHeight rpc.BlockHeight) *kai.BlockHeaderJSON {
+	api.b.mu.Lock()
+	defer api.b.mu.Unlock()
+	if block := api.b.blockAt(blockHeight); block != nil {
+		return kai.NewBlockHeaderJSON(block.block.Header(), block.info)
+	}
+	return nil
+}
+
+func (api *kaiAPI) GetBlockHeaderByHash(blockHash rpc.BlockHeightOrHash) *kai.BlockHeaderJSON {
+	api.b.mu.Lock()
+	defer api.b.mu.Unlock()
+	if block := api.b.blockAtHeightOrHash(blockHash); block != nil {
+		return kai.NewBlockHeaderJSON(block.block.Header(), block.info)
+	}
+	return nil
+}
+
+func (api *kaiAPI) GetValidatorSet(blockHeight rpc.BlockHeight) (*types.ValidatorSet, error) {
+	api.b.mu.Lock()
+	defer api.b.mu.Unlock()
+	block := api.b.blockAt(blockHeight)
+	if block == nil {
+		return nil, ErrBlockNotFound
+	}
+	return block.validators, nil
+}
+
+func (api *kaiAPI) GetCommit(blockHeight rpc.BlockHeight) *types.Commit {
+	api.b.mu.Lock()
+	defer api.b.mu.Unlock()
+	if block := api.b.blockAt(blockHeight); block != nil {
+		return block.commit
+	}
+	return nil
+}
+
+func (api *kaiAPI) GasPrice() string {
+	return api.b.cfg.GasPrice.String()
+}
+
+func (api *kaiAPI) KardiaCall(args types.CallArgsJSON, blockHeightOrHash rpc.BlockHeightOrHash) (common.Bytes, error) {
+	api.b.mu.Lock()
+	defer api.b.mu.Unlock()
+	statedb, header, err := api.b.stateAt(blockHeightOrHash)
+	if err != nil {
+		return nil, err
+	}
+	result, err := api.b.call(toMessage(args), statedb, header)
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Revert()) > 0 {
+		return nil, newRevertError(result)
+	}
+	return result.Return(), result.Err
+}
+
+// EstimateGas binary searches the lowest gas limit args executes with, like the node does
+func (api *kaiAPI) EstimateGas(args types.CallArgsJSON, blockHeightOrHash rpc.BlockHeightOrHash) (uint64, error) {
+	api.b.mu.Lock()
+	defer api.b.mu.Unlock()
+	statedb, header, err := api.b.stateAt(blockHeightOrHash)
+	if err != nil {
+		return 0, err
+	}
+	lo, hi := configs.TxGas-1, header.GasLimit
+	if args.Gas >= configs.TxGas {
+		hi = args.Gas
+	}
+	cap := hi
+	executable := func(gas uint64) (bool, *kvm.ExecutionResult, error) {
+		args.Gas = gas
+		result, err := api.b.call(toMessage(args), statedb.Copy(), header)
+		if err != nil {
+			if errors.Is(err, tx_pool.ErrIntrinsicGas) {
+				return true, nil, nil
+			}
+			return true, nil, err
+		}
+		return result.Failed(), result, nil
+	}
+	for lo+1 < hi {
+		mid := (hi + lo) / 2
+		failed, _, err := executable(mid)
+		if err != nil {
+			return 0, err
+		}
+		if failed {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	if hi == cap {
+		failed, result, err := executable(hi)
+		if err != nil {
+			return 0, err
+		}
+		if failed {
+			if result != nil && result.Err != kvm.ErrOutOfGas {
+				if len(result.Revert()) > 0 {
+					return 0, newRevertError(result)
+				}
+				return 0, result.Err
+			}
+			return 0, fmt.Errorf("gas required exceeds allowance (%d)", cap)
+		}
+	}
+	if hi > configs.GasLimitCap {
+		hi = configs.GasLimitCap
+	}
+	return hi, nil
+}
+
+func (api *kaiAPI) GetLogs(crit filters.FilterCriteria) ([]*types.Log, error) {
+	api.b.mu.Lock()
+	defer api.b.mu.Unlock()
+	var blocks []*simBlock
+	if crit.BlockHash != nil {
+		height, ok := api.b.hashes[*crit.BlockHash]
+		if !ok {
+			return nil, ErrBlockNotFound
+		}
+		blocks = api.b.blocks[height : height+1]
+	} else {
+		head := api.b.head().block.Height()
+		from, to := crit.FromBlock, crit.ToBlock
+		if from > head {
+			from = head
+		}
+		if to > head {
+			to = head
+		}
+		if from > to {
+			return []*types.Log{}, nil
+		}
+		blocks = api.b.blocks[from : to+1]
+	}
+	logs := []*types.Log{}
+	for _, block := range blocks {
+		for _, receipt := range block.info.Receipts {
+			for _, l := range receipt.Logs {
+				if matchLog(l, crit) {
+					logs = append(logs, l)
+				}
+			}
+		}
+	}
+	return logs, nil
+}
+
+// NewHeads notifies the header of every mined block
+func (api *kaiAPI) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	rpcSub := notifier.CreateSubscription()
+	headers := make(chan *types.Header)
+	headersSub := api.b.headFeed.Subscribe(headers)
+	go func() {
+		defer headersSub.Unsubscribe()
+		for {
+			select {
+			case header := <-headers:
+				_ = notifier.Notify(rpcSub.ID, header)
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
+
+// Logs notifies every mined log matching the addresses and topics of crit
+func (api *kaiAPI) Logs(ctx context.Context, crit filters.FilterCriteria) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	rpcSub := notifier.CreateSubscription()
+	logsCh := make(chan []*types.Log)
+	logsSub := api.b.logsFeed.Subscribe(logsCh)
+	go func() {
+		defer logsSub.Unsubscribe()
+		for {
+			select {
+			case logs := <-logsCh:
+				for _, l := range logs {
+					if matchLog(l, crit) {
+						_ = notifier.Notify(rpcSub.ID, l)
+					}
+				}
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
+
+// matchLog reports whether l matches the addresses and topics of crit, block range is not checked
+func matchLog(l *types.Log, crit filters.FilterCriteria) bool {
+	if len(crit.Addresses) > 0 {
+		found := false
+		for _, address := range crit.Addresses {
+			if address.Equal(l.Address) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(crit.Topics) > len(l.Topics) {
+		return false
+	}
+	for i, alternatives := range crit.Topics {
+		if len(alternatives) == 0 {
+			continue
+		}
+		found := false
+		for _, topic := range alternatives {
+			if topic.Equal(l.Topics[i]) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// accountAPI serves the account namespace
+type accountAPI struct {
+	b *Backend
+}
+
+func (api *accountAPI) Balance(address common.Address, blockHeightOrHash rpc.BlockHeightOrHash) (string, error) {
+	api.b.mu.Lock()
+	defer api.b.mu.Unlock()
+	statedb, _, err := api.b.stateAt(blockHeightOrHash)
+	if err != nil {
+		return "", err
+	}
+	return statedb.GetBalance(address).String(), nil
+}
+
+// Nonce returns the nonce of address including pending transactions
+func (api *accountAPI) Nonce(address common.Address) uint64 {
+	api.b.mu.Lock()
+	defer api.b.mu.Unlock()
+	return api.b.pendingState.GetNonce(address)
+}
+
+func (api *accountAPI) NonceAtHeight(address common.Address, blockHeightOrHash rpc.BlockHeightOrHash) (uint64, error) {
+	api.b.mu.Lock()
+	defer api.b.mu.Unlock()
+	statedb, _, err := api.b.stateAt(blockHeightOrHash)
+	if err != nil {
+		return 0, err
+	}
+	return statedb.GetNonce(address), nil
+}
+
+func (api *accountAPI) GetCode(address common.Address, blockHeightOrHash rpc.BlockHeightOrHash) (common.Bytes, error) {
+	api.b.mu.Lock()
+	defer api.b.mu.Unlock()
+	statedb, _, err := api.b.stateAt(blockHeightOrHash)
+	if err != nil {
+		return nil, err
+	}
+	return statedb.GetCode(address), nil
+}
+
+func (api *accountAPI) GetStorageAt(address common.Address, key string, blockHeightOrHash rpc.BlockHeightOrHash) (common.Bytes, error) {
+	api.b.mu.Lock()
+	defer api.b.mu.Unlock()
+	statedb, _, err := api.b.stateAt(blockHeightOrHash)
+	if err != nil {
+		return nil, err
+	}
+	value := statedb.GetState(address, common.HexToHash(key))
+	return value[:], nil
+}
+
+// txAPI serves the tx namespace
+type txAPI struct {
+	b *Backend
+}
+
+func (api *txAPI) SendRawTransaction(encodedTx string) (string, error) {
+	tx := new(types.Transaction)
+	if err := rlp.DecodeBytes(common.FromHex(encodedTx), tx); err != nil {
+		return common.Hash{}.Hex(), err
+	}
+	return tx.Hash().Hex(), api.b.SendTransaction(tx)
+}
+
+// GetTransaction returns the mined transaction hash, nil if unknown or pending
+func (api *txAPI) GetTransaction(hash common.Hash) *kai.PublicTransaction {
+	api.b.mu.Lock()
+	defer api.b.mu.Unlock()
+	lookup, ok := api.b.txs[hash]
+	if !ok {
+		return nil
+	}
+	block := api.b.blocks[lookup.height].block
+	tx := kai.NewPublicTransaction(block.Transactions()[lookup.index], block.Hash(), block.Height(), uint64(lookup.index))
+	tx.Time = block.Header().Time
+	return tx
+}
+
+// GetTransactionReceipt returns the receipt of the mined transaction hash, nil if unknown or pending
+func (api *txAPI) GetTransactionReceipt(hash common.Hash) *kai.PublicReceipt {
+	api.b.mu.Lock()
+	defer api.b.mu.Unlock()
+	lookup, ok := api.b.txs[hash]
+	if !ok {
+		return nil
+	}
+	block := api.b.blocks[lookup.height]
+	tx := block.block.Transactions()[lookup.index]
+	receipt := block.info.Receipts[lookup.index]
+	from, _ := types.Sender(types.HomesteadSigner{}, tx)
+	publicReceipt := &kai.PublicReceipt{
+		BlockHash:         block.block.Hash().Hex(),
+		BlockHeight:       block.block.Height(),
+		TransactionHash:   tx.Hash().Hex(),
+		TransactionIndex:  uint64(lookup.index),
+		From:              from.Hex(),
+		To:                "0x",
+		GasUsed:           receipt.GasUsed,
+		CumulativeGasUsed: receipt.CumulativeGasUsed,
+		ContractAddress:   "0x",
+		Logs:              publicLogs(receipt.Logs),
+		LogsBloom:         receipt.Bloom,
+		Status:            uint(receipt.Status),
+	}
+	if tx.To() != nil {
+		publicReceipt.To = tx.To().Hex()
+	}
+	if receipt.ContractAddress != (common.Address{}) {
+		publicReceipt.ContractAddress = receipt.ContractAddress.Hex()
+	}
+	return publicReceipt
+}
+
+func publicLogs(logs []*types.Log) []kai.Log {
+	result := make([]kai.Log, 0, len(logs))
+	for _, l := range logs {
+		topics := make([]string, 0, len(l.Topics))
+		for _, topic := range l.Topics {
+			topics = append(topics, topic.Hex())
+		}
+		result = append(result, kai.Log{
+			Address:     l.Address.Hex(),
+			Topics:      topics,
+			Data:        common.Encode(l.Data),
+			BlockHeight: l.BlockHeight,
+			TxHash:      l.TxHash.Hex(),
+			TxIndex:     l.TxIndex,
+			BlockHash:   l.BlockHash.Hex(),
+			Index:       l.Index,
+			Removed:     l.Removed,
+		})
+	}
+	return result
+}
+
+// nodeAPI serves the node namespace
+type nodeAPI struct {
+	b *Backend
+}
+
+type nodeInfo struct {
+	ID         string `json:"id"`
+	ListenAddr string `json:"listen_addr"`
+	Network    string `json:"network"`
+	Version    string `json:"version"`
+	Moniker    string `json:"moniker"`
+}
+
+func (api *nodeAPI) NodeInfo() *nodeInfo {
+	return &nodeInfo{ID: "simulated", ListenAddr: "inproc", Network: api.b.cfg.ChainID, Moniker: "simulated"}
+}
+
+func (api *nodeAPI) Peers() []interface{} {
+	return []interface{}{}
+}
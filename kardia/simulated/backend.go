@@ -0,0 +1,541 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package simulated provides an in-memory KardiaChain served over an in-process RPC server,
+// to test code using the kardia package without network access.
+package simulated
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kardiachain/go-kardia/configs"
+	"github.com/kardiachain/go-kardia/kai/kaidb"
+	"github.com/kardiachain/go-kardia/kai/kaidb/memorydb"
+	"github.com/kardiachain/go-kardia/kai/state"
+	"github.com/kardiachain/go-kardia/kvm"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/event"
+	"github.com/kardiachain/go-kardia/lib/log"
+	kai "github.com/kardiachain/go-kardia/mainchain"
+	"github.com/kardiachain/go-kardia/mainchain/blockchain"
+	"github.com/kardiachain/go-kardia/mainchain/genesis"
+	vm "github.com/kardiachain/go-kardia/mainchain/kvm"
+	"github.com/kardiachain/go-kardia/mainchain/staking"
+	stypes "github.com/kardiachain/go-kardia/mainchain/staking/types"
+	"github.com/kardiachain/go-kardia/rpc"
+	"github.com/kardiachain/go-kardia/types"
+)
+
+const (
+	// DefaultChainID is the network reported by node_nodeInfo if Config.ChainID is empty
+	DefaultChainID = "kai-simulated"
+	// DefaultBlockTime is the time between two blocks if Config.BlockTime is zero
+	DefaultBlockTime = 5 * time.Second
+)
+
+var (
+	ErrBlockNotFound = errors.New("block not found")
+	ErrKnownTx       = errors.New("already known")
+
+	// loadContracts registers the genesis contracts shipped with go-kardia, the staking
+	// and validator contracts are deployed from them
+	loadContracts sync.Once
+)
+
+// Config describes the genesis of a simulated chain
+type Config struct {
+	// ChainID is the network reported by node_nodeInfo, DefaultChainID by default
+	ChainID string
+	// Alloc funds accounts and deploys contracts at genesis
+	Alloc genesis.GenesisAlloc
+	// Validators are created in the staking contract at genesis, those with StartWithGenesis
+	// sign every block. Validators and delegators must be funded in Alloc.
+	Validators []*genesis.GenesisValidator
+	// Timestamp is the genesis block time, now by default
+	Timestamp time.Time
+	// BlockTime is the time between two blocks, DefaultBlockTime by default
+	BlockTime time.Duration
+	// GasLimit is the block gas limit, configs.GenesisGasLimit by default
+	GasLimit uint64
+	// GasPrice is returned by kai_gasPrice, configs.GasPriceCap (1 OXY) by default
+	GasPrice *big.Int
+	// AutoCommit mines a new block for every accepted transaction
+	AutoCommit bool
+}
+
+// simBlock is a mined block with its state root and execution results
+type simBlock struct {
+	block      *types.Block
+	root       common.Hash
+	info       *types.BlockInfo
+	validators *types.ValidatorSet
+	// commit is signed by the validators when the next block is prepared
+	commit *types.Commit
+}
+
+type txLookup struct {
+	height uint64
+	index  int
+}
+
+// Backend is an in-memory chain hosting the staking contract at configs.DefaultStakingContractAddress.
+// Transactions are executed by the KVM into a pending block as soon as they are sent,
+// the pending block is mined by Commit, or right away with Config.AutoCommit.
+type Backend struct {
+	cfg     Config
+	logger  log.Logger
+	db      kaidb.Database
+	stateDB state.Database
+	staking *staking.StakingSmcUtil
+	server  *rpc.Server
+
+	mu     sync.Mutex
+	blocks []*simBlock
+	hashes map[common.Hash]uint64
+	txs    map[common.Hash]txLookup
+	// offline validators do not sign the blocks
+	offline map[common.Address]bool
+
+	pendingHeader   *types.Header
+	pendingState    *state.StateDB
+	pendingTxs      []*types.Transaction
+	pendingReceipts types.Receipts
+	pendingGasPool  *types.GasPool
+	pendingGasUsed  uint64
+	pendingReward   *big.Int
+
+	headFeed event.Feed
+	logsFeed event.Feed
+}
+
+// NewBackend creates the genesis block of a simulated chain and serves it
+// through the kai, account, tx and node RPC namespaces.
+func NewBackend(cfg Config) (*Backend, error) {
+	if cfg.ChainID == "" {
+		cfg.ChainID = DefaultChainID
+	}
+	if cfg.BlockTime == 0 {
+		cfg.BlockTime = DefaultBlockTime
+	}
+	if cfg.GasLimit == 0 {
+		cfg.GasLimit = configs.GenesisGasLimit
+	}
+	if cfg.GasPrice == nil {
+		cfg.GasPrice = new(big.Int).Set(configs.GasPriceCap)
+	}
+	if cfg.Timestamp.IsZero() {
+		cfg.Timestamp = time.Unix(time.Now().Unix(), 0).UTC()
+	}
+	logger := log.New()
+	logger.SetHandler(log.DiscardHandler())
+	loadContracts.Do(func() {
+		configs.AddDefaultContract()
+		configs.AddDefaultStakingContractAddress()
+	})
+	stakingUtil, err := staking.NewSmcStakingUtil()
+	if err != nil {
+		return nil, err
+	}
+	b := &Backend{
+		cfg:     cfg,
+		logger:  logger,
+		db:      memorydb.New(),
+		staking: stakingUtil,
+		hashes:  make(map[common.Hash]uint64),
+		txs:     make(map[common.Hash]txLookup),
+		offline: make(map[common.Address]bool),
+	}
+	b.stateDB = state.NewDatabase(b.db)
+
+	alloc := make(genesis.GenesisAlloc, len(cfg.Alloc))
+	for address, account := range cfg.Alloc {
+		alloc[address] = account
+	}
+	// genesis reads exactly 32 bytes of the validator name, pad it like the contract does
+	validators := make([]*genesis.GenesisValidator, 0, len(cfg.Validators))
+	for _, val := range cfg.Validators {
+		padded := *val
+		if len(padded.Name) < 32 {
+			padded.Name += strings.Repeat("\x00", 32-len(padded.Name))
+		}
+		validators = append(validators, &padded)
+	}
+	g := &genesis.Genesis{
+		ChainID:    cfg.ChainID,
+		Timestamp:  cfg.Timestamp,
+		GasLimit:   cfg.GasLimit,
+		Alloc:      alloc,
+		Validators: validators,
+	}
+	block, root, err := toGenesisBlock(g, logger, b.db, stakingUtil)
+	if err != nil {
+		return nil, err
+	}
+	genesisBlock := &simBlock{block: block, root: root, info: &types.BlockInfo{Rewards: big.NewInt(0)}}
+	if genesisBlock.validators, err = b.validatorSet(root, block.Header()); err != nil {
+		return nil, err
+	}
+	b.blocks = append(b.blocks, genesisBlock)
+	b.hashes[block.Hash()] = 0
+	if err := b.resetPending(); err != nil {
+		return nil, err
+	}
+	if err := b.preparePending(); err != nil {
+		return nil, err
+	}
+
+	b.server = rpc.NewServer()
+	services := map[string]interface{}{
+		"kai":     &kaiAPI{b},
+		"account": &accountAPI{b},
+		"tx":      &txAPI{b},
+		"node":    &nodeAPI{b},
+	}
+	for name, service := range services {
+		if err := b.server.RegisterName(name, service); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// toGenesisBlock returns the genesis block and state root, ToBlock panics on invalid validators
+func toGenesisBlock(g *genesis.Genesis, logger log.Logger, db kaidb.Database, stakingUtil *staking.StakingSmcUtil) (block *types.Block, root common.Hash, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("invalid genesis: %v", r)
+		}
+	}()
+	block, root = g.ToBlock(logger, db, stakingUtil)
+	return block, root, nil
+}
+
+// Client returns a new RPC client connected in process to the simulated chain
+func (b *Backend) Client() *rpc.Client {
+	return rpc.DialInProc(b.server)
+}
+
+// Server returns the RPC server of the simulated chain, e.g to serve it over websocket
+func (b *Backend) Server() *rpc.Server {
+	return b.server
+}
+
+// Close stops the RPC server and closes every connected client
+func (b *Backend) Close() {
+	b.server.Stop()
+}
+
+// Commit mines the pending block and returns it
+func (b *Backend) Commit() (*types.Block, error) {
+	b.mu.Lock()
+	mined, err := b.commit()
+	b.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	b.notify(mined)
+	return mined.block, nil
+}
+
+// AdjustTime moves the time of the pending block, and so of every later block, by d.
+// Pending transactions are executed again at the new time.
+func (b *Backend) AdjustTime(d time.Duration) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	txs := b.pendingTxs
+	pendingTime := b.pendingHeader.Time.Add(d)
+	if err := b.resetPending(); err != nil {
+		return err
+	}
+	b.pendingHeader.Time = pendingTime
+	// mint and finalize again with the adjusted time
+	if err := b.preparePending(); err != nil {
+		return err
+	}
+	for _, tx := range txs {
+		if err := b.applyTx(tx); err != nil {
+			b.logger.Warn("Pending transaction dropped", "hash", tx.Hash().Hex(), "err", err)
+		}
+	}
+	return nil
+}
+
+// SetOffline stops or resumes the signing of the validator with the given signer address.
+// Blocks mined while a validator is offline are committed without its signature,
+// the staking contract counts them as missed.
+func (b *Backend) SetOffline(validator common.Address, offline bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if offline {
+		b.offline[validator] = true
+	} else {
+		delete(b.offline, validator)
+	}
+}
+
+// SendTransaction executes tx in the pending block. A tx the pool would queue or reject,
+// e.g. with a nonce gap or not enough funds, is rejected with the error of the execution.
+func (b *Backend) SendTransaction(tx *types.Transaction) error {
+	if tx.GasPrice().Cmp(configs.GasPriceCap) < 0 {
+		return kai.ErrNotEnoughGasPrice
+	}
+	if tx.Gas() > configs.GasLimitCap {
+		return kai.ErrExceedGasLimit
+	}
+	b.mu.Lock()
+	if _, known := b.txs[tx.Hash()]; known {
+		b.mu.Unlock()
+		return ErrKnownTx
+	}
+	for _, pending := range b.pendingTxs {
+		if pending.Hash() == tx.Hash() {
+			b.mu.Unlock()
+			return ErrKnownTx
+		}
+	}
+	if err := b.applyTx(tx); err != nil {
+		b.mu.Unlock()
+		return err
+	}
+	var mined *simBlock
+	if b.cfg.AutoCommit {
+		var err error
+		if mined, err = b.commit(); err != nil {
+			b.mu.Unlock()
+			return err
+		}
+	}
+	b.mu.Unlock()
+	if mined != nil {
+		b.notify(mined)
+	}
+	return nil
+}
+
+// applyTx executes tx in the pending block, the pending state is untouched on error
+func (b *Backend) applyTx(tx *types.Transaction) error {
+	snapshot := b.pendingState.Snapshot()
+	b.pendingState.Prepare(tx.Hash(), common.Hash{}, len(b.pendingTxs))
+	receipt, _, err := blockchain.ApplyTransaction(b.logger, b, b.pendingGasPool, b.pendingState, b.pendingHeader,
+		tx, &b.pendingGasUsed, kvm.Config{})
+	if err != nil {
+		b.pendingState.RevertToSnapshot(snapshot)
+		return err
+	}
+	b.pendingTxs = append(b.pendingTxs, tx)
+	b.pendingReceipts = append(b.pendingReceipts, receipt)
+	return nil
+}
+
+// commit mines the pending block and starts the next one
+func (b *Backend) commit() (*simBlock, error) {
+	header := b.pendingHeader
+	root, err := b.pendingState.Commit(true)
+	if err != nil {
+		return nil, err
+	}
+	if err := b.stateDB.TrieDB().Commit(root, false); err != nil {
+		return nil, err
+	}
+	block := types.NewBlock(header, b.pendingTxs, b.head().commit, nil)
+	for _, receipt := range b.pendingReceipts {
+		for _, l := range receipt.Logs {
+			l.BlockHash = block.Hash()
+			l.BlockHeight = block.Height()
+		}
+	}
+	mined := &simBlock{
+		block: block,
+		root:  root,
+		info: &types.BlockInfo{
+			GasUsed:  b.pendingGasUsed,
+			Rewards:  b.pendingReward,
+			Receipts: b.pendingReceipts,
+			Bloom:    types.CreateBloom(b.pendingReceipts),
+		},
+	}
+	if mined.validators, err = b.validatorSet(root, block.Header()); err != nil {
+		return nil, err
+	}
+	b.blocks = append(b.blocks, mined)
+	b.hashes[block.Hash()] = block.Height()
+	for i, tx := range block.Transactions() {
+		b.txs[tx.Hash()] = txLookup{height: block.Height(), index: i}
+	}
+	if err := b.resetPending(); err != nil {
+		return nil, err
+	}
+	if err := b.preparePending(); err != nil {
+		return nil, err
+	}
+	return mined, nil
+}
+
+// notify sends the new head and its logs to subscribers, it must be called without the lock
+func (b *Backend) notify(mined *simBlock) {
+	b.headFeed.Send(mined.block.Header())
+	var logs []*types.Log
+	for _, receipt := range mined.info.Receipts {
+		logs = append(logs, receipt.Logs...)
+	}
+	if len(logs) > 0 {
+		b.logsFeed.Send(logs)
+	}
+}
+
+// resetPending starts an empty pending block on top of the head
+func (b *Backend) resetPending() error {
+	parent := b.head()
+	statedb, err := state.New(b.logger, parent.root, b.stateDB)
+	if err != nil {
+		return err
+	}
+	var proposer common.Address
+	if parent.validators != nil {
+		proposer = parent.validators.GetProposer().Address
+	}
+	b.pendingHeader = &types.Header{
+		Height:          parent.block.Height() + 1,
+		Time:            parent.block.Time().Add(b.cfg.BlockTime),
+		GasLimit:        b.cfg.GasLimit,
+		LastBlockID:     types.BlockID{Hash: parent.block.Hash()},
+		ProposerAddress: proposer,
+		AppHash:         parent.root,
+	}
+	if parent.validators != nil {
+		b.pendingHeader.ValidatorsHash = parent.validators.Hash()
+		b.pendingHeader.NextValidatorsHash = parent.validators.Hash()
+	}
+	b.pendingState = statedb
+	b.pendingTxs = nil
+	b.pendingReceipts = nil
+	b.pendingGasPool = new(types.GasPool).AddGas(b.cfg.GasLimit)
+	b.pendingGasUsed = 0
+	b.pendingReward = big.NewInt(0)
+	return nil
+}
+
+// preparePending mints the block reward and finalizes the previous block, signed by every online validator,
+// before any transaction like the block operations of a node
+func (b *Backend) preparePending() error {
+	parent := b.head()
+	if parent.validators == nil {
+		return nil
+	}
+	reward, err := b.staking.Mint(b.pendingState, b.pendingHeader, b, kvm.Config{})
+	if err != nil {
+		return err
+	}
+	if reward != nil {
+		b.pendingReward = reward
+	}
+	var lastCommit stypes.LastCommitInfo
+	signatures := make([]types.CommitSig, 0, parent.validators.Size())
+	for _, val := range parent.validators.Validators {
+		signed := !b.offline[val.Address]
+		lastCommit.Votes = append(lastCommit.Votes, stypes.VoteInfo{
+			Address:         val.Address,
+			VotingPower:     big.NewInt(val.VotingPower),
+			SignedLastBlock: signed,
+		})
+		if signed {
+			// the simulated validators have no consensus key, the signature is a placeholder
+			signatures = append(signatures, types.NewCommitSigForBlock([]byte{}, val.Address, b.pendingHeader.Time))
+		} else {
+			signatures = append(signatures, types.NewCommitSigAbsent())
+		}
+	}
+	parent.commit = types.NewCommit(parent.block.Height(), 0, types.BlockID{Hash: parent.block.Hash()}, signatures)
+	return b.staking.FinalizeCommit(b.pendingState, b.pendingHeader, b, kvm.Config{}, lastCommit)
+}
+
+// validatorSet returns the validator set stored in the staking contract at root, nil if empty
+func (b *Backend) validatorSet(root common.Hash, header *types.Header) (*types.ValidatorSet, error) {
+	statedb, err := state.New(b.logger, root, b.stateDB)
+	if err != nil {
+		return nil, err
+	}
+	vals, err := b.staking.ApplyAndReturnValidatorSets(statedb, header, b, kvm.Config{})
+	if err != nil || len(vals) == 0 {
+		return nil, err
+	}
+	return types.NewValidatorSet(vals), nil
+}
+
+func (b *Backend) head() *simBlock {
+	return b.blocks[len(b.blocks)-1]
+}
+
+// GetHeader implements vm.ChainContext, it is called with the lock held
+func (b *Backend) GetHeader(hash common.Hash, height uint64) *types.Header {
+	if height >= uint64(len(b.blocks)) || b.blocks[height].block.Hash() != hash {
+		return nil
+	}
+	return b.blocks[height].block.Header()
+}
+
+// blockAt returns the mined block selected by height, latest if height is latest or pending
+func (b *Backend) blockAt(height rpc.BlockHeight) *simBlock {
+	if height == rpc.LatestBlockHeight || height == rpc.PendingBlockHeight {
+		return b.head()
+	}
+	if height.Uint64() >= uint64(len(b.blocks)) {
+		return nil
+	}
+	return b.blocks[height.Uint64()]
+}
+
+// blockAtHeightOrHash returns the mined block selected by blockHeightOrHash
+func (b *Backend) blockAtHeightOrHash(blockHeightOrHash rpc.BlockHeightOrHash) *simBlock {
+	if height, ok := blockHeightOrHash.Height(); ok {
+		return b.blockAt(height)
+	}
+	if hash, ok := blockHeightOrHash.Hash(); ok {
+		if height, found := b.hashes[hash]; found {
+			return b.blocks[height]
+		}
+	}
+	return nil
+}
+
+// stateAt returns a copy of the state selected by blockHeightOrHash and the header it is read with
+func (b *Backend) stateAt(blockHeightOrHash rpc.BlockHeightOrHash) (*state.StateDB, *types.Header, error) {
+	if height, ok := blockHeightOrHash.Height(); ok && height == rpc.PendingBlockHeight {
+		return b.pendingState.Copy(), b.pendingHeader, nil
+	}
+	block := b.blockAtHeightOrHash(blockHeightOrHash)
+	if block == nil {
+		return nil, nil, ErrBlockNotFound
+	}
+	statedb, err := state.New(b.logger, block.root, b.stateDB)
+	if err != nil {
+		return nil, nil, err
+	}
+	return statedb, block.block.Header(), nil
+}
+
+// call executes msg against statedb without committing it
+func (b *Backend) call(msg types.Message, statedb *state.StateDB, header *types.Header) (*kvm.ExecutionResult, error) {
+	vmenv := kvm.NewKVM(vm.NewKVMContext(msg, header, b), statedb, kvm.Config{})
+	gp := new(types.GasPool).AddGas(common.MaxUint64)
+	return blockchain.ApplyMessage(vmenv, msg, gp)
+}
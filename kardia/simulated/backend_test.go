@@ -0,0 +1,159 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package simulated
+package simulated
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/kardiachain/go-kardia/configs"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/crypto"
+	kai "github.com/kardiachain/go-kardia/mainchain"
+	"github.com/kardiachain/go-kardia/mainchain/genesis"
+	"github.com/kardiachain/go-kardia/types"
+	"github.com/stretchr/testify/assert"
+)
+
+const testKey = "63e16b5334e76d63ee94f35bd2a81c721ebbbb27e81620be6fc1c448c767eed9"
+
+var oneKAI = new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+
+func setupTestBackend(t *testing.T, autoCommit bool) (*Backend, common.Address) {
+	key, err := crypto.HexToECDSA(testKey)
+	assert.Nil(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	b, err := NewBackend(Config{
+		Alloc:      genesis.GenesisAlloc{from: {Balance: new(big.Int).Mul(big.NewInt(20000000), oneKAI)}},
+		AutoCommit: autoCommit,
+		Validators: []*genesis.GenesisValidator{{
+			Name:             "val1",
+			Address:          from.Hex(),
+			CommissionRate:   "100000000000000000",
+			MaxRate:          "250000000000000000",
+			MaxChangeRate:    "50000000000000000",
+			SelfDelegate:     "13000000000000000000000000",
+			StartWithGenesis: true,
+		}},
+	})
+	assert.Nil(t, err)
+	return b, from
+}
+
+func signedTransfer(t *testing.T, nonce uint64, to common.Address, value *big.Int) *types.Transaction {
+	key, err := crypto.HexToECDSA(testKey)
+	assert.Nil(t, err)
+	tx, err := types.SignTx(types.HomesteadSigner{}, types.NewTransaction(nonce, to, value, configs.TxGas, configs.GasPriceCap, nil), key)
+	assert.Nil(t, err)
+	return tx
+}
+
+func TestBackend_TransferAndCommit(t *testing.T) {
+	b, from := setupTestBackend(t, false)
+	defer b.Close()
+	client := b.Client()
+	ctx := context.Background()
+	to := common.HexToAddress("0x1234")
+
+	tx := signedTransfer(t, 0, to, oneKAI)
+	assert.Nil(t, b.SendTransaction(tx))
+	assert.Equal(t, ErrKnownTx, b.SendTransaction(tx))
+	assert.EqualError(t, b.SendTransaction(signedTransfer(t, 0, to, big.NewInt(1))), "nonce too low")
+
+	// pending until mined
+	var nonce uint64
+	assert.Nil(t, client.CallContext(ctx, &nonce, "account_nonce", from))
+	assert.Equal(t, uint64(1), nonce)
+	var balance string
+	assert.Nil(t, client.CallContext(ctx, &balance, "account_balance", to, "latest"))
+	assert.Equal(t, "0", balance)
+	var receipt *kai.PublicReceipt
+	assert.Nil(t, client.CallContext(ctx, &receipt, "tx_getTransactionReceipt", tx.Hash()))
+	assert.Nil(t, receipt)
+
+	block, err := b.Commit()
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), block.Height())
+	assert.Nil(t, client.CallContext(ctx, &balance, "account_balance", to, "latest"))
+	assert.Equal(t, oneKAI.String(), balance)
+	assert.Nil(t, client.CallContext(ctx, &receipt, "tx_getTransactionReceipt", tx.Hash()))
+	assert.Equal(t, block.Hash().Hex(), receipt.BlockHash)
+	assert.Equal(t, uint(types.ReceiptStatusSuccessful), receipt.Status)
+
+	// state of older blocks is kept
+	assert.Nil(t, client.CallContext(ctx, &balance, "account_balance", to, 0))
+	assert.Equal(t, "0", balance)
+}
+
+func TestBackend_GenesisValidators(t *testing.T) {
+	b, from := setupTestBackend(t, true)
+	defer b.Close()
+	client := b.Client()
+	ctx := context.Background()
+
+	var code common.Bytes
+	assert.Nil(t, client.CallContext(ctx, &code, "account_getCode", configs.DefaultStakingContractAddress, "latest"))
+	assert.NotEmpty(t, code)
+	var valSet *types.ValidatorSet
+	assert.Nil(t, client.CallContext(ctx, &valSet, "kai_getValidatorSet", 0))
+	assert.Equal(t, 1, len(valSet.Validators))
+	assert.Equal(t, from, valSet.Validators[0].Address)
+
+	// blocks are proposed by the validator and spaced by the block time
+	assert.Nil(t, b.SendTransaction(signedTransfer(t, 0, from, big.NewInt(0))))
+	assert.Nil(t, b.AdjustTime(time.Minute))
+	_, err := b.Commit()
+	assert.Nil(t, err)
+	var first, second *kai.BlockHeaderJSON
+	assert.Nil(t, client.CallContext(ctx, &first, "kai_getBlockHeaderByNumber", 1))
+	assert.Nil(t, client.CallContext(ctx, &second, "kai_getBlockHeaderByNumber", 2))
+	assert.Equal(t, from, first.ProposerAddress)
+	assert.Equal(t, DefaultBlockTime+time.Minute, second.Time.Sub(first.Time))
+}
+
+func TestBackend_OfflineValidator(t *testing.T) {
+	b, from := setupTestBackend(t, false)
+	defer b.Close()
+	client := b.Client()
+	ctx := context.Background()
+
+	b.SetOffline(from, true)
+	_, err := b.Commit()
+	assert.Nil(t, err)
+	b.SetOffline(from, false)
+	_, err = b.Commit()
+	assert.Nil(t, err)
+
+	var commit *types.Commit
+	assert.Nil(t, client.CallContext(ctx, &commit, "kai_getCommit", 0))
+	assert.Equal(t, types.BlockIDFlagCommit, commit.Signatures[0].BlockIDFlag)
+	assert.Equal(t, from, commit.Signatures[0].ValidatorAddress)
+	assert.Nil(t, client.CallContext(ctx, &commit, "kai_getCommit", 1))
+	assert.Equal(t, uint64(1), commit.Height)
+	assert.True(t, commit.Signatures[0].Absent())
+	assert.Nil(t, client.CallContext(ctx, &commit, "kai_getCommit", 2))
+	assert.False(t, commit.Signatures[0].Absent())
+
+	// the commit of a block is included in the next one
+	var block *kai.BlockJSON
+	assert.Nil(t, client.CallContext(ctx, &block, "kai_getBlockByNumber", 2))
+	assert.Equal(t, b.blocks[1].commit.Hash(), block.CommitHash)
+}
@@ -0,0 +1,165 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package kardia
+package kardia
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kardiachain/go-kardia"
+	"github.com/kardiachain/go-kardia/lib/abi/bind"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/mainchain/genesis"
+	"github.com/kardiachain/go-kardia/types"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/kardiachain/go-kaiclient/kardia/simulated"
+)
+
+// setupSimulatedNode returns a node on a simulated chain mining every transaction, where the
+// test account holds 20M KAI and runs the only validator with a 13M KAI self delegation
+func setupSimulatedNode(t *testing.T) (*simulated.Backend, Node, *bind.TransactOpts) {
+	_, privateKey, err := setupTestAccount()
+	assert.Nil(t, err)
+	auth := NewKeyedTransactor(privateKey)
+	b, err := simulated.NewBackend(simulatedTestConfig(auth))
+	assert.Nil(t, err)
+	node, err := NewSimulatedNode(b, zap.NewNop())
+	assert.Nil(t, err)
+	return b, node, auth
+}
+
+// simulatedTestConfig returns the simulated chain config of setupSimulatedNode
+func simulatedTestConfig(auth *bind.TransactOpts) simulated.Config {
+	return simulated.Config{
+		Alloc: genesis.GenesisAlloc{auth.From: {Balance: FloatToBigInt(20000000, 18)}},
+		Validators: []*genesis.GenesisValidator{{
+			Name:             "simulated",
+			Address:          auth.From.Hex(),
+			CommissionRate:   "100000000000000000",
+			MaxRate:          "250000000000000000",
+			MaxChangeRate:    "50000000000000000",
+			SelfDelegate:     FloatToBigInt(13000000, 18).String(),
+			StartWithGenesis: true,
+		}},
+		AutoCommit: true,
+	}
+}
+
+func TestSimulated_DeployKRC20(t *testing.T) {
+	b, node, auth := setupSimulatedNode(t)
+	defer b.Close()
+	ctx := context.Background()
+
+	address, txHash, err := node.DeployKRC20(auth)
+	assert.Nil(t, err)
+	receipt, err := node.WaitMined(ctx, txHash.Hex())
+	assert.Nil(t, err)
+	assert.Equal(t, address.Hex(), receipt.ContractAddress)
+
+	token, err := NewToken(node, address.Hex())
+	assert.Nil(t, err)
+	info, err := token.KRC20Info(ctx)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, info.Symbol)
+	balance, err := token.HolderBalance(ctx, auth.From.Hex())
+	assert.Nil(t, err)
+	assert.Equal(t, 1, balance.Sign())
+	assert.True(t, balance.Cmp(info.TotalSupply) <= 0)
+}
+
+func TestSimulated_BoundContractLogs(t *testing.T) {
+	b, node, auth := setupSimulatedNode(t)
+	defer b.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	address, _, err := node.DeployKRC20(auth)
+	assert.Nil(t, err)
+	krc20ABI, err := KRC20ABI()
+	assert.Nil(t, err)
+	contract := NewBoundContract(node, krc20ABI, address)
+
+	logs := make(chan types.Log, 1)
+	sub, err := node.SubscribeFilterLogs(ctx, kardia.FilterQuery{
+		Addresses: []common.Address{address},
+		Topics:    [][]common.Hash{{krc20ABI.Events["Transfer"].ID}},
+	}, logs)
+	assert.Nil(t, err)
+	defer sub.Unsubscribe()
+
+	receiver := common.HexToAddress("0x0000000000000000000000000000000000c0ffee")
+	tx, err := contract.Transact(auth, "transfer", receiver, big.NewInt(1000))
+	assert.Nil(t, err)
+
+	select {
+	case log := <-logs:
+		assert.Equal(t, tx.Hash(), log.TxHash)
+		assert.Equal(t, common.BytesToHash(receiver.Bytes()), log.Topics[2])
+	case <-ctx.Done():
+		t.Fatal("transfer log not received")
+	}
+	filtered, err := node.FilterLogs(ctx, kardia.FilterQuery{
+		FromBlock: 1,
+		Addresses: []common.Address{address},
+		Topics:    [][]common.Hash{{krc20ABI.Events["Transfer"].ID}, {}, {common.BytesToHash(receiver.Bytes())}},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(filtered))
+
+	token, err := NewToken(node, address.Hex())
+	assert.Nil(t, err)
+	_, err = token.KRC20Info(ctx)
+	assert.Nil(t, err)
+	balance, err := token.HolderBalance(ctx, receiver.Hex())
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(1000), balance)
+}
+
+func TestSimulated_Validators(t *testing.T) {
+	b, node, auth := setupSimulatedNode(t)
+	defer b.Close()
+	ctx := context.Background()
+
+	validators, err := node.Validators(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(validators))
+	assert.Equal(t, auth.From, validators[0].Signer)
+	assert.Equal(t, "simulated", strings.TrimRight(string(validators[0].Name[:]), "\x00"))
+	validator, err := node.Validator(ctx, validators[0].SMCAddress.Hex())
+	assert.Nil(t, err)
+	assert.Equal(t, validators[0], validator)
+
+	staked, err := node.TotalStakedAmount(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, FloatToBigInt(13000000, 18), staked)
+
+	// every block mints rewards for the validator
+	for i := 0; i < 3; i++ {
+		_, err := b.Commit()
+		assert.Nil(t, err)
+	}
+	rewards, err := node.DelegationRewards(ctx, validators[0].SMCAddress.Hex(), auth.From.Hex())
+	assert.Nil(t, err)
+	assert.Equal(t, 1, rewards.Sign())
+}
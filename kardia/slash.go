@@ -0,0 +1,301 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package kardia
+package kardia
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/kardiachain/go-kardia/lib/abi/bind"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"go.uber.org/zap"
+)
+
+const (
+	SlashUnknown SlashReason = iota
+	SlashDowntime
+	SlashDoubleSign
+)
+
+// SlashReason is the _reason of the Slashed event of the validator contract
+type SlashReason uint8
+
+func (r SlashReason) String() string {
+	switch r {
+	case SlashDowntime:
+		return "downtime"
+	case SlashDoubleSign:
+		return "double sign"
+	default:
+		return "unknown"
+	}
+}
+
+// ISlash reads the slash history of the validators
+type ISlash interface {
+	// SlashHistory returns the slashes of the validator up to the block selected by ctx, with the KAI
+	// lost by each of its delegators
+	SlashHistory(ctx context.Context, validatorSMCAddress common.Address) ([]*Slash, error)
+}
+
+// Slash is a slash event of a validator
+type Slash struct {
+	// Period is the validator period ended by the slash
+	Period uint64
+	// Fraction is the share of the validator tokens burnt in 1e18 units. Unbonding entries
+	// slashed with the validator are not in the fraction.
+	Fraction *big.Int
+	Height   uint64
+	Time     time.Time
+	Reason   SlashReason
+	Losses   []*SlashLoss
+}
+
+// Loss returns the KAI lost by all the delegators
+func (s *Slash) Loss() *big.Int {
+	total := new(big.Int)
+	for _, loss := range s.Losses {
+		total.Add(total, loss.Amount)
+	}
+	return total
+}
+
+// SlashLoss is the KAI a delegator lost in a slash
+type SlashLoss struct {
+	Delegator common.Address
+	// Stake is the stake of the delegator before the slash
+	Stake *big.Int
+	// Amount is the KAI lost from the stake and from the unbonding entries
+	Amount *big.Int
+}
+
+func (n *node) SlashHistory(ctx context.Context, validatorSMCAddress common.Address) ([]*Slash, error) {
+	lgr := n.lgr.With(zap.String("method", "SlashHistory"))
+	header, err := n.selectedHeader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if header.Height != 0 {
+		ctx = WithBlock(ctx, BlockAtHeight(header.Height))
+	}
+	size, err := n.getSlashEventsSize(ctx, validatorSMCAddress.Hex())
+	if err != nil {
+		return nil, err
+	}
+	calls := make([]SMCCallArgs, size)
+	for i := range calls {
+		payload, err := n.validatorSMC.Abi.Pack("slashEvents", big.NewInt(int64(i)))
+		if err != nil {
+			return nil, err
+		}
+		calls[i] = ConstructCallArgs(validatorSMCAddress.Hex(), payload)
+	}
+	results, err := n.BatchKardiaCall(ctx, calls)
+	if err != nil {
+		lgr.Error("Slash events batch call error: ", zap.Error(err))
+		return nil, err
+	}
+	slashes := make([]*Slash, size)
+	for i, result := range results {
+		if result.Err != nil {
+			return nil, result.Err
+		}
+		var event struct {
+			Period   *big.Int
+			Fraction *big.Int
+			Height   *big.Int
+		}
+		if err := n.validatorSMC.Abi.UnpackIntoInterface(&event, "slashEvents", result.Data); err != nil {
+			lgr.Error("Error unpacking slash event", zap.Error(err))
+			return nil, err
+		}
+		slash := &Slash{
+			Period:   event.Period.Uint64(),
+			Fraction: event.Fraction,
+			Height:   event.Height.Uint64(),
+		}
+		if err := n.loadSlash(ctx, validatorSMCAddress, slash); err != nil {
+			return nil, err
+		}
+		slashes[i] = slash
+	}
+	return slashes, nil
+}
+
+// loadSlash joins the slash with its block, its reason and the losses of the delegators
+func (n *node) loadSlash(ctx context.Context, validatorSMCAddress common.Address, slash *Slash) error {
+	// the validator contract only slashes infractions of past blocks, there is no block before genesis
+	if slash.Height == 0 {
+		return ErrInvalidSlashHeight
+	}
+	header, err := n.BlockHeaderByNumber(ctx, slash.Height)
+	if err != nil {
+		return err
+	}
+	slash.Time = header.Time
+	// the state before the slash is selected by hash, height 0 would select the latest block
+	before := WithBlock(ctx, BlockAtHash(header.LastBlockID.Hash))
+	if slash.Reason, err = n.slashReason(ctx, before, validatorSMCAddress, slash); err != nil {
+		return err
+	}
+	slash.Losses, err = n.slashLosses(ctx, before, validatorSMCAddress, slash)
+	return err
+}
+
+// slashReason reads the reason from the Slashed event. The event is emitted by the block finalization,
+// outside of any transaction, nodes which do not return it classify the slash with the params slash fractions
+// of the block before, selected by before.
+func (n *node) slashReason(ctx, before context.Context, validatorSMCAddress common.Address, slash *Slash) (SlashReason, error) {
+	c, err := NewValidatorContract(n, validatorSMCAddress)
+	if err != nil {
+		return SlashUnknown, err
+	}
+	it, err := c.FilterSlashed(&bind.FilterOpts{Start: slash.Height, End: &slash.Height, Context: ctx})
+	if err != nil {
+		return SlashUnknown, err
+	}
+	defer it.Close()
+	if it.Next() {
+		return SlashReason(it.Event.Reason.Uint64()), nil
+	}
+	if err := it.Error(); err != nil {
+		return SlashUnknown, err
+	}
+	params, err := n.ChainParams(before)
+	if err != nil {
+		return SlashUnknown, err
+	}
+	return ClassifySlash(slash.Fraction, params), nil
+}
+
+// slashLosses values the shares of the delegations of the block before the slash, selected by before, with the
+// validator tokens of both blocks. The slash is applied when the slash block is finalized before its transactions,
+// which do not move the value of a share.
+func (n *node) slashLosses(ctx, before context.Context, validatorSMCAddress common.Address, slash *Slash) ([]*SlashLoss, error) {
+	payload, err := n.validatorSMC.Abi.Pack("getDelegations")
+	if err != nil {
+		return nil, err
+	}
+	res, err := n.KardiaCall(before, ConstructCallArgs(validatorSMCAddress.Hex(), payload))
+	if err != nil {
+		return nil, err
+	}
+	var delegations struct {
+		Addresses []common.Address
+		Shares    []*big.Int
+	}
+	if err := n.validatorSMC.Abi.UnpackIntoInterface(&delegations, "getDelegations", res); err != nil {
+		return nil, err
+	}
+
+	info, err := n.validatorSMC.Abi.Pack("inforValidator")
+	if err != nil {
+		return nil, err
+	}
+	beforeCalls := []SMCCallArgs{ConstructCallArgs(validatorSMCAddress.Hex(), info)}
+	afterCalls := []SMCCallArgs{ConstructCallArgs(validatorSMCAddress.Hex(), info)}
+	for _, delegator := range delegations.Addresses {
+		entries, err := n.validatorSMC.Abi.Pack("getUBDEntries", delegator)
+		if err != nil {
+			return nil, err
+		}
+		beforeCalls = append(beforeCalls, ConstructCallArgs(validatorSMCAddress.Hex(), entries))
+		afterCalls = append(afterCalls, ConstructCallArgs(validatorSMCAddress.Hex(), entries))
+	}
+	beforeResults, err := n.BatchKardiaCall(before, beforeCalls)
+	if err != nil {
+		return nil, err
+	}
+	afterResults, err := n.BatchKardiaCall(WithBlock(ctx, BlockAtHeight(slash.Height)), afterCalls)
+	if err != nil {
+		return nil, err
+	}
+	unpack := func(result *CallResult, method string, out interface{}) error {
+		if result.Err != nil {
+			return result.Err
+		}
+		if err := n.validatorSMC.Abi.UnpackIntoInterface(out, method, result.Data); err != nil {
+			return fmt.Errorf("unpack %s: %w", method, err)
+		}
+		return nil
+	}
+	var validatorBefore, validatorAfter Validator
+	if err := unpack(beforeResults[0], "inforValidator", &validatorBefore); err != nil {
+		return nil, err
+	}
+	if err := unpack(afterResults[0], "inforValidator", &validatorAfter); err != nil {
+		return nil, err
+	}
+
+	losses := make([]*SlashLoss, len(delegations.Addresses))
+	for i, delegator := range delegations.Addresses {
+		var entriesBefore, entriesAfter UnbondedRecord
+		if err := unpack(beforeResults[i+1], "getUBDEntries", &entriesBefore); err != nil {
+			return nil, err
+		}
+		if err := unpack(afterResults[i+1], "getUBDEntries", &entriesAfter); err != nil {
+			return nil, err
+		}
+		stake := tokenFromShare(delegations.Shares[i], &validatorBefore)
+		amount := new(big.Int).Sub(stake, tokenFromShare(delegations.Shares[i], &validatorAfter))
+		if amount.Sign() < 0 {
+			amount.SetInt64(0)
+		}
+		amount.Add(amount, entriesSlashed(&entriesBefore, &entriesAfter))
+		losses[i] = &SlashLoss{Delegator: delegator, Stake: stake, Amount: amount}
+	}
+	return losses, nil
+}
+
+// tokenFromShare returns the tokens of shares of the validator like the validator contract
+func tokenFromShare(shares *big.Int, validator *Validator) *big.Int {
+	if validator.DelegationShares.Sign() == 0 {
+		return new(big.Int)
+	}
+	tokens := new(big.Int).Mul(shares, validator.Tokens)
+	return tokens.Quo(tokens, validator.DelegationShares)
+}
+
+// entriesSlashed returns the decrease of the unbonding entries found in both records. Entries are matched by
+// index, as entries undelegated in the same block share their completion time. New entries are appended, while
+// a withdraw moves the last entry to the index of a withdrawn one, so entries whose completion time changed are skipped.
+func entriesSlashed(before, after *UnbondedRecord) *big.Int {
+	slashed := new(big.Int)
+	for i, balance := range before.Balances {
+		if i >= len(after.Balances) || before.CompletionTimes[i].Cmp(after.CompletionTimes[i]) != 0 {
+			continue
+		}
+		if left := after.Balances[i]; left.Cmp(balance) < 0 {
+			slashed.Add(slashed, new(big.Int).Sub(balance, left))
+		}
+	}
+	return slashed
+}
+
+// ClassifySlash returns the reason of a slash of fraction with the slash fractions of params. The fraction
+// is reduced by the slashed unbonding entries and the rounding of the voting power, it never exceeds
+// the slash fraction of its reason, so only double signs exceed SlashFractionDowntime.
+func ClassifySlash(fraction *big.Int, params *ChainParams) SlashReason {
+	if fraction.Cmp(params.SlashFractionDowntime) > 0 {
+		return SlashDoubleSign
+	}
+	return SlashDowntime
+}
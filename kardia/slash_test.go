@@ -0,0 +1,141 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package kardia
+package kardia
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/kardiachain/go-kardia/lib/abi/bind"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifySlash(t *testing.T) {
+	params := &ChainParams{SlashFractionDowntime: FloatToBigInt(0.0001, 18), SlashFractionDoubleSign: FloatToBigInt(0.25, 18)}
+	assert.Equal(t, SlashDowntime, ClassifySlash(FloatToBigInt(0.0001, 18), params))
+	assert.Equal(t, SlashDowntime, ClassifySlash(FloatToBigInt(0.00009, 18), params))
+	assert.Equal(t, SlashDoubleSign, ClassifySlash(FloatToBigInt(0.2, 18), params))
+	assert.Equal(t, "double sign", SlashDoubleSign.String())
+
+	validator := &Validator{Tokens: big.NewInt(1000), DelegationShares: big.NewInt(3000)}
+	assert.Equal(t, big.NewInt(33), tokenFromShare(big.NewInt(100), validator))
+	assert.Equal(t, big.NewInt(0), tokenFromShare(big.NewInt(100), &Validator{DelegationShares: new(big.Int)}))
+
+	// entries undelegated in the same block share their completion time, entries added in the slash block are not losses
+	before := &UnbondedRecord{
+		Balances:        []*big.Int{big.NewInt(100), big.NewInt(200), big.NewInt(300)},
+		CompletionTimes: []*big.Int{big.NewInt(5), big.NewInt(5), big.NewInt(6)},
+	}
+	after := &UnbondedRecord{
+		Balances:        []*big.Int{big.NewInt(90), big.NewInt(180), big.NewInt(300), big.NewInt(50)},
+		CompletionTimes: []*big.Int{big.NewInt(5), big.NewInt(5), big.NewInt(6), big.NewInt(7)},
+	}
+	assert.Equal(t, big.NewInt(30), entriesSlashed(before, after))
+	// a withdraw moves the last entry to the withdrawn index
+	after = &UnbondedRecord{
+		Balances:        []*big.Int{big.NewInt(270), big.NewInt(180)},
+		CompletionTimes: []*big.Int{big.NewInt(6), big.NewInt(5)},
+	}
+	assert.Equal(t, big.NewInt(20), entriesSlashed(before, after))
+
+	node, err := setupMockNodeInstance(map[string]interface{}{})
+	assert.Nil(t, err)
+	assert.Equal(t, ErrInvalidSlashHeight, node.loadSlash(context.Background(), common.Address{}, &Slash{}))
+}
+
+func TestSimulated_SlashHistory(t *testing.T) {
+	b, node, auth, delegator, validator := setupSimulatedStaking(t)
+	defer b.Close()
+	ctx := context.Background()
+
+	// the validator is jailed once it misses more than 5 blocks of a window of 10
+	staking, err := NewStakingContract(node, node.StakingContact(ctx).ContractAddress)
+	assert.Nil(t, err)
+	paramsAddress, err := staking.Params(&bind.CallOpts{Context: ctx})
+	assert.Nil(t, err)
+	contract, err := NewParamsContract(node, paramsAddress)
+	assert.Nil(t, err)
+	params, err := node.ChainParams(ctx)
+	assert.Nil(t, err)
+	opts := *auth
+	opts.Value = params.ProposalDeposit
+	_, err = contract.AddProposal(&opts, []uint8{uint8(ParamSignedBlockWindow)}, []*big.Int{big.NewInt(10)})
+	assert.Nil(t, err)
+	_, err = contract.AddVote(auth, big.NewInt(0), uint8(VoteYes))
+	assert.Nil(t, err)
+	assert.Nil(t, b.AdjustTime(31*24*time.Hour))
+	_, err = b.Commit()
+	assert.Nil(t, err)
+	_, err = contract.ConfirmProposal(auth, big.NewInt(0))
+	assert.Nil(t, err)
+
+	_, err = node.Delegate(ctx, delegator, validator, FloatToBigInt(60000, 18))
+	assert.Nil(t, err)
+	_, err = node.Undelegate(ctx, delegator, validator, FloatToBigInt(10000, 18))
+	assert.Nil(t, err)
+	validatorContract, err := NewValidatorContract(node, validator)
+	assert.Nil(t, err)
+	stake, err := validatorContract.GetDelegatorStake(&bind.CallOpts{Context: ctx}, delegator.From)
+	assert.Nil(t, err)
+	slashes, err := node.SlashHistory(ctx, validator)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(slashes))
+
+	b.SetOffline(auth.From, true)
+	for i := 0; i < 20; i++ {
+		_, err := b.Commit()
+		assert.Nil(t, err)
+	}
+	info, err := node.ValidatorInfo(ctx, validator.Hex())
+	assert.Nil(t, err)
+	assert.True(t, info.Jailed)
+
+	slashes, err = node.SlashHistory(ctx, validator)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(slashes))
+	slash := slashes[0]
+	assert.Equal(t, SlashDowntime, slash.Reason)
+	assert.Equal(t, 1, slash.Fraction.Sign())
+	assert.Equal(t, -1, slash.Fraction.Cmp(params.SlashFractionDowntime))
+	header, err := node.BlockHeaderByNumber(ctx, slash.Height)
+	assert.Nil(t, err)
+	assert.Equal(t, header.Time, slash.Time)
+	raw, err := node.SlashEvents(ctx, validator.Hex())
+	assert.Nil(t, err)
+	assert.Equal(t, raw[0].Fraction, slash.Fraction.String())
+	assert.Equal(t, raw[0].Period, new(big.Int).SetUint64(slash.Period).String())
+
+	assert.Equal(t, 2, len(slash.Losses))
+	loss := slash.Losses[1]
+	if loss.Delegator != delegator.From {
+		loss = slash.Losses[0]
+	}
+	assert.Equal(t, delegator.From, loss.Delegator)
+	assert.Equal(t, stake, loss.Stake)
+	// the unbonding entry started before the infraction, it is not slashed
+	after, err := validatorContract.GetDelegatorStake(&bind.CallOpts{Context: ctx}, delegator.From)
+	assert.Nil(t, err)
+	assert.Equal(t, new(big.Int).Sub(stake, after), loss.Amount)
+	// the fraction is rounded down
+	assert.True(t, mulDec(stake, slash.Fraction).Cmp(loss.Amount) <= 0)
+	assert.Equal(t, new(big.Int).Add(slash.Losses[0].Amount, slash.Losses[1].Amount), slash.Loss())
+}
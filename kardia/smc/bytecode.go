@@ -0,0 +1,14 @@
+// Code generated by internal/evmasm/gen. DO NOT EDIT.
+
+package smc
+
+const (
+	// KRC721Bytecode deploys the KRC721 test token "Kardia Test NFT" (KTN), which implements KRC721ABI
+	// with the metadata and enumerable extensions, and mint(address to, uint256 tokenId, string uri) open to anyone.
+	KRC721Bytecode = "610fdb80600c6000396000f360003560e01c806306fdde03146100c657806395d89b41146100fa57806318160ddd1461012e57806301ffc9a71461013a57806370a08231146101785780636352211e14610210578063c87b56dd146102925780634f6ccce7146103865780632f745c5914610403578063081812fc146104bb578063e985e9c514610542578063095ea7b314610596578063a22cb4651461076157806323b872dd1461084057806342842e0e14610851578063b88d4fde14610862578063d3fc9864146108775760006000fd5b6020608052600f60a0527f4b61726469612054657374204e4654000000000000000000000000000000000060c05260606080f35b6020608052600360a0527f4b544e000000000000000000000000000000000000000000000000000000000060c05260606080f35b60005460805260206080f35b60043560e01c6101e05263780e9d636101e05114635b5e139f6101e05114176380ac58cd6101e051146301ffc9a76101e05114171760805260206080f35b73ffffffffffffffffffffffffffffffffffffffff6004351661016052610160516101f5577f08c379a0000000000000000000000000000000000000000000000000000000006080526020608452601460a4527f4b52433732313a207a65726f206164647265737300000000000000000000000060c45260646080fd5b60026101605160005260205260406000205460805260206080f35b60016004356000526020526040600020546101605261016051610285577f08c379a0000000000000000000000000000000000000000000000000000000006080526020608452601960a4527f4b52433732313a206e6f6e6578697374656e7420746f6b656e0000000000000060c45260646080fd5b6101605160805260206080f35b60016004356000526020526040600020546102ff577f08c379a0000000000000000000000000000000000000000000000000000000006080526020608452601960a4527f4b52433732313a206e6f6e6578697374656e7420746f6b656e0000000000000060c45260646080fd5b600860043560005260205260406000206102405261024051546102605260206104005261026051610420526000610280525b6102605160206102805102101561037357600161028051016102405101546101e0526101e0516020610280510261044001526001610280510161028052610331565b601f19601f610260510116604001610400f35b600054600435106103e9577f08c379a0000000000000000000000000000000000000000000000000000000006080526020608452601b60a4527f4b52433732313a20696e646578206f7574206f6620626f756e6473000000000060c45260646080fd5b600560043560005260205260406000205460805260206080f35b73ffffffffffffffffffffffffffffffffffffffff600435166101605260026101605160005260205260406000205460243510610492577f08c379a0000000000000000000000000000000000000000000000000000000006080526020608452601b60a4527f4b52433732313a20696e646578206f7574206f6620626f756e6473000000000060c45260646080fd5b600661016051600052602052604060002060243560005260205260406000205460805260206080f35b6001600435600052602052604060002054610528577f08c379a0000000000000000000000000000000000000000000000000000000006080526020608452601960a4527f4b52433732313a206e6f6e6578697374656e7420746f6b656e0000000000000060c45260646080fd5b600360043560005260205260406000205460805260206080f35b600473ffffffffffffffffffffffffffffffffffffffff60043516600052602052604060002073ffffffffffffffffffffffffffffffffffffffff6024351660005260205260406000205460805260206080f35b73ffffffffffffffffffffffffffffffffffffffff6004351661012052602435610140526001610140516000526020526040600020546101605261016051610630577f08c379a0000000000000000000000000000000000000000000000000000000006080526020608452601960a4527f4b52433732313a206e6f6e6578697374656e7420746f6b656e0000000000000060c45260646080fd5b61016051610120511415610696577f08c379a0000000000000000000000000000000000000000000000000000000006080526020608452601960a4527f4b52433732313a20617070726f76616c20746f206f776e65720000000000000060c45260646080fd5b60046101605160005260205260406000203360005260205260406000205461016051331417610717577f08c379a0000000000000000000000000000000000000000000000000000000006080526020608452601e60a4527f4b52433732313a206e6f74206f776e6572206e6f72206f70657261746f72000060c45260646080fd5b610120516003610140516000526020526040600020556101405161012051610160517f8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b92560006080a4005b73ffffffffffffffffffffffffffffffffffffffff6004351661012052336101205114156107e1577f08c379a0000000000000000000000000000000000000000000000000000000006080526020608452601960a4527f4b52433732313a20617070726f766520746f2063616c6c65720000000000000060c45260646080fd5b60243515156101e0526101e0516004336000526020526040600020610120516000526020526040600020556101e05160805261012051337f17307eab39ab6107e8899845ad3d59bd9653f200f220920489ca2b5937696c3160206080a3005b600061020052600061022052610ab9565b600161020052600061022052610ab9565b60016102005260046064350161022052610ab9565b73ffffffffffffffffffffffffffffffffffffffff600435166101205260243561014052610120516108fb577f08c379a0000000000000000000000000000000000000000000000000000000006080526020608452601c60a4527f4b52433732313a206d696e7420746f207a65726f20616464726573730000000060c45260646080fd5b6001610140516000526020526040600020541561096a577f08c379a0000000000000000000000000000000000000000000000000000000006080526020608452601c60a4527f4b52433732313a20746f6b656e20616c7265616479206d696e7465640000000060c45260646080fd5b6000546101805261014051600561018051600052602052604060002055600161018051016000556002610120516000526020526040600020546101805261014051600661012051600052602052604060002061018051600052602052604060002055610180516007610140516000526020526040600020556001610180510160026101205160005260205260406000205561012051600161014051600052602052604060002055600460443501610220526102205135610260526008610140516000526020526040600020610240526102605161024051556000610280525b61026051602061028051021015610a875760206102805102602061022051010135600161028051016102405101556001610280510161028052610a49565b610140516101205160007fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef60006080a4005b73ffffffffffffffffffffffffffffffffffffffff600435166101005273ffffffffffffffffffffffffffffffffffffffff6024351661012052604435610140526001610140516000526020526040600020546101605261016051610b70577f08c379a0000000000000000000000000000000000000000000000000000000006080526020608452601960a4527f4b52433732313a206e6f6e6578697374656e7420746f6b656e0000000000000060c45260646080fd5b610100516101605114610bd5577f08c379a0000000000000000000000000000000000000000000000000000000006080526020608452601960a4527f4b52433732313a2066726f6d206973206e6f74206f776e65720000000000000060c45260646080fd5b61012051610c35577f08c379a0000000000000000000000000000000000000000000000000000000006080526020608452602060a4527f4b52433732313a207472616e7366657220746f207a65726f206164647265737360c45260646080fd5b60046101605160005260205260406000203360005260205260406000205460036101405160005260205260406000205433146101605133141717610ccb577f08c379a0000000000000000000000000000000000000000000000000000000006080526020608452601e60a4527f4b52433732313a206e6f74206f776e6572206e6f7220617070726f766564000060c45260646080fd5b60006003610140516000526020526040600020556001600261010051600052602052604060002054036101a052600761014051600052602052604060002054610180526101a05161018051141515610d7e5760066101005160005260205260406000206101a0516000526020526040600020546101c0526101c0516006610100516000526020526040600020610180516000526020526040600020556101805160076101c0516000526020526040600020555b600060066101005160005260205260406000206101a0516000526020526040600020556101a05160026101005160005260205260406000205560026101205160005260205260406000205461018052610140516006610120516000526020526040600020610180516000526020526040600020556101805160076101405160005260205260406000205560016101805101600261012051600052602052604060002055610120516001610140516000526020526040600020556101405161012051610100517fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef60006080a4610120513b600010610200511615610fd9576000610260526102205115610e94576102205135610260525b7f150b7a0200000000000000000000000000000000000000000000000000000000610400523361040452610100516104245261014051610444526080610464526102605161048452601f19601f610260510116602061022051016104a437600060005260206000601f19601f61026051011660a4016104006000610120515af1610f70577f08c379a0000000000000000000000000000000000000000000000000000000006080526020608452602060a4527f4b52433732313a207472616e7366657220746f206e6f6e20726563656976657260c45260646080fd5b63150b7a0260005160e01c14610fd8577f08c379a0000000000000000000000000000000000000000000000000000000006080526020608452602060a4527f4b52433732313a207472616e7366657220746f206e6f6e20726563656976657260c45260646080fd5b5b00"
+	// KRC1155Bytecode deploys the KRC1155 test token, which implements KRC1155ABI with the metadata URI
+	// extension, and mint(address to, uint256 id, uint256 amount) open to anyone.
+	KRC1155Bytecode = "610ba380600c6000396000f360003560e01c806301ffc9a71461006d578062fdd58e146100a05780634e1273f4146101465780630e89341c1461026e578063e985e9c5146102a2578063a22cb465146102f6578063f242432a146103d55780632eb2c2d614610639578063156e29f6146109965760006000fd5b60043560e01c6101e052630e89341c6101e0511463d9b67a266101e051146301ffc9a76101e05114171760805260206080f35b73ffffffffffffffffffffffffffffffffffffffff60043516610160526101605161011d577f08c379a0000000000000000000000000000000000000000000000000000000006080526020608452601560a4527f4b5243313135353a207a65726f2061646472657373000000000000000000000060c45260646080fd5b600060243560005260205260406000206101605160005260205260406000205460805260206080f35b600460043501610220526004602435016102c0526102205135610260526102c0513561026051146101c9577f08c379a0000000000000000000000000000000000000000000000000000000006080526020608452601860a4527f4b5243313135353a206c656e677468206d69736d61746368000000000000000060c45260646080fd5b60206104005261026051610420526000610280525b6102605161028051101561025f5760006020610280510260206102c051010135600052602052604060002073ffffffffffffffffffffffffffffffffffffffff60206102805102602061022051010135166000526020526040600020546101e0526101e05160206102805102610440015260016102805101610280526101de565b60206102605102604001610400f35b6020608052601a60a0527f697066733a2f2f516d4b5243313135352f7b69647d2e6a736f6e00000000000060c05260606080f35b600173ffffffffffffffffffffffffffffffffffffffff60043516600052602052604060002073ffffffffffffffffffffffffffffffffffffffff6024351660005260205260406000205460805260206080f35b73ffffffffffffffffffffffffffffffffffffffff600435166101205233610120511415610376577f08c379a0000000000000000000000000000000000000000000000000000000006080526020608452601a60a4527f4b5243313135353a20617070726f766520746f2063616c6c657200000000000060c45260646080fd5b60243515156101e0526101e0516001336000526020526040600020610120516000526020526040600020556101e05160805261012051337f17307eab39ab6107e8899845ad3d59bd9653f200f220920489ca2b5937696c3160206080a3005b73ffffffffffffffffffffffffffffffffffffffff600435166101005273ffffffffffffffffffffffffffffffffffffffff60243516610120526101205161046f577f08c379a0000000000000000000000000000000000000000000000000000000006080526020608452601e60a4527f4b5243313135353a207472616e7366657220746f207a65726f2061646472000060c45260646080fd5b600161010051600052602052604060002033600052602052604060002054610100513314176104f0577f08c379a0000000000000000000000000000000000000000000000000000000006080526020608452601f60a4527f4b5243313135353a206e6f74206f776e6572206e6f7220617070726f7665640060c45260646080fd5b604435610140526064356102a05260006101405160005260205260406000206101005160005260205260406000206102405261024051546101e0526102a0516101e0511015610591577f08c379a0000000000000000000000000000000000000000000000000000000006080526020608452601d60a4527f4b5243313135353a20696e73756666696369656e742062616c616e636500000060c45260646080fd5b6102a0516101e0510361024051556000610140516000526020526040600020610120516000526020526040600020610240526102a0516102405154016102405155610140516080526102a05160a0526101205161010051337fc3d58168c5ae7397731d063d5bbf3d657854427343f4c083240f7aacaa2d0f6260406080a47ff23a6e610000000000000000000000000000000000000000000000000000000061030052610a99565b73ffffffffffffffffffffffffffffffffffffffff600435166101005273ffffffffffffffffffffffffffffffffffffffff6024351661012052610120516106d3577f08c379a0000000000000000000000000000000000000000000000000000000006080526020608452601e60a4527f4b5243313135353a207472616e7366657220746f207a65726f2061646472000060c45260646080fd5b60016101005160005260205260406000203360005260205260406000205461010051331417610754577f08c379a0000000000000000000000000000000000000000000000000000000006080526020608452601f60a4527f4b5243313135353a206e6f74206f776e6572206e6f7220617070726f7665640060c45260646080fd5b6004604435016102c0526004606435016102e0526102c05135610260526102e0513561026051146107d7577f08c379a0000000000000000000000000000000000000000000000000000000006080526020608452601860a4527f4b5243313135353a206c656e677468206d69736d61746368000000000000000060c45260646080fd5b6000610280525b610260516102805110156108f8576020610280510260206102c051010135610140526020610280510260206102e0510101356102a05260006101405160005260205260406000206101005160005260205260406000206102405261024051546101e0526102a0516101e05110156108a7577f08c379a0000000000000000000000000000000000000000000000000000000006080526020608452601d60a4527f4b5243313135353a20696e73756666696369656e742062616c616e636500000060c45260646080fd5b6102a0516101e0510361024051556000610140516000526020526040600020610120516000526020526040600020610240526102a051610240515401610240515560016102805101610280526107de565b602061026051026020016101e0526040610400526101e051604001610420526101e0516102c051610440376101e0516102e0516101e05161044001376101205161010051337f4a39dc06d4c0dbc64b70af90fd698a233a518aa5d07e595d983b8c0526c8f7fb60026101e05102604001610400a47fbc197c810000000000000000000000000000000000000000000000000000000061030052610a99565b73ffffffffffffffffffffffffffffffffffffffff6004351661012052602435610140526044356102a05261012051610a21577f08c379a0000000000000000000000000000000000000000000000000000000006080526020608452601d60a4527f4b5243313135353a206d696e7420746f207a65726f206164647265737300000060c45260646080fd5b6000610140516000526020526040600020610120516000526020526040600020610240526102a0516102405154016102405155600061010052610140516080526102a05160a0526101205161010051337fc3d58168c5ae7397731d063d5bbf3d657854427343f4c083240f7aacaa2d0f6260406080a4005b610120513b60001015610ba15761030051610400523361040452610100516104245260443603604461044437600060005260206000366104006000610120515af1610b36577f08c379a0000000000000000000000000000000000000000000000000000000006080526020608452601d60a4527f4b5243313135353a2072656a656374656420627920726563656976657200000060c45260646080fd5b6103005160e01c60005160e01c14610ba0577f08c379a0000000000000000000000000000000000000000000000000000000006080526020608452601d60a4527f4b5243313135353a2072656a656374656420627920726563656976657200000060c45260646080fd5b5b00"
+	// MulticallBytecode deploys an aggregator implementing the tryAggregate method of MulticallABI.
+	MulticallBytecode = "6101be80600c6000396000f360003560e01c8063bce38bd7146100165760006000fd5b60046024350161022052610220513561026052602061040052610260516104205260206102605102610340526000610280525b610260516102805110156101b2576020610280510260206102205101013560206102205101016103205273ffffffffffffffffffffffffffffffffffffffff6103205135166101205260206103205101356103205101610240526103405161044001610360526103405160206102805102610440015261024051356101e0526101e051602061024051016060610360510137600060006101e051606061036051016000610120515af16103805260043515610380511761015b577f08c379a0000000000000000000000000000000000000000000000000000000006080526020608452601660a4527f4d756c746963616c6c3a2063616c6c206661696c65640000000000000000000060c45260646080fd5b610380516103605152604060206103605101523d60406103605101523d6000606061036051013e60003d606061036051010152601f19601f3d01166060016103405101610340526001610280510161028052610049565b61034051604001610400f3"
+)
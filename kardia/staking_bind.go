@@ -0,0 +1,549 @@
+// Code generated by kaibind. DO NOT EDIT.
+
+package kardia
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/kardiachain/go-kardia/lib/abi"
+	"github.com/kardiachain/go-kardia/lib/abi/bind"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/event"
+	"github.com/kardiachain/go-kardia/types"
+
+	"github.com/kardiachain/go-kaiclient/kardia/smc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = abi.JSON
+	_ = bind.NewBoundContract
+	_ = common.Big1
+	_ = event.NewSubscription
+	_ = types.BloomLookup
+)
+
+// StakingContract is a typed binding of the contract, on top of BoundContract.
+type StakingContract struct {
+	*BoundContract
+}
+
+// NewStakingContract binds the contract deployed at address.
+func NewStakingContract(node Node, address common.Address) (*StakingContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(smc.StakingABI))
+	if err != nil {
+		return nil, err
+	}
+	return &StakingContract{NewBoundContract(node, &parsed, address)}, nil
+}
+
+// AllVals calls function allVals(uint256 ) view returns(address).
+func (c *StakingContract) AllVals(opts *bind.CallOpts, arg0 *big.Int) (common.Address, error) {
+	var out common.Address
+	err := c.BoundContract.Call(opts, &out, "allVals", arg0)
+	return out, err
+}
+
+// AllValsLength calls function allValsLength() view returns(uint256).
+func (c *StakingContract) AllValsLength(opts *bind.CallOpts) (*big.Int, error) {
+	var out *big.Int
+	err := c.BoundContract.Call(opts, &out, "allValsLength")
+	return out, err
+}
+
+// BalanceOf calls function balanceOf(address ) view returns(uint256).
+func (c *StakingContract) BalanceOf(opts *bind.CallOpts, arg0 common.Address) (*big.Int, error) {
+	var out *big.Int
+	err := c.BoundContract.Call(opts, &out, "balanceOf", arg0)
+	return out, err
+}
+
+// GetAllValidator calls function getAllValidator() view returns(address[]).
+func (c *StakingContract) GetAllValidator(opts *bind.CallOpts) ([]common.Address, error) {
+	var out []common.Address
+	err := c.BoundContract.Call(opts, &out, "getAllValidator")
+	return out, err
+}
+
+// StakingGetValidatorSetsOutput is the output of StakingContract.GetValidatorSets.
+type StakingGetValidatorSetsOutput struct {
+	ValidatorAddresses []common.Address
+	ValidatorPowers    []*big.Int
+}
+
+// GetValidatorSets calls function getValidatorSets() view returns(address[] validatorAddresses, uint256[] validatorPowers).
+func (c *StakingContract) GetValidatorSets(opts *bind.CallOpts) (*StakingGetValidatorSetsOutput, error) {
+	out := make([]interface{}, 2)
+	if err := c.BoundContract.Call(opts, &out, "getValidatorSets"); err != nil {
+		return nil, err
+	}
+	return &StakingGetValidatorSetsOutput{
+		ValidatorAddresses: *abi.ConvertType(out[0], new([]common.Address)).(*[]common.Address),
+		ValidatorPowers:    *abi.ConvertType(out[1], new([]*big.Int)).(*[]*big.Int),
+	}, nil
+}
+
+// GetValidatorsByDelegator calls function getValidatorsByDelegator(address delAddr) view returns(address[]).
+func (c *StakingContract) GetValidatorsByDelegator(opts *bind.CallOpts, delAddr common.Address) ([]common.Address, error) {
+	var out []common.Address
+	err := c.BoundContract.Call(opts, &out, "getValidatorsByDelegator", delAddr)
+	return out, err
+}
+
+// Minter calls function minter() view returns(address).
+func (c *StakingContract) Minter(opts *bind.CallOpts) (common.Address, error) {
+	var out common.Address
+	err := c.BoundContract.Call(opts, &out, "minter")
+	return out, err
+}
+
+// Owner calls function owner() view returns(address).
+func (c *StakingContract) Owner(opts *bind.CallOpts) (common.Address, error) {
+	var out common.Address
+	err := c.BoundContract.Call(opts, &out, "owner")
+	return out, err
+}
+
+// OwnerOf calls function ownerOf(address ) view returns(address).
+func (c *StakingContract) OwnerOf(opts *bind.CallOpts, arg0 common.Address) (common.Address, error) {
+	var out common.Address
+	err := c.BoundContract.Call(opts, &out, "ownerOf", arg0)
+	return out, err
+}
+
+// Params calls function params() view returns(address).
+func (c *StakingContract) Params(opts *bind.CallOpts) (common.Address, error) {
+	var out common.Address
+	err := c.BoundContract.Call(opts, &out, "params")
+	return out, err
+}
+
+// Proposal calls function proposal() view returns(uint256).
+func (c *StakingContract) Proposal(opts *bind.CallOpts) (*big.Int, error) {
+	var out *big.Int
+	err := c.BoundContract.Call(opts, &out, "proposal")
+	return out, err
+}
+
+// TotalBonded calls function totalBonded() view returns(uint256 totalBonded).
+func (c *StakingContract) TotalBonded(opts *bind.CallOpts) (*big.Int, error) {
+	var out *big.Int
+	err := c.BoundContract.Call(opts, &out, "totalBonded")
+	return out, err
+}
+
+// TotalSlashedToken calls function totalSlashedToken() view returns(uint256).
+func (c *StakingContract) TotalSlashedToken(opts *bind.CallOpts) (*big.Int, error) {
+	var out *big.Int
+	err := c.BoundContract.Call(opts, &out, "totalSlashedToken")
+	return out, err
+}
+
+// TotalSupply calls function totalSupply() view returns(uint256).
+func (c *StakingContract) TotalSupply(opts *bind.CallOpts) (*big.Int, error) {
+	var out *big.Int
+	err := c.BoundContract.Call(opts, &out, "totalSupply")
+	return out, err
+}
+
+// TotalVoted calls function totalVoted() view returns(uint256).
+func (c *StakingContract) TotalVoted(opts *bind.CallOpts) (*big.Int, error) {
+	var out *big.Int
+	err := c.BoundContract.Call(opts, &out, "totalVoted")
+	return out, err
+}
+
+// Treasury calls function treasury() view returns(address).
+func (c *StakingContract) Treasury(opts *bind.CallOpts) (common.Address, error) {
+	var out common.Address
+	err := c.BoundContract.Call(opts, &out, "treasury")
+	return out, err
+}
+
+// ValOf calls function valOf(address ) view returns(address).
+func (c *StakingContract) ValOf(opts *bind.CallOpts, arg0 common.Address) (common.Address, error) {
+	var out common.Address
+	err := c.BoundContract.Call(opts, &out, "valOf", arg0)
+	return out, err
+}
+
+// ValSets calls function valSets(uint256 ) view returns(address).
+func (c *StakingContract) ValSets(opts *bind.CallOpts, arg0 *big.Int) (common.Address, error) {
+	var out common.Address
+	err := c.BoundContract.Call(opts, &out, "valSets", arg0)
+	return out, err
+}
+
+// Vote calls function vote(address ) view returns(bool).
+func (c *StakingContract) Vote(opts *bind.CallOpts, arg0 common.Address) (bool, error) {
+	var out bool
+	err := c.BoundContract.Call(opts, &out, "vote", arg0)
+	return out, err
+}
+
+// AddDelegation sends a transaction calling function addDelegation(address delAddr) returns().
+func (c *StakingContract) AddDelegation(opts *bind.TransactOpts, delAddr common.Address) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "addDelegation", delAddr)
+}
+
+// AddVote sends a transaction calling function addVote() returns().
+func (c *StakingContract) AddVote(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "addVote")
+}
+
+// Burn sends a transaction calling function burn(uint256 amount, uint256 reason) returns().
+func (c *StakingContract) Burn(opts *bind.TransactOpts, amount *big.Int, reason *big.Int) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "burn", amount, reason)
+}
+
+// CreateValidator sends a transaction calling function createValidator(bytes32 name, uint256 rate, uint256 maxRate, uint256 maxChangeRate) payable returns(address val).
+func (c *StakingContract) CreateValidator(opts *bind.TransactOpts, name [32]byte, rate *big.Int, maxRate *big.Int, maxChangeRate *big.Int) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "createValidator", name, rate, maxRate, maxChangeRate)
+}
+
+// Delegate sends a transaction calling function delegate(uint256 amount) returns().
+func (c *StakingContract) Delegate(opts *bind.TransactOpts, amount *big.Int) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "delegate", amount)
+}
+
+// Deposit sends a transaction calling function deposit() payable returns().
+func (c *StakingContract) Deposit(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "deposit")
+}
+
+// DoubleSign sends a transaction calling function doubleSign(address signerAddr, uint256 votingPower, uint256 distributionHeight) returns().
+func (c *StakingContract) DoubleSign(opts *bind.TransactOpts, signerAddr common.Address, votingPower *big.Int, distributionHeight *big.Int) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "doubleSign", signerAddr, votingPower, distributionHeight)
+}
+
+// Finalize sends a transaction calling function finalize(address[] _signers, uint256[] _votingPower, bool[] _signed) returns().
+func (c *StakingContract) Finalize(opts *bind.TransactOpts, signers []common.Address, votingPower []*big.Int, signed []bool) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "finalize", signers, votingPower, signed)
+}
+
+// Mint sends a transaction calling function mint() returns(uint256).
+func (c *StakingContract) Mint(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "mint")
+}
+
+// ProposalMaxProposers sends a transaction calling function proposalMaxProposers(uint256 _maxValidators) returns().
+func (c *StakingContract) ProposalMaxProposers(opts *bind.TransactOpts, maxValidators *big.Int) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "proposalMaxProposers", maxValidators)
+}
+
+// RemoveDelegation sends a transaction calling function removeDelegation(address delAddr) returns().
+func (c *StakingContract) RemoveDelegation(opts *bind.TransactOpts, delAddr common.Address) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "removeDelegation", delAddr)
+}
+
+// RemoveFromSets sends a transaction calling function removeFromSets() returns().
+func (c *StakingContract) RemoveFromSets(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "removeFromSets")
+}
+
+// RenounceOwnership sends a transaction calling function renounceOwnership() returns().
+func (c *StakingContract) RenounceOwnership(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "renounceOwnership")
+}
+
+// SetMaxProposers sends a transaction calling function setMaxProposers(uint256 _maxValidators) returns().
+func (c *StakingContract) SetMaxProposers(opts *bind.TransactOpts, maxValidators *big.Int) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "setMaxProposers", maxValidators)
+}
+
+// SetMintParams sends a transaction calling function setMintParams(uint256 _inflationRateChange, uint256 _goalBonded, uint256 _blocksPerYear, uint256 _inflationMax, uint256 _inflationMin) returns().
+func (c *StakingContract) SetMintParams(opts *bind.TransactOpts, inflationRateChange *big.Int, goalBonded *big.Int, blocksPerYear *big.Int, inflationMax *big.Int, inflationMin *big.Int) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "setMintParams", inflationRateChange, goalBonded, blocksPerYear, inflationMax, inflationMin)
+}
+
+// SetParams sends a transaction calling function setParams(address _params) returns().
+func (c *StakingContract) SetParams(opts *bind.TransactOpts, params common.Address) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "setParams", params)
+}
+
+// SetPreviousProposer sends a transaction calling function setPreviousProposer(address previousProposer) returns().
+func (c *StakingContract) SetPreviousProposer(opts *bind.TransactOpts, previousProposer common.Address) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "setPreviousProposer", previousProposer)
+}
+
+// SetProposalFail sends a transaction calling function setProposalFail() returns().
+func (c *StakingContract) SetProposalFail(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "setProposalFail")
+}
+
+// SetValidatorParams sends a transaction calling function setValidatorParams(uint256 _downtimeJailDuration, uint256 _slashFractionDowntime, uint256 _unbondingTime, uint256 _slashFractionDoubleSign, uint256 _signedBlockWindow, uint256 _minSignedPerWindow, uint256 _minStake, uint256 _minValidatorStake, uint256 _minAmountChangeName, uint256 _minSelfDelegation) returns().
+func (c *StakingContract) SetValidatorParams(opts *bind.TransactOpts, downtimeJailDuration *big.Int, slashFractionDowntime *big.Int, unbondingTime *big.Int, slashFractionDoubleSign *big.Int, signedBlockWindow *big.Int, minSignedPerWindow *big.Int, minStake *big.Int, minValidatorStake *big.Int, minAmountChangeName *big.Int, minSelfDelegation *big.Int) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "setValidatorParams", downtimeJailDuration, slashFractionDowntime, unbondingTime, slashFractionDoubleSign, signedBlockWindow, minSignedPerWindow, minStake, minValidatorStake, minAmountChangeName, minSelfDelegation)
+}
+
+// StartValidator sends a transaction calling function startValidator() returns().
+func (c *StakingContract) StartValidator(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "startValidator")
+}
+
+// SumVotingPowerProposer sends a transaction calling function sumVotingPowerProposer() returns(uint256).
+func (c *StakingContract) SumVotingPowerProposer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "sumVotingPowerProposer")
+}
+
+// TransferOwnership sends a transaction calling function transferOwnership(address newOwner) returns().
+func (c *StakingContract) TransferOwnership(opts *bind.TransactOpts, newOwner common.Address) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "transferOwnership", newOwner)
+}
+
+// Undelegate sends a transaction calling function undelegate(uint256 amount) returns().
+func (c *StakingContract) Undelegate(opts *bind.TransactOpts, amount *big.Int) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "undelegate", amount)
+}
+
+// UpdateSigner sends a transaction calling function updateSigner(address signerAddr) returns().
+func (c *StakingContract) UpdateSigner(opts *bind.TransactOpts, signerAddr common.Address) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "updateSigner", signerAddr)
+}
+
+// WithdrawRewards sends a transaction calling function withdrawRewards(address to, uint256 amount) returns().
+func (c *StakingContract) WithdrawRewards(opts *bind.TransactOpts, to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "withdrawRewards", to, amount)
+}
+
+// StakingBurn is the Burn event of StakingContract.
+type StakingBurn struct {
+	From   common.Address
+	Amount *big.Int
+	Reason *big.Int
+	Raw    types.Log
+}
+
+// StakingBurnIterator iterates over the Burn events returned by StakingContract.FilterBurn.
+type StakingBurnIterator struct {
+	// Event is the event the iterator is at
+	Event *StakingBurn
+
+	*EventIterator
+}
+
+// FilterBurn iterates over past logs of event Burn(address from, uint256 amount, uint256 reason).
+func (c *StakingContract) FilterBurn(opts *bind.FilterOpts) (*StakingBurnIterator, error) {
+	it := new(StakingBurnIterator)
+	var err error
+	it.EventIterator, err = c.BoundContract.FilterEvent(opts, "Burn", func(log types.Log) error {
+		ev, err := c.ParseBurn(log)
+		it.Event = ev
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return it, nil
+}
+
+// WatchBurn sends new logs of event Burn(address from, uint256 amount, uint256 reason) to sink.
+func (c *StakingContract) WatchBurn(opts *bind.WatchOpts, sink chan<- *StakingBurn) (event.Subscription, error) {
+	return c.BoundContract.WatchEvent(opts, "Burn", func(log types.Log, quit <-chan struct{}) error {
+		ev, err := c.ParseBurn(log)
+		if err != nil {
+			return err
+		}
+		select {
+		case sink <- ev:
+		case <-quit:
+		}
+		return nil
+	})
+}
+
+// ParseBurn unpacks a log of event Burn(address from, uint256 amount, uint256 reason).
+func (c *StakingContract) ParseBurn(log types.Log) (*StakingBurn, error) {
+	ev := new(StakingBurn)
+	if err := c.BoundContract.UnpackLog(ev, "Burn", log); err != nil {
+		return nil, err
+	}
+	ev.Raw = log
+	return ev, nil
+}
+
+// StakingCreatedValidator is the CreatedValidator event of StakingContract.
+type StakingCreatedValidator struct {
+	Name                    [32]byte
+	ValAddr                 common.Address
+	CommissionRate          *big.Int
+	CommissionMaxRate       *big.Int
+	CommissionMaxChangeRate *big.Int
+	Raw                     types.Log
+}
+
+// StakingCreatedValidatorIterator iterates over the CreatedValidator events returned by StakingContract.FilterCreatedValidator.
+type StakingCreatedValidatorIterator struct {
+	// Event is the event the iterator is at
+	Event *StakingCreatedValidator
+
+	*EventIterator
+}
+
+// FilterCreatedValidator iterates over past logs of event CreatedValidator(bytes32 _name, address _valAddr, uint256 _commissionRate, uint256 _commissionMaxRate, uint256 _commissionMaxChangeRate).
+func (c *StakingContract) FilterCreatedValidator(opts *bind.FilterOpts) (*StakingCreatedValidatorIterator, error) {
+	it := new(StakingCreatedValidatorIterator)
+	var err error
+	it.EventIterator, err = c.BoundContract.FilterEvent(opts, "CreatedValidator", func(log types.Log) error {
+		ev, err := c.ParseCreatedValidator(log)
+		it.Event = ev
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return it, nil
+}
+
+// WatchCreatedValidator sends new logs of event CreatedValidator(bytes32 _name, address _valAddr, uint256 _commissionRate, uint256 _commissionMaxRate, uint256 _commissionMaxChangeRate) to sink.
+func (c *StakingContract) WatchCreatedValidator(opts *bind.WatchOpts, sink chan<- *StakingCreatedValidator) (event.Subscription, error) {
+	return c.BoundContract.WatchEvent(opts, "CreatedValidator", func(log types.Log, quit <-chan struct{}) error {
+		ev, err := c.ParseCreatedValidator(log)
+		if err != nil {
+			return err
+		}
+		select {
+		case sink <- ev:
+		case <-quit:
+		}
+		return nil
+	})
+}
+
+// ParseCreatedValidator unpacks a log of event CreatedValidator(bytes32 _name, address _valAddr, uint256 _commissionRate, uint256 _commissionMaxRate, uint256 _commissionMaxChangeRate).
+func (c *StakingContract) ParseCreatedValidator(log types.Log) (*StakingCreatedValidator, error) {
+	ev := new(StakingCreatedValidator)
+	if err := c.BoundContract.UnpackLog(ev, "CreatedValidator", log); err != nil {
+		return nil, err
+	}
+	ev.Raw = log
+	return ev, nil
+}
+
+// StakingMint is the Mint event of StakingContract.
+type StakingMint struct {
+	Amount *big.Int
+	Raw    types.Log
+}
+
+// StakingMintIterator iterates over the Mint events returned by StakingContract.FilterMint.
+type StakingMintIterator struct {
+	// Event is the event the iterator is at
+	Event *StakingMint
+
+	*EventIterator
+}
+
+// FilterMint iterates over past logs of event Mint(uint256 amount).
+func (c *StakingContract) FilterMint(opts *bind.FilterOpts) (*StakingMintIterator, error) {
+	it := new(StakingMintIterator)
+	var err error
+	it.EventIterator, err = c.BoundContract.FilterEvent(opts, "Mint", func(log types.Log) error {
+		ev, err := c.ParseMint(log)
+		it.Event = ev
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return it, nil
+}
+
+// WatchMint sends new logs of event Mint(uint256 amount) to sink.
+func (c *StakingContract) WatchMint(opts *bind.WatchOpts, sink chan<- *StakingMint) (event.Subscription, error) {
+	return c.BoundContract.WatchEvent(opts, "Mint", func(log types.Log, quit <-chan struct{}) error {
+		ev, err := c.ParseMint(log)
+		if err != nil {
+			return err
+		}
+		select {
+		case sink <- ev:
+		case <-quit:
+		}
+		return nil
+	})
+}
+
+// ParseMint unpacks a log of event Mint(uint256 amount).
+func (c *StakingContract) ParseMint(log types.Log) (*StakingMint, error) {
+	ev := new(StakingMint)
+	if err := c.BoundContract.UnpackLog(ev, "Mint", log); err != nil {
+		return nil, err
+	}
+	ev.Raw = log
+	return ev, nil
+}
+
+// StakingOwnershipTransferred is the OwnershipTransferred event of StakingContract.
+type StakingOwnershipTransferred struct {
+	PreviousOwner common.Address
+	NewOwner      common.Address
+	Raw           types.Log
+}
+
+// StakingOwnershipTransferredIterator iterates over the OwnershipTransferred events returned by StakingContract.FilterOwnershipTransferred.
+type StakingOwnershipTransferredIterator struct {
+	// Event is the event the iterator is at
+	Event *StakingOwnershipTransferred
+
+	*EventIterator
+}
+
+// FilterOwnershipTransferred iterates over past logs of event OwnershipTransferred(address indexed previousOwner, address indexed newOwner).
+func (c *StakingContract) FilterOwnershipTransferred(opts *bind.FilterOpts, previousOwner []common.Address, newOwner []common.Address) (*StakingOwnershipTransferredIterator, error) {
+	var previousOwnerRule []interface{}
+	for _, item := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, item)
+	}
+	var newOwnerRule []interface{}
+	for _, item := range newOwner {
+		newOwnerRule = append(newOwnerRule, item)
+	}
+	it := new(StakingOwnershipTransferredIterator)
+	var err error
+	it.EventIterator, err = c.BoundContract.FilterEvent(opts, "OwnershipTransferred", func(log types.Log) error {
+		ev, err := c.ParseOwnershipTransferred(log)
+		it.Event = ev
+		return err
+	}, previousOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return it, nil
+}
+
+// WatchOwnershipTransferred sends new logs of event OwnershipTransferred(address indexed previousOwner, address indexed newOwner) to sink.
+func (c *StakingContract) WatchOwnershipTransferred(opts *bind.WatchOpts, sink chan<- *StakingOwnershipTransferred, previousOwner []common.Address, newOwner []common.Address) (event.Subscription, error) {
+	var previousOwnerRule []interface{}
+	for _, item := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, item)
+	}
+	var newOwnerRule []interface{}
+	for _, item := range newOwner {
+		newOwnerRule = append(newOwnerRule, item)
+	}
+	return c.BoundContract.WatchEvent(opts, "OwnershipTransferred", func(log types.Log, quit <-chan struct{}) error {
+		ev, err := c.ParseOwnershipTransferred(log)
+		if err != nil {
+			return err
+		}
+		select {
+		case sink <- ev:
+		case <-quit:
+		}
+		return nil
+	}, previousOwnerRule, newOwnerRule)
+}
+
+// ParseOwnershipTransferred unpacks a log of event OwnershipTransferred(address indexed previousOwner, address indexed newOwner).
+func (c *StakingContract) ParseOwnershipTransferred(log types.Log) (*StakingOwnershipTransferred, error) {
+	ev := new(StakingOwnershipTransferred)
+	if err := c.BoundContract.UnpackLog(ev, "OwnershipTransferred", log); err != nil {
+		return nil, err
+	}
+	ev.Raw = log
+	return ev, nil
+}
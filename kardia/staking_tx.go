@@ -0,0 +1,315 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package kardia
+package kardia
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/kardiachain/go-kardia"
+	"github.com/kardiachain/go-kardia/lib/abi/bind"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"go.uber.org/zap"
+)
+
+// maxUnbondingEntries is the number of pending unbonding entries a delegation can have
+// before the validator contract rejects undelegating with an amount
+const maxUnbondingEntries = 7
+
+// stakingGasMargin is the percentage added to the gas estimation of staking transactions without gas limit.
+// Estimations run against the latest block, while the block of the transaction first allocates
+// the block reward to the validators, which makes their calls more expensive.
+const stakingGasMargin = 20
+
+// IStakingTx sends the staking transactions of opts.From to a validator contract. The transactions are
+// checked against the contract rules and executed through KardiaCall before being sent, then waited for until mined.
+type IStakingTx interface {
+	// Delegate stakes amount KAI to the validator
+	Delegate(ctx context.Context, opts *bind.TransactOpts, validatorSMCAddress common.Address, amount *big.Int) (*StakingReceipt, error)
+	// Undelegate starts unbonding amount KAI from the validator, the whole stake if amount is nil
+	Undelegate(ctx context.Context, opts *bind.TransactOpts, validatorSMCAddress common.Address, amount *big.Int) (*StakingReceipt, error)
+	// Withdraw withdraws the unbonding entries whose completion time has passed
+	Withdraw(ctx context.Context, opts *bind.TransactOpts, validatorSMCAddress common.Address) (*StakingReceipt, error)
+	// WithdrawReward withdraws the delegation rewards, the contract emits no event for it
+	WithdrawReward(ctx context.Context, opts *bind.TransactOpts, validatorSMCAddress common.Address) (*StakingReceipt, error)
+	// WithdrawCommission withdraws the commission of the validator, opts.From must be its signer
+	WithdrawCommission(ctx context.Context, opts *bind.TransactOpts, validatorSMCAddress common.Address) (*StakingReceipt, error)
+}
+
+// StakingReceipt is the receipt of a staking transaction with the validator events it emitted
+type StakingReceipt struct {
+	*Receipt
+	Delegates         []*ValidatorDelegate
+	Undelegates       []*ValidatorUndelegate
+	Withdraws         []*ValidatorWithdraw
+	CommissionRewards []*ValidatorWithdrawCommissionReward
+
+	CommissionRateUpdates []*ValidatorUpdateCommissionRate
+	NameUpdates           []*ValidatorUpdateName
+	SignerUpdates         []*ValidatorUpdatedSigner
+	Started               []*ValidatorStarted
+	Stopped               []*ValidatorStopped
+}
+
+func (n *node) Delegate(ctx context.Context, opts *bind.TransactOpts, validatorSMCAddress common.Address, amount *big.Int) (*StakingReceipt, error) {
+	if amount == nil || amount.Sign() <= 0 {
+		return nil, ErrInvalidDelegateAmount
+	}
+	minStake, err := n.paramsValue(ctx, "getMinStake")
+	if err != nil {
+		return nil, err
+	}
+	if amount.Cmp(minStake) < 0 {
+		return nil, fmt.Errorf("%w: %s < %s", ErrBelowMinStake, amount, minStake)
+	}
+	return n.validatorTx(ctx, opts, validatorSMCAddress, amount, "delegate")
+}
+
+func (n *node) Undelegate(ctx context.Context, opts *bind.TransactOpts, validatorSMCAddress common.Address, amount *big.Int) (*StakingReceipt, error) {
+	stake, err := n.DelegatorStakedAmount(ctx, validatorSMCAddress.Hex(), opts.From.Hex())
+	if err != nil {
+		return nil, err
+	}
+	if stake.Sign() == 0 {
+		return nil, ErrNoDelegation
+	}
+	if amount == nil {
+		return n.validatorTx(ctx, opts, validatorSMCAddress, nil, "undelegate")
+	}
+
+	// the remaining stake is either nothing or at least the minimum stake
+	if amount.Sign() <= 0 || amount.Cmp(stake) > 0 {
+		return nil, fmt.Errorf("%w: %s of %s staked", ErrInvalidUndelegateAmount, amount, stake)
+	}
+	remaining := new(big.Int).Sub(stake, amount)
+	if remaining.Sign() > 0 {
+		minStake, err := n.paramsValue(ctx, "getMinStake")
+		if err != nil {
+			return nil, err
+		}
+		if remaining.Cmp(minStake) < 0 {
+			return nil, fmt.Errorf("%w: remaining stake %s < %s", ErrInvalidUndelegateAmount, remaining, minStake)
+		}
+	}
+	records, err := n.UnbondedRecords(ctx, validatorSMCAddress.Hex(), opts.From.Hex())
+	if err != nil {
+		return nil, err
+	}
+	if len(records.Balances) >= maxUnbondingEntries {
+		return nil, ErrTooManyUnbondingEntries
+	}
+	return n.validatorTx(ctx, opts, validatorSMCAddress, nil, "undelegateWithAmount", amount)
+}
+
+func (n *node) Withdraw(ctx context.Context, opts *bind.TransactOpts, validatorSMCAddress common.Address) (*StakingReceipt, error) {
+	records, err := n.UnbondedRecords(ctx, validatorSMCAddress.Hex(), opts.From.Hex())
+	if err != nil {
+		return nil, err
+	}
+	latest, err := n.LatestBlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	header, err := n.BlockHeaderByNumber(ctx, latest)
+	if err != nil {
+		return nil, err
+	}
+	// entries completed before the latest block are completed before the block of the transaction
+	if matured, _ := records.Split(header.Time); matured.Total().Sign() == 0 {
+		return nil, ErrNoUnbondedAmount
+	}
+	return n.validatorTx(ctx, opts, validatorSMCAddress, nil, "withdraw")
+}
+
+func (n *node) WithdrawReward(ctx context.Context, opts *bind.TransactOpts, validatorSMCAddress common.Address) (*StakingReceipt, error) {
+	rewards, err := n.DelegationRewards(ctx, validatorSMCAddress.Hex(), opts.From.Hex())
+	if err != nil {
+		return nil, err
+	}
+	if rewards.Sign() == 0 {
+		return nil, ErrNoRewards
+	}
+	return n.validatorTx(ctx, opts, validatorSMCAddress, nil, "withdrawRewards")
+}
+
+func (n *node) WithdrawCommission(ctx context.Context, opts *bind.TransactOpts, validatorSMCAddress common.Address) (*StakingReceipt, error) {
+	info, err := n.ValidatorInfo(ctx, validatorSMCAddress.Hex())
+	if err != nil {
+		return nil, err
+	}
+	if !info.Signer.Equal(opts.From) {
+		return nil, ErrNotValidator
+	}
+	if info.AccumulatedCommission.Sign() == 0 {
+		return nil, ErrNoCommission
+	}
+	return n.validatorTx(ctx, opts, validatorSMCAddress, nil, "withdrawCommission")
+}
+
+// validatorTx sends the call of method of the validator contract with value, see sendStakingTx,
+// and decodes the events of the validator from the receipt
+func (n *node) validatorTx(ctx context.Context, opts *bind.TransactOpts, validatorSMCAddress common.Address, value *big.Int, method string, args ...interface{}) (*StakingReceipt, error) {
+	c, err := NewValidatorContract(n, validatorSMCAddress)
+	if err != nil {
+		return nil, err
+	}
+	receipt, err := n.sendStakingTx(ctx, opts, c.BoundContract, value, method, args...)
+	if err != nil {
+		return nil, err
+	}
+	result, err := decodeStakingReceipt(c, receipt)
+	if err != nil {
+		return nil, &TxSentError{Hash: common.HexToHash(receipt.TransactionHash), Err: err}
+	}
+	return result, nil
+}
+
+// sendStakingTx executes the call of method of contract with value through KardiaCall first, so that
+// violated rules are returned as a *RevertError before any gas is spent. The transaction is then sent
+// and waited for until mined, a *TxSentError is returned if it cannot be waited for.
+func (n *node) sendStakingTx(ctx context.Context, opts *bind.TransactOpts, contract *BoundContract, value *big.Int, method string, args ...interface{}) (*Receipt, error) {
+	input, err := contract.Abi.Pack(method, args...)
+	if err != nil {
+		return nil, err
+	}
+	call := kardia.CallMsg{From: opts.From, To: &contract.ContractAddress, Value: value, Data: input}
+	if _, err := n.KardiaCall(ctx, toCallArgs(call)); err != nil {
+		return nil, err
+	}
+
+	txOpts := *opts
+	if txOpts.Context == nil {
+		txOpts.Context = ctx
+	}
+	txOpts.Value = value
+	if txOpts.GasLimit == 0 {
+		gas, err := n.EstimateGas(ctx, call)
+		if err != nil {
+			return nil, err
+		}
+		txOpts.GasLimit = gas + gas*stakingGasMargin/100
+	}
+	tx, err := contract.Transact(&txOpts, method, args...)
+	if err != nil {
+		return nil, err
+	}
+	receipt, err := n.WaitMined(ctx, tx.Hash().Hex())
+	if err != nil {
+		n.lgr.Error("Staking transaction failed", zap.String("Method", method), zap.String("TxHash", tx.Hash().Hex()), zap.Error(err))
+		var txFailure *TxFailedError
+		if errors.As(err, &txFailure) {
+			return nil, err
+		}
+		// the transaction is sent, Nodes must not fail over and send it again
+		return nil, &TxSentError{Hash: tx.Hash(), Err: err}
+	}
+	return receipt, nil
+}
+
+// decodeStakingReceipt decodes the events emitted by the validator contract c in receipt
+func decodeStakingReceipt(c *ValidatorContract, receipt *Receipt) (*StakingReceipt, error) {
+	result := &StakingReceipt{Receipt: receipt}
+	for _, l := range receipt.Logs {
+		log := toTypesLog(l)
+		if log.Address != c.ContractAddress || len(log.Topics) == 0 {
+			continue
+		}
+		event, err := c.Abi.EventByID(log.Topics[0])
+		if err != nil {
+			continue
+		}
+		switch event.RawName {
+		case "Delegate":
+			ev, err := c.ParseDelegate(log)
+			if err != nil {
+				return nil, err
+			}
+			result.Delegates = append(result.Delegates, ev)
+		case "Undelegate":
+			ev, err := c.ParseUndelegate(log)
+			if err != nil {
+				return nil, err
+			}
+			result.Undelegates = append(result.Undelegates, ev)
+		case "Withdraw":
+			ev, err := c.ParseWithdraw(log)
+			if err != nil {
+				return nil, err
+			}
+			result.Withdraws = append(result.Withdraws, ev)
+		case "WithdrawCommissionReward":
+			ev, err := c.ParseWithdrawCommissionReward(log)
+			if err != nil {
+				return nil, err
+			}
+			result.CommissionRewards = append(result.CommissionRewards, ev)
+		case "UpdateCommissionRate":
+			ev, err := c.ParseUpdateCommissionRate(log)
+			if err != nil {
+				return nil, err
+			}
+			result.CommissionRateUpdates = append(result.CommissionRateUpdates, ev)
+		case "UpdateName":
+			ev, err := c.ParseUpdateName(log)
+			if err != nil {
+				return nil, err
+			}
+			result.NameUpdates = append(result.NameUpdates, ev)
+		case "UpdatedSigner":
+			ev, err := c.ParseUpdatedSigner(log)
+			if err != nil {
+				return nil, err
+			}
+			result.SignerUpdates = append(result.SignerUpdates, ev)
+		case "Started":
+			ev, err := c.ParseStarted(log)
+			if err != nil {
+				return nil, err
+			}
+			result.Started = append(result.Started, ev)
+		case "Stopped":
+			ev, err := c.ParseStopped(log)
+			if err != nil {
+				return nil, err
+			}
+			result.Stopped = append(result.Stopped, ev)
+		}
+	}
+	return result, nil
+}
+
+// paramsValue returns the staking parameter read by the getter method of the params contract, e.g. getMinStake
+func (n *node) paramsValue(ctx context.Context, method string) (*big.Int, error) {
+	payload, err := n.paramsSMC.Abi.Pack(method)
+	if err != nil {
+		return nil, err
+	}
+	res, err := n.KardiaCall(ctx, ConstructCallArgs(n.paramsSMC.ContractAddress.Hex(), payload))
+	if err != nil {
+		n.lgr.Error("Params KardiaCall error: ", zap.String("Method", method), zap.Error(err))
+		return nil, err
+	}
+	var value *big.Int
+	if err := n.paramsSMC.Abi.UnpackIntoInterface(&value, method, res); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
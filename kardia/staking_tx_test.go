@@ -0,0 +1,167 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package kardia
+package kardia
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/kardiachain/go-kardia/lib/abi/bind"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/crypto"
+	"github.com/kardiachain/go-kardia/mainchain/genesis"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/kardiachain/go-kaiclient/kardia/simulated"
+)
+
+// setupSimulatedStaking returns the simulated chain of setupSimulatedNode with a second account
+// holding 20M KAI, and the address of the validator contract
+func setupSimulatedStaking(t *testing.T) (*simulated.Backend, Node, *bind.TransactOpts, *bind.TransactOpts, common.Address) {
+	_, privateKey, err := setupTestAccount()
+	assert.Nil(t, err)
+	auth := NewKeyedTransactor(privateKey)
+	key, err := crypto.GenerateKey()
+	assert.Nil(t, err)
+	delegator := NewKeyedTransactor(key)
+
+	cfg := simulatedTestConfig(auth)
+	cfg.Alloc[delegator.From] = genesis.GenesisAccount{Balance: FloatToBigInt(20000000, 18)}
+	b, err := simulated.NewBackend(cfg)
+	assert.Nil(t, err)
+	node, err := NewSimulatedNode(b, zap.NewNop())
+	assert.Nil(t, err)
+	validators, err := node.ValidatorSMCAddresses(context.Background())
+	assert.Nil(t, err)
+	return b, node, auth, delegator, validators[0]
+}
+
+func TestNode_Delegate(t *testing.T) {
+	b, node, _, delegator, validator := setupSimulatedStaking(t)
+	defer b.Close()
+	ctx := context.Background()
+
+	_, err := node.Delegate(ctx, delegator, validator, FloatToBigInt(1000, 18))
+	assert.True(t, errors.Is(err, ErrBelowMinStake))
+	for _, amount := range []*big.Int{nil, big.NewInt(0), big.NewInt(-1)} {
+		_, err = node.Delegate(ctx, delegator, validator, amount)
+		assert.Equal(t, ErrInvalidDelegateAmount, err)
+	}
+	_, err = node.Undelegate(ctx, delegator, validator, nil)
+	assert.Equal(t, ErrNoDelegation, err)
+
+	receipt, err := node.Delegate(ctx, delegator, validator, FloatToBigInt(30000, 18))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(receipt.Delegates))
+	assert.Equal(t, delegator.From, receipt.Delegates[0].DelAddr)
+	assert.Equal(t, FloatToBigInt(30000, 18), receipt.Delegates[0].Amount)
+	assert.Equal(t, receipt.BlockHeight, receipt.Delegates[0].Raw.BlockHeight)
+	// the stake is computed from the shares of the delegation, rounded down
+	stake, err := node.DelegatorStakedAmount(ctx, validator.Hex(), delegator.From.Hex())
+	assert.Nil(t, err)
+	assert.Equal(t, -1, stake.Cmp(FloatToBigInt(30000, 18)))
+	assert.Equal(t, 1, stake.Cmp(FloatToBigInt(29999, 18)))
+
+	// the remaining stake must be nothing or at least the 25K KAI minimum
+	for _, amount := range []float64{10000, 40000, 0} {
+		_, err = node.Undelegate(ctx, delegator, validator, FloatToBigInt(amount, 18))
+		assert.True(t, errors.Is(err, ErrInvalidUndelegateAmount), amount)
+	}
+	amount := new(big.Int).Sub(stake, FloatToBigInt(25000, 18))
+	receipt, err = node.Undelegate(ctx, delegator, validator, amount)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(receipt.Undelegates))
+	assert.Equal(t, delegator.From, receipt.Undelegates[0].DelAddr)
+	unbonded := receipt.Undelegates[0].Amount
+	assert.Equal(t, 1, unbonded.Sign())
+	header, err := node.BlockHeaderByNumber(ctx, receipt.BlockHeight)
+	assert.Nil(t, err)
+	unbondingTime := int64(7 * 24 * time.Hour / time.Second)
+	assert.Equal(t, big.NewInt(header.Time.Unix()+unbondingTime), receipt.Undelegates[0].CompletionTime)
+	records, err := node.UnbondedRecords(ctx, validator.Hex(), delegator.From.Hex())
+	assert.Nil(t, err)
+	assert.Equal(t, []*big.Int{unbonded}, records.Balances)
+
+	_, err = node.Withdraw(ctx, delegator, validator)
+	assert.Equal(t, ErrNoUnbondedAmount, err)
+	assert.Nil(t, b.AdjustTime(8*24*time.Hour))
+	_, err = b.Commit()
+	assert.Nil(t, err)
+	receipt, err = node.Withdraw(ctx, delegator, validator)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(receipt.Withdraws))
+	assert.Equal(t, delegator.From, receipt.Withdraws[0].DelAddr)
+	assert.Equal(t, unbonded, receipt.Withdraws[0].Amount)
+
+	receipt, err = node.WithdrawReward(ctx, delegator, validator)
+	assert.Nil(t, err)
+	assert.Equal(t, ReceiptStatusSuccessful, receipt.Status)
+	_, err = node.WithdrawReward(ctx, delegator, validator)
+	assert.Equal(t, ErrNoRewards, err)
+
+	receipt, err = node.Undelegate(ctx, delegator, validator, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(receipt.Undelegates))
+	// shares are rounded down too, at most dust is left staked
+	stake, err = node.DelegatorStakedAmount(ctx, validator.Hex(), delegator.From.Hex())
+	assert.Nil(t, err)
+	assert.Equal(t, -1, stake.Cmp(big.NewInt(1e9)), stake.String())
+}
+
+func TestNode_UndelegateEntries(t *testing.T) {
+	b, node, _, delegator, validator := setupSimulatedStaking(t)
+	defer b.Close()
+	ctx := context.Background()
+
+	_, err := node.Delegate(ctx, delegator, validator, FloatToBigInt(50000, 18))
+	assert.Nil(t, err)
+	for i := 0; i < maxUnbondingEntries; i++ {
+		_, err = node.Undelegate(ctx, delegator, validator, FloatToBigInt(1, 18))
+		assert.Nil(t, err)
+	}
+	_, err = node.Undelegate(ctx, delegator, validator, FloatToBigInt(1, 18))
+	assert.Equal(t, ErrTooManyUnbondingEntries, err)
+	// undelegating the whole stake has no limit
+	receipt, err := node.Undelegate(ctx, delegator, validator, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(receipt.Undelegates))
+}
+
+func TestNode_WithdrawCommission(t *testing.T) {
+	b, node, auth, delegator, validator := setupSimulatedStaking(t)
+	defer b.Close()
+	ctx := context.Background()
+
+	_, err := node.WithdrawCommission(ctx, delegator, validator)
+	assert.Equal(t, ErrNotValidator, err)
+
+	// every block pays the commission of the validator
+	for i := 0; i < 3; i++ {
+		_, err := b.Commit()
+		assert.Nil(t, err)
+	}
+	receipt, err := node.WithdrawCommission(ctx, auth, validator)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(receipt.CommissionRewards))
+	assert.Equal(t, 1, receipt.CommissionRewards[0].Rewards.Sign())
+}
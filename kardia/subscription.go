@@ -22,11 +22,19 @@ import (
 	"context"
 	"time"
 
+	"github.com/kardiachain/go-kardia"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/event"
 	"github.com/kardiachain/go-kardia/rpc"
 	"github.com/kardiachain/go-kardia/types"
 	"go.uber.org/zap"
 )
 
+const (
+	resubscribeMinBackoff = 1 * time.Second
+	resubscribeMaxBackoff = 30 * time.Second
+)
+
 type ISubscription interface {
 	KaiSubscribe(ctx context.Context, channel interface{}, args ...interface{}) (*rpc.ClientSubscription, error)
 	SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (*rpc.ClientSubscription, error)
@@ -53,3 +61,165 @@ func (n *node) KaiSubscribe(ctx context.Context, channel interface{}, args ...in
 func (n *node) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (*rpc.ClientSubscription, error) {
 	return n.KaiSubscribe(ctx, ch, "newHeads")
 }
+
+// SubscribeFilterLogs subscribes to logs matching the given query and delivers them on ch.
+// The subscription survives connection drops: it resubscribes with backoff and backfills
+// the missed block range through FilterLogs, so no log is silently lost or duplicated.
+// If query.FromBlock is set, logs from that block up to the current head are delivered first.
+func (n *node) SubscribeFilterLogs(ctx context.Context, query kardia.FilterQuery, ch chan<- types.Log) (event.Subscription, error) {
+	arg, err := toFilterArg(kardia.FilterQuery{Addresses: query.Addresses, Topics: query.Topics})
+	if err != nil {
+		return nil, err
+	}
+	logsCh := make(chan types.Log)
+	sub, err := n.client.Subscribe(ctx, "kai", logsCh, "logs", arg)
+	if err != nil {
+		return nil, err
+	}
+	head, err := n.LatestBlockNumber(ctx)
+	if err != nil {
+		sub.Unsubscribe()
+		return nil, err
+	}
+	s := &logsSubscription{
+		node:  n,
+		query: query,
+		ch:    ch,
+		last:  head,
+		seen:  make(map[logKey]struct{}),
+	}
+	if query.FromBlock != 0 {
+		s.last = query.FromBlock - 1
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer func() { sub.Unsubscribe() }()
+		if err := s.backfill(ctx, quit, head); err != nil {
+			return err
+		}
+		for {
+			select {
+			case log := <-logsCh:
+				if !s.deliver(quit, log) {
+					return nil
+				}
+			case err := <-sub.Err():
+				n.lgr.Warn("Logs subscription dropped, resubscribing", zap.Error(err))
+				newSub, head, err := s.resubscribe(ctx, quit, logsCh, arg)
+				if err != nil {
+					return err
+				}
+				if newSub == nil {
+					return nil
+				}
+				sub = newSub
+				if err := s.backfill(ctx, quit, head); err != nil {
+					return err
+				}
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// logKey identifies a log inside a block
+type logKey struct {
+	txHash common.Hash
+	index  uint
+}
+
+// logsSubscription tracks the last delivered block height of a logs subscription,
+// which is where the backfill resumes after a reconnection.
+type logsSubscription struct {
+	node  *node
+	query kardia.FilterQuery
+	ch    chan<- types.Log
+
+	// last is the highest block height covered so far,
+	// seen holds logs already delivered for that height
+	last uint64
+	seen map[logKey]struct{}
+}
+
+// deliver forwards log to the subscriber, unless it was already delivered.
+// Logs are expected in block height order. It returns false once unsubscribed.
+func (s *logsSubscription) deliver(quit <-chan struct{}, log types.Log) bool {
+	key := logKey{txHash: log.TxHash, index: log.Index}
+	switch {
+	case log.BlockHeight < s.last:
+		return true
+	case log.BlockHeight == s.last:
+		if _, found := s.seen[key]; found {
+			return true
+		}
+	default:
+		s.last = log.BlockHeight
+		s.seen = make(map[logKey]struct{})
+	}
+	s.seen[key] = struct{}{}
+	select {
+	case s.ch <- log:
+		return true
+	case <-quit:
+		return false
+	}
+}
+
+// backfill delivers logs between the last covered block and head.
+// It stops early without error once unsubscribed.
+func (s *logsSubscription) backfill(ctx context.Context, quit <-chan struct{}, head uint64) error {
+	from := s.last
+	if len(s.seen) == 0 {
+		from++
+	}
+	if from == 0 || from > head {
+		return nil
+	}
+	query := s.query
+	query.FromBlock, query.ToBlock = from, head
+	logs, err := s.node.FilterLogs(ctx, query)
+	if err != nil {
+		return err
+	}
+	s.node.lgr.Debug("Backfilled logs", zap.Uint64("From", from), zap.Uint64("To", head), zap.Int("Total", len(logs)))
+	for _, log := range logs {
+		if !s.deliver(quit, log) {
+			return nil
+		}
+	}
+	if s.last < head {
+		s.last = head
+		s.seen = make(map[logKey]struct{})
+	}
+	return nil
+}
+
+// resubscribe retries the logs subscription with exponential backoff until it succeeds,
+// then returns the new subscription and the current head. The subscription is nil
+// if unsubscribed meanwhile.
+func (s *logsSubscription) resubscribe(ctx context.Context, quit <-chan struct{}, logsCh chan types.Log, arg interface{}) (*rpc.ClientSubscription, uint64, error) {
+	backoff := resubscribeMinBackoff
+	for {
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return nil, 0, ctx.Err()
+		case <-quit:
+			return nil, 0, nil
+		}
+		sub, err := s.node.client.Subscribe(ctx, "kai", logsCh, "logs", arg)
+		if err == nil {
+			head, err := s.node.LatestBlockNumber(ctx)
+			if err == nil {
+				return sub, head, nil
+			}
+			sub.Unsubscribe()
+		}
+		s.node.lgr.Warn("Cannot resubscribe logs", zap.Duration("Backoff", backoff), zap.Error(err))
+		if backoff *= 2; backoff > resubscribeMaxBackoff {
+			backoff = resubscribeMaxBackoff
+		}
+	}
+}
@@ -25,12 +25,15 @@ import (
 	"log"
 	"math/big"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/kardiachain/go-kardia/lib/abi"
 	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia"
 	"github.com/kardiachain/go-kardia/lib/crypto"
+	"github.com/kardiachain/go-kardia/mainchain/filters"
 	"github.com/kardiachain/go-kardia/rpc"
 	"github.com/kardiachain/go-kardia/types"
 	"github.com/stretchr/testify/assert"
@@ -59,6 +62,132 @@ func TestSubscription_NewBlockHead(t *testing.T) {
 	}
 }
 
+// mockChainLogs serves kai_blockNumber, kai_getLogs and kai_subscribe("logs")
+// from an in-memory list of logs
+type mockChainLogs struct {
+	mu   sync.Mutex
+	head uint64
+	logs []*types.Log
+	subs map[rpc.ID]chan *types.Log
+}
+
+func newMockChainLogs(head uint64) *mockChainLogs {
+	return &mockChainLogs{head: head, subs: make(map[rpc.ID]chan *types.Log)}
+}
+
+func (api *mockChainLogs) BlockNumber() uint64 {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	return api.head
+}
+
+func (api *mockChainLogs) GetLogs(crit filters.FilterCriteria) ([]*types.Log, error) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	var logs []*types.Log
+	for _, log := range api.logs {
+		if log.BlockHeight >= crit.FromBlock && log.BlockHeight <= crit.ToBlock {
+			logs = append(logs, log)
+		}
+	}
+	return logs, nil
+}
+
+func (api *mockChainLogs) Logs(ctx context.Context, crit filters.FilterCriteria) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	rpcSub := notifier.CreateSubscription()
+	logsCh := make(chan *types.Log, 10)
+	api.mu.Lock()
+	api.subs[rpcSub.ID] = logsCh
+	api.mu.Unlock()
+	go func() {
+		defer func() {
+			api.mu.Lock()
+			delete(api.subs, rpcSub.ID)
+			api.mu.Unlock()
+		}()
+		for {
+			select {
+			case log := <-logsCh:
+				_ = notifier.Notify(rpcSub.ID, log)
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
+
+// mine appends a log at a new head, notifying subscribers if live
+func (api *mockChainLogs) mine(live bool) *types.Log {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	api.head++
+	log := &types.Log{
+		Address:     common.HexToAddress(test1SmcAddr),
+		BlockHeight: api.head,
+		TxHash:      common.BigToHash(new(big.Int).SetUint64(api.head)),
+	}
+	api.logs = append(api.logs, log)
+	if live {
+		for _, ch := range api.subs {
+			ch <- log
+		}
+	}
+	return log
+}
+
+func receiveLog(t *testing.T, ch chan types.Log) types.Log {
+	select {
+	case log := <-ch:
+		return log
+	case <-time.After(10 * time.Second):
+		t.Fatal("timeout waiting for log")
+	}
+	return types.Log{}
+}
+
+func TestSubscription_SubscribeFilterLogs(t *testing.T) {
+	api := newMockChainLogs(0)
+	backfilled := api.mine(false)
+	api.mine(false)
+	node, drop, err := setupMockWSNodeInstance(map[string]interface{}{"kai": api})
+	assert.Nil(t, err)
+
+	logsCh := make(chan types.Log)
+	query := kardia.FilterQuery{FromBlock: backfilled.BlockHeight, Addresses: []common.Address{common.HexToAddress(test1SmcAddr)}}
+	sub, err := node.SubscribeFilterLogs(context.Background(), query, logsCh)
+	assert.Nil(t, err)
+	defer sub.Unsubscribe()
+
+	// logs before subscription are backfilled from FromBlock
+	assert.Equal(t, uint64(1), receiveLog(t, logsCh).BlockHeight)
+	assert.Equal(t, uint64(2), receiveLog(t, logsCh).BlockHeight)
+
+	api.mine(true)
+	assert.Equal(t, uint64(3), receiveLog(t, logsCh).BlockHeight)
+
+	// logs mined while disconnected are backfilled after resubscription
+	drop()
+	api.mine(false)
+	api.mine(false)
+	assert.Equal(t, uint64(4), receiveLog(t, logsCh).BlockHeight)
+	assert.Equal(t, uint64(5), receiveLog(t, logsCh).BlockHeight)
+
+	api.mine(true)
+	assert.Equal(t, uint64(6), receiveLog(t, logsCh).BlockHeight)
+	select {
+	case log := <-logsCh:
+		t.Fatal("unexpected log", log.BlockHeight)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
 func subscribe(n Node, channel interface{}) (*rpc.ClientSubscription, error) {
 	args := FilterArgs{Address: []string{"0x9e003D7e05E19514aa76DcFF4EB2443522677288"}}
 	sub, err := n.KaiSubscribe(context.Background(), channel, "logs", args)
@@ -30,6 +30,7 @@ const (
 	TokenTypeUnknown = 0
 	TokenTypeKRC20   = 1
 	TokenTypeKRC721  = 2
+	TokenTypeKRC1155 = 3
 )
 
 type Token interface {
@@ -47,21 +48,26 @@ type token struct {
 	krcType int
 }
 
+// NewToken binds the token at address, see NewTokenContext
 func NewToken(node Node, address string) (Token, error) {
+	return NewTokenContext(context.Background(), node, address)
+}
+
+// NewTokenContext binds the token at address, whose standard is detected by node.DetectTokenType.
+// TokenType returns TokenTypeUnknown if no standard is detected.
+func NewTokenContext(ctx context.Context, node Node, address string) (Token, error) {
+	krcType, err := node.DetectTokenType(ctx, address)
+	if err != nil {
+		return nil, err
+	}
 	c := &Contract{
 		ContractAddress: common.HexToAddress(address),
 	}
-
 	t := &token{
-		node: node,
-		c:    c,
+		node:    node,
+		c:       c,
+		krcType: krcType,
 	}
-
-	//krcType := t.getKRCType(ctx)
-	//if krcType == TokenTypeUnknown {
-	//	return nil, errors.New("token type not support")
-	//}
-	//t.krcType = krcType
 	return t, nil
 }
 
@@ -69,17 +75,6 @@ func (t *token) TokenType() int {
 	return t.krcType
 }
 
-func (t *token) getKRCType(ctx context.Context) int {
-	if krc20Info, err := t.KRC20Info(ctx); err == nil && krc20Info != nil {
-		return TokenTypeKRC20
-	}
-	if krc721Info, err := t.KRC721Info(ctx); err == nil && krc721Info != nil {
-		return TokenTypeKRC721
-	}
-
-	return TokenTypeUnknown
-}
-
 func (t *token) KRC721Info(ctx context.Context) (*KRC721, error) {
 	krc721ABI, err := KRC721ABI()
 	if err != nil {
@@ -0,0 +1,246 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package kardia
+package kardia
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/kardiachain/go-kardia/lib/abi"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/crypto"
+)
+
+type IToken interface {
+	// DetectTokenType returns the token standard implemented by the contract at address, one of the
+	// TokenType constants. Detected standards are cached by address.
+	DetectTokenType(ctx context.Context, address string) (int, error)
+}
+
+func (n *node) DetectTokenType(ctx context.Context, address string) (int, error) {
+	return n.tokenTypes.detect(ctx, n, address)
+}
+
+// ERC165 interface ids of the token standards
+var (
+	interfaceIDERC165  = [4]byte{0x01, 0xff, 0xc9, 0xa7}
+	interfaceIDInvalid = [4]byte{0xff, 0xff, 0xff, 0xff}
+	interfaceIDKRC721  = [4]byte{0x80, 0xac, 0x58, 0xcd}
+	interfaceIDKRC1155 = [4]byte{0xd9, 0xb6, 0x7a, 0x26}
+)
+
+// tokenSelectors are the method selectors a token bytecode dispatches on, by standard.
+// They are checked in order, as KRC721 and KRC1155 share selectors with KRC20.
+var tokenSelectors = []struct {
+	krcType   int
+	selectors [][]byte
+}{
+	{TokenTypeKRC1155, selectors("balanceOfBatch(address[],uint256[])", "safeBatchTransferFrom(address,address,uint256[],uint256[],bytes)")},
+	{TokenTypeKRC721, selectors("ownerOf(uint256)", "transferFrom(address,address,uint256)")},
+	{TokenTypeKRC20, selectors("totalSupply()", "balanceOf(address)", "transfer(address,uint256)", "transferFrom(address,address,uint256)")},
+}
+
+func selectors(signatures ...string) [][]byte {
+	var ids [][]byte
+	for _, sig := range signatures {
+		ids = append(ids, crypto.Keccak256([]byte(sig))[:4])
+	}
+	return ids
+}
+
+// tokenTypeCache caches the token standards detected by a node
+type tokenTypeCache struct {
+	types sync.Map // common.Address => int
+}
+
+// detect returns the token standard of the contract at address. The standard is read from supportsInterface,
+// then from the selectors in the bytecode, then by probing the KRC20 and KRC721 methods. Only detected
+// standards are cached, a probe failing to reach the node returns its error.
+func (c *tokenTypeCache) detect(ctx context.Context, node Node, address string) (int, error) {
+	addr := common.HexToAddress(address)
+	if krcType, ok := c.types.Load(addr); ok {
+		return krcType.(int), nil
+	}
+	code, err := node.Code(ctx, addr.Hex())
+	if err != nil {
+		return TokenTypeUnknown, err
+	}
+	// accounts without code may still be deployed to
+	if len(code) == 0 {
+		return TokenTypeUnknown, nil
+	}
+
+	krcType, err := interfaceTokenType(ctx, node, addr)
+	if err != nil {
+		return TokenTypeUnknown, err
+	}
+	if krcType == TokenTypeUnknown {
+		krcType = bytecodeTokenType(code)
+	}
+	if krcType == TokenTypeUnknown {
+		if krcType, err = methodsTokenType(ctx, node, addr); err != nil {
+			return TokenTypeUnknown, err
+		}
+	}
+	// unknown contracts are probed again, the code of a proxy may still be upgraded to a token
+	if krcType != TokenTypeUnknown {
+		c.types.Store(addr, krcType)
+	}
+	return krcType, nil
+}
+
+// interfaceTokenType returns the standard the contract declares through ERC165
+func interfaceTokenType(ctx context.Context, node Node, address common.Address) (int, error) {
+	krc721ABI, err := KRC721ABI()
+	if err != nil {
+		return TokenTypeUnknown, err
+	}
+	supports := func(id [4]byte) (bool, error) {
+		out, err := probe(ctx, node, address, krc721ABI, "supportsInterface", id)
+		if err != nil || len(out) == 0 {
+			return false, err
+		}
+		ok, _ := out[0].(bool)
+		return ok, nil
+	}
+	for _, check := range []struct {
+		id       [4]byte
+		expected bool
+	}{{interfaceIDERC165, true}, {interfaceIDInvalid, false}} {
+		ok, err := supports(check.id)
+		if err != nil || ok != check.expected {
+			return TokenTypeUnknown, err
+		}
+	}
+	for _, standard := range []struct {
+		id      [4]byte
+		krcType int
+	}{{interfaceIDKRC1155, TokenTypeKRC1155}, {interfaceIDKRC721, TokenTypeKRC721}} {
+		ok, err := supports(standard.id)
+		if err != nil {
+			return TokenTypeUnknown, err
+		}
+		if ok {
+			return standard.krcType, nil
+		}
+	}
+	return TokenTypeUnknown, nil
+}
+
+// methodsTokenType returns the first of KRC20 and KRC721 whose metadata methods are all implemented by the contract
+func methodsTokenType(ctx context.Context, node Node, address common.Address) (int, error) {
+	krc20ABI, err := KRC20ABI()
+	if err != nil {
+		return TokenTypeUnknown, err
+	}
+	krc721ABI, err := KRC721ABI()
+	if err != nil {
+		return TokenTypeUnknown, err
+	}
+	for _, standard := range []struct {
+		abi     *abi.ABI
+		methods []string
+		krcType int
+	}{
+		{krc20ABI, []string{"name", "symbol", "decimals", "totalSupply"}, TokenTypeKRC20},
+		{krc721ABI, []string{"name", "symbol", "totalSupply"}, TokenTypeKRC721},
+	} {
+		implemented := true
+		for _, method := range standard.methods {
+			out, err := probe(ctx, node, address, standard.abi, method)
+			if err != nil {
+				return TokenTypeUnknown, err
+			}
+			if out == nil {
+				implemented = false
+				break
+			}
+		}
+		if implemented {
+			return standard.krcType, nil
+		}
+	}
+	return TokenTypeUnknown, nil
+}
+
+// probe calls method of the contract at address and returns its unpacked outputs. The outputs are nil without
+// error when the contract does not implement the method: the call reverts, returns nothing or returns data which
+// does not unpack. Any other error is returned, so that a failing node is not taken for a missing method.
+func probe(ctx context.Context, node Node, address common.Address, contractABI *abi.ABI, method string, args ...interface{}) ([]interface{}, error) {
+	payload, err := contractABI.Pack(method, args...)
+	if err != nil {
+		return nil, err
+	}
+	res, err := node.KardiaCall(ctx, ConstructCallArgs(address.Hex(), payload))
+	if err != nil {
+		var revert *RevertError
+		if errors.As(err, &revert) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if len(res) == 0 {
+		return nil, nil
+	}
+	out, err := contractABI.Unpack(method, res)
+	if err != nil {
+		return nil, nil
+	}
+	return out, nil
+}
+
+// bytecodeTokenType returns the standard whose selectors are all pushed by code
+func bytecodeTokenType(code []byte) int {
+	pushed := pushedSelectors(code)
+	for _, standard := range tokenSelectors {
+		found := true
+		for _, selector := range standard.selectors {
+			if !pushed[string(selector)] {
+				found = false
+				break
+			}
+		}
+		if found {
+			return standard.krcType
+		}
+	}
+	return TokenTypeUnknown
+}
+
+// pushedSelectors returns the values of at most 4 bytes pushed by code, padded to 4 bytes.
+// Dispatchers compare the selector of calls to them, selectors starting with zeros are pushed with shorter PUSHes.
+func pushedSelectors(code []byte) map[string]bool {
+	const push1, push32 = 0x60, 0x7f
+	pushed := make(map[string]bool)
+	for i := 0; i < len(code); i++ {
+		op := code[i]
+		if op < push1 || op > push32 {
+			continue
+		}
+		size := int(op-push1) + 1
+		if size <= 4 && i+1+size <= len(code) {
+			selector := make([]byte, 4)
+			copy(selector[4-size:], code[i+1:i+1+size])
+			pushed[string(selector)] = true
+		}
+		i += size
+	}
+	return pushed
+}
@@ -0,0 +1,161 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package kardia
+package kardia
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kardiachain/go-kardia/kvm"
+	"github.com/kardiachain/go-kardia/lib/abi"
+	"github.com/kardiachain/go-kardia/lib/abi/bind"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kardiachain/go-kaiclient/kardia/internal/evmasm"
+	"github.com/kardiachain/go-kaiclient/kardia/smc"
+)
+
+// testKRC1155Code returns a contract declaring KRC1155 through supportsInterface, and reverting other calls
+func testKRC1155Code(t *testing.T) []byte {
+	p := evmasm.NewProgram()
+	p.Emit(kvm.SHR, evmasm.Const(224), evmasm.Call(kvm.CALLDATALOAD, evmasm.Const(0)))
+	evmasm.Selector("supportsInterface(bytes4)")(p)
+	p.Op(kvm.EQ)
+	p.JumpIf("supportsInterface")
+	p.Emit(kvm.REVERT, evmasm.Const(0), evmasm.Const(0))
+	p.Label("supportsInterface")
+	id := evmasm.Shr(224, evmasm.Arg(0))
+	p.ReturnWord(evmasm.Or(evmasm.Eq(id, evmasm.Const(interfaceIDERC165[:])), evmasm.Eq(id, evmasm.Const(interfaceIDKRC1155[:]))))
+	code, err := p.Bytes()
+	assert.Nil(t, err)
+	return code
+}
+
+func TestBytecodeTokenType(t *testing.T) {
+	assert.Equal(t, TokenTypeKRC20, bytecodeTokenType(common.FromHex(smc.KRC20Bytecode)))
+	assert.Equal(t, TokenTypeKRC721, bytecodeTokenType(common.FromHex(smc.KRC721Bytecode)))
+	assert.Equal(t, TokenTypeKRC1155, bytecodeTokenType(common.FromHex(smc.KRC1155Bytecode)))
+	assert.Equal(t, TokenTypeUnknown, bytecodeTokenType(testKRC1155Code(t)))
+	assert.Equal(t, TokenTypeUnknown, bytecodeTokenType(nil))
+
+	// selectors starting with zeros are pushed with shorter PUSHes, push data is not read as opcodes
+	p := evmasm.NewProgram()
+	for _, selector := range selectors("balanceOfBatch(address[],uint256[])", "safeBatchTransferFrom(address,address,uint256[],uint256[],bytes)") {
+		p.Push(selector)
+	}
+	code, err := p.Bytes()
+	assert.Nil(t, err)
+	assert.Equal(t, TokenTypeKRC1155, bytecodeTokenType(code))
+	assert.True(t, pushedSelectors([]byte{0x62, 0x01, 0x02, 0x03})[string([]byte{0, 1, 2, 3})])
+	assert.Len(t, pushedSelectors([]byte{0x61, 0x63, 0x01}), 1)
+}
+
+// flakyNode fails the KardiaCall number failAt with err, like a dropped connection or a rate limited node
+type flakyNode struct {
+	Node
+	err    error
+	failAt int
+	calls  int
+}
+
+func (n *flakyNode) KardiaCall(ctx context.Context, args SMCCallArgs) ([]byte, error) {
+	n.calls++
+	if n.calls == n.failAt {
+		return nil, n.err
+	}
+	return n.Node.KardiaCall(ctx, args)
+}
+
+func TestNode_DetectTokenType(t *testing.T) {
+	b, kaiNode, auth := setupSimulatedNode(t)
+	defer b.Close()
+	ctx := context.Background()
+
+	krc20, txHash, err := kaiNode.DeployKRC20(auth)
+	assert.Nil(t, err)
+	_, err = kaiNode.WaitMined(ctx, txHash.Hex())
+	assert.Nil(t, err)
+	krc721, txHash, err := kaiNode.DeployKRC721(auth)
+	assert.Nil(t, err)
+	_, err = kaiNode.WaitMined(ctx, txHash.Hex())
+	assert.Nil(t, err)
+	krc1155, tx, _, err := bind.DeployContract(auth, abi.ABI{}, evmasm.Deployment(testKRC1155Code(t)), kaiNode)
+	assert.Nil(t, err)
+	_, err = kaiNode.WaitMined(ctx, tx.Hash().Hex())
+	assert.Nil(t, err)
+
+	for address, expected := range map[common.Address]int{
+		krc20:     TokenTypeKRC20,
+		krc721:    TokenTypeKRC721,
+		krc1155:   TokenTypeKRC1155,
+		auth.From: TokenTypeUnknown,
+	} {
+		krcType, err := kaiNode.DetectTokenType(ctx, address.Hex())
+		assert.Nil(t, err)
+		assert.Equal(t, expected, krcType, address.Hex())
+		token, err := NewToken(kaiNode, address.Hex())
+		assert.Nil(t, err)
+		assert.Equal(t, expected, token.TokenType())
+	}
+
+	cached, ok := kaiNode.(*node).tokenTypes.types.Load(krc721)
+	assert.True(t, ok)
+	assert.Equal(t, TokenTypeKRC721, cached)
+	// accounts without code are not cached
+	_, ok = kaiNode.(*node).tokenTypes.types.Load(auth.From)
+	assert.False(t, ok)
+
+	// a failed probe is returned and not cached
+	cache := &tokenTypeCache{}
+	flaky := &flakyNode{Node: kaiNode, err: errors.New("connection reset by peer"), failAt: 1}
+	_, err = cache.detect(ctx, flaky, krc1155.Hex())
+	assert.NotNil(t, err)
+	_, ok = cache.types.Load(krc1155)
+	assert.False(t, ok)
+	krcType, err := cache.detect(ctx, flaky, krc1155.Hex())
+	assert.Nil(t, err)
+	assert.Equal(t, TokenTypeKRC1155, krcType)
+
+	// JSON-RPC errors other than reverts are returned too, the KRC20 probe does not fall through to KRC721.
+	// The KRC20 token reverts supportsInterface, the third call probes its symbol.
+	bytecode := tokenSelectors
+	tokenSelectors = nil
+	defer func() { tokenSelectors = bytecode }()
+	flaky = &flakyNode{Node: kaiNode, err: &mockRevert{msg: "rate limit exceeded"}, failAt: 3}
+	_, err = cache.detect(ctx, flaky, krc20.Hex())
+	assert.Equal(t, flaky.err, err)
+	_, ok = cache.types.Load(krc20)
+	assert.False(t, ok)
+	krcType, err = cache.detect(ctx, flaky, krc20.Hex())
+	assert.Nil(t, err)
+	assert.Equal(t, TokenTypeKRC20, krcType)
+
+	// contracts of no standard are probed again
+	unknown, tx, _, err := bind.DeployContract(auth, abi.ABI{}, evmasm.Deployment([]byte{byte(kvm.STOP)}), kaiNode)
+	assert.Nil(t, err)
+	_, err = kaiNode.WaitMined(ctx, tx.Hash().Hex())
+	assert.Nil(t, err)
+	krcType, err = kaiNode.DetectTokenType(ctx, unknown.Hex())
+	assert.Nil(t, err)
+	assert.Equal(t, TokenTypeUnknown, krcType)
+	_, ok = kaiNode.(*node).tokenTypes.types.Load(unknown)
+	assert.False(t, ok)
+}
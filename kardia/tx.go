@@ -33,6 +33,9 @@ type ITx interface {
 	GetTransactionReceipt(ctx context.Context, txHash string) (*Receipt, error)
 	SendTransaction(ctx context.Context, tx *types.Transaction) error
 	SendRawTransaction(ctx context.Context, tx *types.Transaction) error
+	WaitMined(ctx context.Context, txHash string) (*Receipt, error)
+	WaitConfirmed(ctx context.Context, txHash string, confirmations uint64) (*Receipt, error)
+	TxRevertReason(ctx context.Context, txHash string, d *RevertDecoder) (*RevertError, error)
 }
 
 // GetTransaction returns the transaction with the given hash.
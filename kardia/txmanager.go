@@ -0,0 +1,236 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package kardia
+package kardia
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kardiachain/go-kardia"
+	"github.com/kardiachain/go-kardia/lib/abi/bind"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/types"
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultNonceGapTimeout is how long a sent transaction may be missing from the pool
+	// before its nonce is considered dropped and reused
+	defaultNonceGapTimeout = 30 * time.Second
+	maxNonceTooLowRetries  = 3
+
+	nonceTooLowMsg = "nonce too low"
+)
+
+// TxManager builds, signs and sends transactions with a locally managed nonce per account,
+// so many goroutines can send from the same wallet without duplicate nonces.
+// Sends from one account are serialized, sends from different accounts run concurrently.
+type TxManager struct {
+	node Node
+	lgr  *zap.Logger
+
+	// mu guards gapTimeout and accounts
+	mu         sync.Mutex
+	gapTimeout time.Duration
+	accounts   map[common.Address]*accountNonce
+}
+
+type accountNonce struct {
+	mu sync.Mutex
+	// next is the nonce of the next new transaction
+	next uint64
+	// sent holds when transactions not yet seen in the pool were sent, by nonce
+	sent map[uint64]time.Time
+	// replaced holds transactions competing for a nonce after SpeedUp or Cancel
+	replaced map[uint64][]*types.Transaction
+}
+
+func NewTxManager(n Node, lgr *zap.Logger) *TxManager {
+	if lgr == nil {
+		lgr = zap.L()
+	}
+	return &TxManager{
+		node:       n,
+		lgr:        lgr,
+		gapTimeout: defaultNonceGapTimeout,
+		accounts:   make(map[common.Address]*accountNonce),
+	}
+}
+
+// SetNonceGapTimeout changes how long a sent transaction may be missing from the pool before
+// its nonce is reused, default 30s.
+func (m *TxManager) SetNonceGapTimeout(timeout time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.gapTimeout = timeout
+}
+
+func (m *TxManager) nonceGapTimeout() time.Duration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.gapTimeout
+}
+
+func (m *TxManager) account(address common.Address) *accountNonce {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	acc, ok := m.accounts[address]
+	if !ok {
+		acc = &accountNonce{
+			sent:     make(map[uint64]time.Time),
+			replaced: make(map[uint64][]*types.Transaction),
+		}
+		m.accounts[address] = acc
+	}
+	return acc
+}
+
+// Nonce returns the nonce the next transaction of account will be sent with, based on local state
+func (m *TxManager) Nonce(account common.Address) uint64 {
+	acc := m.account(account)
+	acc.mu.Lock()
+	defer acc.mu.Unlock()
+	return acc.next
+}
+
+// Resync drops the local nonce of account and reloads it from the node pending pool
+func (m *TxManager) Resync(ctx context.Context, account common.Address) error {
+	acc := m.account(account)
+	acc.mu.Lock()
+	defer acc.mu.Unlock()
+	nonce, err := m.node.PendingNonceAt(ctx, account)
+	if err != nil {
+		return err
+	}
+	acc.next = nonce
+	acc.sent = make(map[uint64]time.Time)
+	return nil
+}
+
+// Transfer sends opts.Value KAI from opts.From to to
+func (m *TxManager) Transfer(opts *bind.TransactOpts, to common.Address) (*types.Transaction, error) {
+	return m.Transact(opts, &to, nil)
+}
+
+// Transact sends a transaction from opts.From with the next managed nonce, opts.Nonce is ignored.
+// A nil to creates a contract. Missing gas price and gas limit are filled by the node.
+// Sends rejected with "nonce too low" are retried with a resynced nonce.
+func (m *TxManager) Transact(opts *bind.TransactOpts, to *common.Address, data []byte) (*types.Transaction, error) {
+	if opts.Signer == nil {
+		return nil, errors.New("no signer to authorize the transaction with")
+	}
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	value := opts.Value
+	if value == nil {
+		value = new(big.Int)
+	}
+	gasPrice := opts.GasPrice
+	if gasPrice == nil {
+		price, err := m.node.SuggestGasPrice(ctx)
+		if err != nil {
+			return nil, err
+		}
+		gasPrice = new(big.Int).SetUint64(price)
+	}
+	gasLimit := opts.GasLimit
+	if gasLimit == 0 {
+		msg := kardia.CallMsg{From: opts.From, To: to, GasPrice: gasPrice, Value: value, Data: data}
+		gas, err := m.node.EstimateGas(ctx, msg)
+		if err != nil {
+			return nil, err
+		}
+		gasLimit = gas
+	}
+
+	acc := m.account(opts.From)
+	acc.mu.Lock()
+	defer acc.mu.Unlock()
+	for retry := 0; ; retry++ {
+		nonce, err := m.nextNonce(ctx, opts.From, acc)
+		if err != nil {
+			return nil, err
+		}
+		var rawTx *types.Transaction
+		if to == nil {
+			rawTx = types.NewContractCreation(nonce, value, gasLimit, gasPrice, data)
+		} else {
+			rawTx = types.NewTransaction(nonce, *to, value, gasLimit, gasPrice, data)
+		}
+		signedTx, err := opts.Signer(types.HomesteadSigner{}, opts.From, rawTx)
+		if err != nil {
+			return nil, err
+		}
+		err = m.node.SendRawTransaction(ctx, signedTx)
+		if err == nil {
+			acc.sent[nonce] = time.Now()
+			if nonce >= acc.next {
+				acc.next = nonce + 1
+			}
+			return signedTx, nil
+		}
+		if !isNonceTooLow(err) || retry >= maxNonceTooLowRetries {
+			// the nonce is not consumed, next send reuses it
+			m.lgr.Error("Cannot send transaction", zap.String("from", opts.From.Hex()),
+				zap.Uint64("nonce", nonce), zap.Error(err))
+			return nil, err
+		}
+		m.lgr.Warn("Nonce too low, resyncing", zap.String("from", opts.From.Hex()), zap.Uint64("nonce", nonce))
+		delete(acc.sent, nonce)
+		if nonce >= acc.next {
+			acc.next = nonce + 1
+		}
+	}
+}
+
+// nextNonce returns the nonce of the next transaction of account. The node pending nonce is the
+// first nonce missing from the pool: a higher value means transactions were sent outside of
+// the manager, a lower one a gap left by a dropped transaction, which is filled first.
+func (m *TxManager) nextNonce(ctx context.Context, account common.Address, acc *accountNonce) (uint64, error) {
+	pending, err := m.node.PendingNonceAt(ctx, account)
+	if err != nil {
+		return 0, err
+	}
+	for nonce := range acc.sent {
+		if nonce < pending {
+			delete(acc.sent, nonce)
+		}
+	}
+	if pending >= acc.next {
+		acc.next = pending
+		return pending, nil
+	}
+	// a transaction sent recently may not have reached the pool yet
+	if sentAt, ok := acc.sent[pending]; ok && time.Since(sentAt) < m.nonceGapTimeout() {
+		return acc.next, nil
+	}
+	m.lgr.Warn("Filling nonce gap", zap.String("account", account.Hex()), zap.Uint64("nonce", pending),
+		zap.Uint64("next", acc.next))
+	return pending, nil
+}
+
+func isNonceTooLow(err error) bool {
+	return err != nil && strings.Contains(err.Error(), nonceTooLowMsg)
+}
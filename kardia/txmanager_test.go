@@ -0,0 +1,230 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package kardia
+package kardia
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/kardiachain/go-kardia/lib/abi/bind"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/rlp"
+	"github.com/kardiachain/go-kardia/rpc"
+	"github.com/kardiachain/go-kardia/types"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// mockTxPoolAPI is a single account tx pool, mined nonces are below mined
+type mockTxPoolAPI struct {
+	mu    sync.Mutex
+	mined uint64
+	pool  map[uint64]*types.Transaction
+	// receipts of mined transactions
+	receipts map[common.Hash]*Receipt
+	// hidden hides pool transactions from Nonce
+	hidden bool
+}
+
+func newMockTxPoolAPI() *mockTxPoolAPI {
+	return &mockTxPoolAPI{pool: make(map[uint64]*types.Transaction), receipts: make(map[common.Hash]*Receipt)}
+}
+
+// Nonce returns the first nonce missing from the pool
+func (api *mockTxPoolAPI) Nonce(address common.Address) uint64 {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	nonce := api.mined
+	for !api.hidden {
+		if _, ok := api.pool[nonce]; !ok {
+			return nonce
+		}
+		nonce++
+	}
+	return nonce
+}
+
+// NonceAtHeight returns the mined nonce, the chain has a single block
+func (api *mockTxPoolAPI) NonceAtHeight(address common.Address, height rpc.BlockHeight) uint64 {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	return api.mined
+}
+
+func (api *mockTxPoolAPI) BlockNumber() uint64 {
+	return 1
+}
+
+func (api *mockTxPoolAPI) SendRawTransaction(raw string) (string, error) {
+	data, err := hexutil.Decode(raw)
+	if err != nil {
+		return "", err
+	}
+	tx := new(types.Transaction)
+	if err := rlp.DecodeBytes(data, tx); err != nil {
+		return "", err
+	}
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	if tx.Nonce() < api.mined {
+		return "", errors.New(nonceTooLowMsg)
+	}
+	if old, ok := api.pool[tx.Nonce()]; ok {
+		if old.Hash() == tx.Hash() {
+			return "", errors.New("known transaction")
+		}
+		min := new(big.Int).Mul(old.GasPrice(), big.NewInt(110))
+		if new(big.Int).Mul(tx.GasPrice(), big.NewInt(100)).Cmp(min) < 0 {
+			return "", errors.New("replacement transaction underpriced")
+		}
+	}
+	api.pool[tx.Nonce()] = tx
+	return tx.Hash().Hex(), nil
+}
+
+func (api *mockTxPoolAPI) GetTransactionReceipt(hash common.Hash) *Receipt {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	return api.receipts[hash]
+}
+
+// mine mines the pool transaction with the given nonce
+func (api *mockTxPoolAPI) mine(nonce uint64) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	tx := api.pool[nonce]
+	delete(api.pool, nonce)
+	api.mined = nonce + 1
+	api.receipts[tx.Hash()] = &Receipt{TransactionHash: tx.Hash().Hex(), Status: ReceiptStatusSuccessful}
+}
+
+func setupMockTxManager(t *testing.T) (*TxManager, *mockTxPoolAPI, *bind.TransactOpts) {
+	api := newMockTxPoolAPI()
+	n, err := setupMockNodeInstance(map[string]interface{}{"account": api, "tx": api, "kai": api})
+	assert.Nil(t, err)
+	_, privateKey, err := setupTestAccount()
+	assert.Nil(t, err)
+	auth := NewKeyedTransactor(privateKey)
+	auth.GasPrice = big.NewInt(1000000000)
+	auth.GasLimit = 21000
+	return NewTxManager(n, zap.NewNop()), api, auth
+}
+
+func TestTxManager_Concurrent(t *testing.T) {
+	m, api, auth := setupMockTxManager(t)
+	to := common.HexToAddress("0x59173FAF22C3fEd212Ec6B5Ea2E50f7644b614f3")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := m.Transfer(auth, to)
+			assert.Nil(t, err)
+		}()
+	}
+	wg.Wait()
+	assert.Len(t, api.pool, 20)
+	assert.Equal(t, uint64(20), m.Nonce(auth.From))
+}
+
+func TestTxManager_NonceTooLow(t *testing.T) {
+	m, api, auth := setupMockTxManager(t)
+	to := common.HexToAddress("0x59173FAF22C3fEd212Ec6B5Ea2E50f7644b614f3")
+	m.SetNonceGapTimeout(time.Hour)
+
+	tx, err := m.Transfer(auth, to)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0), tx.Nonce())
+
+	// the transaction is mined and the pool forgets it, while a stale node still reports it pending
+	api.mu.Lock()
+	api.mined = 5
+	delete(api.pool, 0)
+	api.mu.Unlock()
+	tx, err = m.Transfer(auth, to)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(5), tx.Nonce())
+}
+
+func TestTxManager_NonceGap(t *testing.T) {
+	m, api, auth := setupMockTxManager(t)
+	to := common.HexToAddress("0x59173FAF22C3fEd212Ec6B5Ea2E50f7644b614f3")
+
+	for i := 0; i < 3; i++ {
+		_, err := m.Transfer(auth, to)
+		assert.Nil(t, err)
+	}
+	// nonce 1 is dropped from the pool
+	api.mu.Lock()
+	delete(api.pool, 1)
+	api.mu.Unlock()
+
+	tx, err := m.Transfer(auth, to)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), tx.Nonce(), "gap is filled first")
+	tx, err = m.Transfer(auth, to)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(3), tx.Nonce())
+}
+
+func TestTxManager_NonceGapTimeout(t *testing.T) {
+	m, api, auth := setupMockTxManager(t)
+	to := common.HexToAddress("0x59173FAF22C3fEd212Ec6B5Ea2E50f7644b614f3")
+	// the node does not see sent transactions yet
+	api.hidden = true
+
+	for i := 0; i < 3; i++ {
+		tx, err := m.Transfer(auth, to)
+		assert.Nil(t, err)
+		assert.Equal(t, uint64(i), tx.Nonce())
+	}
+
+	m.SetNonceGapTimeout(0)
+	api.mu.Lock()
+	delete(api.pool, 0)
+	api.mu.Unlock()
+	tx, err := m.Transfer(auth, to)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0), tx.Nonce())
+}
+
+func TestTxManager_ConcurrentNonceGapTimeout(t *testing.T) {
+	m, _, auth := setupMockTxManager(t)
+	to := common.HexToAddress("0x59173FAF22C3fEd212Ec6B5Ea2E50f7644b614f3")
+
+	// the timeout may change while transactions are sent, run with -race
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 10; i++ {
+			m.SetNonceGapTimeout(time.Duration(i) * time.Second)
+		}
+	}()
+	for i := 0; i < 10; i++ {
+		_, err := m.Transfer(auth, to)
+		assert.Nil(t, err)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,207 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package kardia
+package kardia
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/kardiachain/go-kardia"
+	"github.com/kardiachain/go-kardia/lib/abi/bind"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/types"
+	"go.uber.org/zap"
+)
+
+const (
+	// MinPriceBump is the minimum gas price increase, in percent, for the pool to accept a replacement
+	MinPriceBump uint64 = 10
+
+	transferGas uint64 = 21000
+)
+
+// SpeedUp replaces the pending tx with a copy sent with gasPrice. A nil gasPrice bumps
+// the original gas price by MinPriceBump percent.
+func (m *TxManager) SpeedUp(opts *bind.TransactOpts, tx *types.Transaction, gasPrice *big.Int) (*types.Transaction, error) {
+	gasPrice, err := replacementGasPrice(tx, gasPrice)
+	if err != nil {
+		return nil, err
+	}
+	var replacement *types.Transaction
+	if tx.To() == nil {
+		replacement = types.NewContractCreation(tx.Nonce(), tx.Value(), tx.Gas(), gasPrice, tx.Data())
+	} else {
+		replacement = types.NewTransaction(tx.Nonce(), *tx.To(), tx.Value(), tx.Gas(), gasPrice, tx.Data())
+	}
+	return m.replace(opts, tx, replacement)
+}
+
+// Cancel replaces the pending tx with a zero value transfer to opts.From, sent with gasPrice.
+// A nil gasPrice bumps the original gas price by MinPriceBump percent.
+func (m *TxManager) Cancel(opts *bind.TransactOpts, tx *types.Transaction, gasPrice *big.Int) (*types.Transaction, error) {
+	gasPrice, err := replacementGasPrice(tx, gasPrice)
+	if err != nil {
+		return nil, err
+	}
+	replacement := types.NewTransaction(tx.Nonce(), opts.From, big.NewInt(0), transferGas, gasPrice, nil)
+	return m.replace(opts, tx, replacement)
+}
+
+func (m *TxManager) replace(opts *bind.TransactOpts, tx *types.Transaction, replacement *types.Transaction) (*types.Transaction, error) {
+	if opts.Signer == nil {
+		return nil, errors.New("no signer to authorize the transaction with")
+	}
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	signedTx, err := opts.Signer(types.HomesteadSigner{}, opts.From, replacement)
+	if err != nil {
+		return nil, err
+	}
+
+	acc := m.account(opts.From)
+	acc.mu.Lock()
+	defer acc.mu.Unlock()
+	if err := m.node.SendRawTransaction(ctx, signedTx); err != nil {
+		if isNonceTooLow(err) {
+			return nil, ErrNonceAlreadyMined
+		}
+		m.lgr.Error("Cannot replace transaction", zap.String("hash", tx.Hash().Hex()), zap.Error(err))
+		return nil, err
+	}
+	m.lgr.Info("Transaction replaced", zap.String("hash", tx.Hash().Hex()),
+		zap.String("replacement", signedTx.Hash().Hex()), zap.Uint64("nonce", tx.Nonce()))
+	if _, ok := acc.sent[tx.Nonce()]; ok {
+		acc.sent[tx.Nonce()] = time.Now()
+	}
+	competing := acc.replaced[tx.Nonce()]
+	if len(competing) == 0 {
+		competing = append(competing, tx)
+	}
+	acc.replaced[tx.Nonce()] = append(competing, signedTx)
+	return signedTx, nil
+}
+
+// Replacements returns tx and every transaction sent by SpeedUp or Cancel to replace it,
+// in the order they were sent
+func (m *TxManager) Replacements(tx *types.Transaction) ([]*types.Transaction, error) {
+	from, err := types.Sender(types.HomesteadSigner{}, tx)
+	if err != nil {
+		return nil, err
+	}
+	acc := m.account(from)
+	acc.mu.Lock()
+	defer acc.mu.Unlock()
+	if competing, ok := acc.replaced[tx.Nonce()]; ok {
+		return append([]*types.Transaction{}, competing...), nil
+	}
+	return []*types.Transaction{tx}, nil
+}
+
+// WaitMined waits until tx or one of its replacements is mined, then returns the mined
+// transaction and its receipt. A *TxFailedError is returned with them if the execution failed,
+// and ErrNonceConsumed if a transaction sent without the manager is mined with the nonce instead.
+func (m *TxManager) WaitMined(ctx context.Context, tx *types.Transaction) (*types.Transaction, *Receipt, error) {
+	from, err := types.Sender(types.HomesteadSigner{}, tx)
+	if err != nil {
+		return nil, nil, err
+	}
+	ticker := time.NewTicker(receiptPollInterval)
+	defer ticker.Stop()
+	for {
+		competing, err := m.Replacements(tx)
+		if err != nil {
+			return nil, nil, err
+		}
+		// the nonce is read before the receipts, so a consumed nonce without receipt is not one of ours
+		consumed, err := m.nonceConsumed(ctx, from, tx.Nonce())
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, candidate := range competing {
+			receipt, err := m.node.GetTransactionReceipt(ctx, candidate.Hash().Hex())
+			if errors.Is(err, kardia.NotFound) {
+				continue
+			}
+			if err != nil {
+				return nil, nil, err
+			}
+			m.forgetReplacements(tx)
+			if receipt.Status != ReceiptStatusSuccessful {
+				return candidate, receipt, &TxFailedError{Receipt: receipt}
+			}
+			return candidate, receipt, nil
+		}
+		if consumed {
+			m.forgetReplacements(tx)
+			m.lgr.Warn("Nonce consumed by unknown transaction", zap.String("hash", tx.Hash().Hex()), zap.Uint64("nonce", tx.Nonce()))
+			return nil, nil, ErrNonceConsumed
+		}
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// nonceConsumed returns true if a transaction of from with nonce is mined in the latest block
+func (m *TxManager) nonceConsumed(ctx context.Context, from common.Address, nonce uint64) (bool, error) {
+	latest, err := m.node.LatestBlockNumber(ctx)
+	if err != nil || latest == 0 {
+		return false, err
+	}
+	mined, err := m.node.NonceAt(WithBlock(ctx, BlockAtHeight(latest)), from.Hex())
+	if err != nil {
+		return false, err
+	}
+	return mined > nonce, nil
+}
+
+func (m *TxManager) forgetReplacements(tx *types.Transaction) {
+	from, err := types.Sender(types.HomesteadSigner{}, tx)
+	if err != nil {
+		return
+	}
+	acc := m.account(from)
+	acc.mu.Lock()
+	defer acc.mu.Unlock()
+	delete(acc.replaced, tx.Nonce())
+}
+
+// replacementGasPrice returns gasPrice if it is high enough to replace tx, or the minimum
+// accepted replacement gas price if gasPrice is nil
+func replacementGasPrice(tx *types.Transaction, gasPrice *big.Int) (*big.Int, error) {
+	min := new(big.Int).Mul(tx.GasPrice(), new(big.Int).SetUint64(100+MinPriceBump))
+	min.Div(min, big.NewInt(100))
+	if min.Cmp(tx.GasPrice()) <= 0 {
+		min.Add(tx.GasPrice(), big.NewInt(1))
+	}
+	if gasPrice == nil {
+		return min, nil
+	}
+	if gasPrice.Cmp(min) < 0 {
+		return nil, fmt.Errorf("%w: got %s, need at least %s", ErrReplacementUnderpriced, gasPrice, min)
+	}
+	return gasPrice, nil
+}
@@ -0,0 +1,102 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package kardia
+package kardia
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTxReplace_SpeedUpAndCancel(t *testing.T) {
+	receiptPollInterval = 10 * time.Millisecond
+	defer func() { receiptPollInterval = time.Second }()
+	m, api, auth := setupMockTxManager(t)
+	to := common.HexToAddress("0x59173FAF22C3fEd212Ec6B5Ea2E50f7644b614f3")
+	auth.Value = big.NewInt(1000)
+
+	tx, err := m.Transfer(auth, to)
+	assert.Nil(t, err)
+
+	_, err = m.SpeedUp(auth, tx, big.NewInt(1050000000))
+	assert.True(t, errors.Is(err, ErrReplacementUnderpriced))
+
+	fast, err := m.SpeedUp(auth, tx, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, tx.Nonce(), fast.Nonce())
+	assert.Equal(t, big.NewInt(1100000000), fast.GasPrice())
+	assert.Equal(t, tx.Value(), fast.Value())
+
+	cancel, err := m.Cancel(auth, fast, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, auth.From, *cancel.To())
+	assert.Equal(t, int64(0), cancel.Value().Int64())
+	assert.Equal(t, big.NewInt(1210000000), cancel.GasPrice())
+
+	competing, err := m.Replacements(tx)
+	assert.Nil(t, err)
+	assert.Len(t, competing, 3)
+
+	ctx, stop := context.WithTimeout(context.Background(), 10*time.Second)
+	defer stop()
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		api.mine(tx.Nonce())
+	}()
+	mined, receipt, err := m.WaitMined(ctx, tx)
+	assert.Nil(t, err)
+	assert.Equal(t, cancel.Hash(), mined.Hash())
+	assert.Equal(t, cancel.Hash().Hex(), receipt.TransactionHash)
+
+	_, err = m.SpeedUp(auth, tx, nil)
+	assert.True(t, errors.Is(err, ErrNonceAlreadyMined))
+}
+
+func TestTxReplace_NonceConsumed(t *testing.T) {
+	receiptPollInterval = 10 * time.Millisecond
+	defer func() { receiptPollInterval = time.Second }()
+	m, api, auth := setupMockTxManager(t)
+	to := common.HexToAddress("0x59173FAF22C3fEd212Ec6B5Ea2E50f7644b614f3")
+
+	tx, err := m.Transfer(auth, to)
+	assert.Nil(t, err)
+	_, err = m.SpeedUp(auth, tx, nil)
+	assert.Nil(t, err)
+
+	// a transaction sent by another client is mined with the nonce
+	ctx, stop := context.WithTimeout(context.Background(), 10*time.Second)
+	defer stop()
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		api.mu.Lock()
+		delete(api.pool, tx.Nonce())
+		api.mined = tx.Nonce() + 1
+		api.mu.Unlock()
+	}()
+	_, _, err = m.WaitMined(ctx, tx)
+	assert.Equal(t, ErrNonceConsumed, err)
+	competing, err := m.Replacements(tx)
+	assert.Nil(t, err)
+	assert.Len(t, competing, 1)
+}
@@ -144,7 +144,12 @@ type SMCCallArgs struct {
 }
 
 type Receipt struct {
+	BlockHash         string      `json:"blockHash"`
+	BlockHeight       uint64      `json:"blockHeight"`
 	TransactionHash   string      `json:"transactionHash"`
+	TransactionIndex  uint64      `json:"transactionIndex"`
+	From              string      `json:"from"`
+	To                string      `json:"to"`
 	GasUsed           uint64      `json:"gasUsed"`
 	CumulativeGasUsed uint64      `json:"cumulativeGasUsed"`
 	ContractAddress   string      `json:"contractAddress"`
@@ -249,6 +254,9 @@ type FilterLogs struct {
 	TransactionIndex int64    `json:"transactionIndex"`
 }
 
+// ValidatorsByDelegator is the delegation of a delegator to a validator. UnbondedRecords are the entries
+// still unbonding and WithdrawableRecords the matured ones. TotalWithdrawableAmount adds the claimable
+// rewards to the withdrawable amount, TotalUnbondedAmount adds the withdrawable amount to the unbonded one.
 type ValidatorsByDelegator struct {
 	Name                    string            `json:"name"`
 	Validator               common.Address    `json:"validator"`
@@ -258,6 +266,7 @@ type ValidatorsByDelegator struct {
 	StakedAmount            string            `json:"stakedAmount"`
 	ClaimableRewards        string            `json:"claimableRewards"`
 	UnbondedRecords         []*UnbondedRecord `json:"unbondedRecords"`
+	WithdrawableRecords     []*UnbondedRecord `json:"withdrawableRecords"`
 	TotalWithdrawableAmount string            `json:"totalWithdrawableAmount"`
 	TotalUnbondedAmount     string            `json:"totalUnbondedAmount"`
 	UnbondedAmount          string            `json:"unbondedAmount"`
@@ -265,8 +274,32 @@ type ValidatorsByDelegator struct {
 }
 
 type UnbondedRecord struct {
-	Balances        []*big.Int `json:"balances"`
-	CompletionTimes []*big.Int `json:"completionTimes"`
+	Balances        []*big.Int `json:"balances" abi:"amounts"`
+	CompletionTimes []*big.Int `json:"completionTimes" abi:"completionTimes"`
+}
+
+// Split splits the entries into the ones matured at now, which can be withdrawn, and the pending ones.
+// The contract withdraws the entries completed before the block time.
+func (r *UnbondedRecord) Split(now time.Time) (matured, pending *UnbondedRecord) {
+	matured, pending = &UnbondedRecord{}, &UnbondedRecord{}
+	for i, completionTime := range r.CompletionTimes {
+		record := pending
+		if completionTime.Cmp(big.NewInt(now.Unix())) < 0 {
+			record = matured
+		}
+		record.Balances = append(record.Balances, r.Balances[i])
+		record.CompletionTimes = append(record.CompletionTimes, completionTime)
+	}
+	return matured, pending
+}
+
+// Total returns the sum of the balances of the entries
+func (r *UnbondedRecord) Total() *big.Int {
+	total := new(big.Int)
+	for _, balance := range r.Balances {
+		total.Add(total, balance)
+	}
+	return total
 }
 
 type DelegatorWithShare struct {
@@ -0,0 +1,403 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package kardia
+package kardia
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/event"
+	"github.com/kardiachain/go-kardia/types"
+	"go.uber.org/zap"
+)
+
+const (
+	UptimeOK UptimeStatus = iota
+	// UptimeAtRisk is the status of a validator which missed AlertRatio of the blocks it may miss in its window
+	UptimeAtRisk
+	// UptimeJailable is the status of a validator which missed more blocks than allowed by MinSignedPerWindow,
+	// the staking contract jails it once it signed a full window since it started
+	UptimeJailable
+)
+
+const defaultUptimeInterval = 5 * time.Second
+
+// defaultUptimeAlertRatio is 80% in 1e18 units
+var defaultUptimeAlertRatio = big.NewInt(8e17)
+
+type UptimeStatus int32
+
+func (s UptimeStatus) String() string {
+	switch s {
+	case UptimeAtRisk:
+		return "at risk"
+	case UptimeJailable:
+		return "jailable"
+	default:
+		return "ok"
+	}
+}
+
+// IUptime reads the validator signatures of the block commits
+type IUptime interface {
+	// BlockSignatures returns the validators of the block, in the order of the validator set,
+	// and whether they signed its commit
+	BlockSignatures(ctx context.Context, height uint64) ([]*BlockSignature, error)
+	// Uptime returns the signed and missed blocks of the validators of blocks fromBlock to toBlock,
+	// in the order they joined the validator set
+	Uptime(ctx context.Context, fromBlock, toBlock uint64) ([]*ValidatorUptime, error)
+}
+
+// BlockSignature is the signature of a block by a validator, Validator is its signer address
+type BlockSignature struct {
+	Validator   common.Address
+	VotingPower int64
+	Signed      bool
+}
+
+// ValidatorUptime is the signing record of a validator over a range of blocks. Blocks counts
+// the blocks of the range where the validator was in the validator set.
+type ValidatorUptime struct {
+	Validator     common.Address
+	Blocks        uint64
+	Signed        uint64
+	MissedHeights []uint64
+}
+
+// Missed returns the number of blocks the validator did not sign
+func (u *ValidatorUptime) Missed() uint64 {
+	return uint64(len(u.MissedHeights))
+}
+
+// Ratio returns the share of the blocks signed by the validator in 1e18 units
+func (u *ValidatorUptime) Ratio() *big.Int {
+	if u.Blocks == 0 {
+		return new(big.Int)
+	}
+	return divDec(new(big.Int).SetUint64(u.Signed), new(big.Int).SetUint64(u.Blocks))
+}
+
+func (n *node) BlockSignatures(ctx context.Context, height uint64) ([]*BlockSignature, error) {
+	validators, err := n.GetValidators(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	commit, err := n.GetCommit(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	return commitSignatures(commit, validators)
+}
+
+func (n *node) Uptime(ctx context.Context, fromBlock, toBlock uint64) ([]*ValidatorUptime, error) {
+	if fromBlock > toBlock {
+		return nil, ErrInvalidBlockRange
+	}
+	var (
+		uptimes     []*ValidatorUptime
+		byValidator = make(map[common.Address]*ValidatorUptime)
+	)
+	for height := fromBlock; height <= toBlock; height++ {
+		signatures, err := n.BlockSignatures(ctx, height)
+		if err != nil {
+			return nil, err
+		}
+		for _, sig := range signatures {
+			uptime, ok := byValidator[sig.Validator]
+			if !ok {
+				uptime = &ValidatorUptime{Validator: sig.Validator}
+				byValidator[sig.Validator] = uptime
+				uptimes = append(uptimes, uptime)
+			}
+			uptime.Blocks++
+			if sig.Signed {
+				uptime.Signed++
+			} else {
+				uptime.MissedHeights = append(uptime.MissedHeights, height)
+			}
+		}
+	}
+	return uptimes, nil
+}
+
+// commitSignatures maps the signatures of commit to validators, they are in the order of the validator set.
+// Like the staking contract, a validator signed the block unless its signature is absent.
+func commitSignatures(commit *types.Commit, validators *types.ValidatorSet) ([]*BlockSignature, error) {
+	if len(commit.Signatures) != validators.Size() {
+		return nil, fmt.Errorf("%w: %d signatures for %d validators at block %d",
+			ErrInvalidCommit, len(commit.Signatures), validators.Size(), commit.Height)
+	}
+	signatures := make([]*BlockSignature, validators.Size())
+	for i, val := range validators.Validators {
+		sig := commit.Signatures[i]
+		if !sig.Absent() && sig.ValidatorAddress != val.Address {
+			return nil, fmt.Errorf("%w: signature %d of block %d is from %s, not %s",
+				ErrInvalidCommit, i, commit.Height, sig.ValidatorAddress.Hex(), val.Address.Hex())
+		}
+		signatures[i] = &BlockSignature{
+			Validator:   val.Address,
+			VotingPower: val.VotingPower,
+			Signed:      !sig.Absent(),
+		}
+	}
+	return signatures, nil
+}
+
+// SigningWindow is the sliding window of the latest blocks a validator had to sign
+type SigningWindow struct {
+	Validator common.Address
+	Status    UptimeStatus
+	// Height is the latest block of the window
+	Height uint64
+	// Blocks is the number of blocks in the window, it grows up to the window size
+	Blocks uint64
+	Missed uint64
+	// MaxMissed is the number of blocks of a full window the validator may miss without being jailed
+	MaxMissed uint64
+}
+
+type UptimeConfig struct {
+	// Interval between two polls of the latest block, default 5s
+	Interval time.Duration
+	// FromBlock is the first monitored block, 0 starts at the latest block
+	FromBlock uint64
+	// Window is the number of blocks of the sliding window, SignedBlockWindow of the chain params by default
+	Window uint64
+	// MinSignedPerWindow is the share of the window a validator has to sign in 1e18 units,
+	// MinSignedPerWindow of the chain params by default
+	MinSignedPerWindow *big.Int
+	// AlertRatio is the share of MaxMissed a validator misses before it is at risk in 1e18 units, 80% by default
+	AlertRatio *big.Int
+
+	Logger *zap.Logger
+}
+
+// signingWindow is a ring of the missed blocks of a validator. Like the staking contract, the window
+// only moves at the blocks of the validator.
+type signingWindow struct {
+	SigningWindow
+	missed []bool
+	offset uint64
+}
+
+func (w *signingWindow) add(height uint64, signed bool) {
+	index := w.offset % uint64(len(w.missed))
+	w.offset++
+	if w.missed[index] {
+		w.Missed--
+	}
+	w.missed[index] = !signed
+	if !signed {
+		w.Missed++
+	}
+	w.Height = height
+	if w.Blocks < uint64(len(w.missed)) {
+		w.Blocks++
+	}
+}
+
+// UptimeMonitor walks the commits of every block from UptimeConfig.FromBlock and keeps a sliding window
+// of the signed and missed blocks of each validator. It notifies the validators whose status changes as they
+// approach the jail threshold of the staking contract. The windows are not reset when a validator is jailed.
+type UptimeMonitor struct {
+	cfg  UptimeConfig
+	node Node
+
+	// syncMu serializes Sync so concurrent calls cannot add the same heights twice
+	syncMu     sync.Mutex
+	mu         sync.RWMutex
+	next       uint64
+	maxMissed  uint64
+	alertLevel uint64
+	windows    map[common.Address]*signingWindow
+	validators []common.Address
+
+	feed event.Feed
+	quit chan struct{}
+	once sync.Once
+}
+
+func NewUptimeMonitor(cfg UptimeConfig, node Node) *UptimeMonitor {
+	if cfg.Interval == 0 {
+		cfg.Interval = defaultUptimeInterval
+	}
+	if cfg.AlertRatio == nil {
+		cfg.AlertRatio = defaultUptimeAlertRatio
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = zap.L()
+	}
+	return &UptimeMonitor{
+		cfg:     cfg,
+		node:    node,
+		windows: make(map[common.Address]*signingWindow),
+		quit:    make(chan struct{}),
+	}
+}
+
+// Start syncs the monitor immediately then every Interval in background until Stop is called
+func (m *UptimeMonitor) Start() {
+	go func() {
+		ticker := time.NewTicker(m.cfg.Interval)
+		defer ticker.Stop()
+		for {
+			if err := m.Sync(context.Background()); err != nil {
+				m.cfg.Logger.Warn("Cannot sync uptime", zap.Error(err))
+			}
+			select {
+			case <-ticker.C:
+			case <-m.quit:
+				return
+			}
+		}
+	}()
+}
+
+// Stop ends the background sync
+func (m *UptimeMonitor) Stop() {
+	m.once.Do(func() { close(m.quit) })
+}
+
+// SubscribeUptimeAlerts delivers the window of a validator every time its status changes
+func (m *UptimeMonitor) SubscribeUptimeAlerts(ch chan<- SigningWindow) event.Subscription {
+	return m.feed.Subscribe(ch)
+}
+
+// Windows returns the window of every validator seen by the monitor, in the order they were seen
+func (m *UptimeMonitor) Windows() []SigningWindow {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	windows := make([]SigningWindow, 0, len(m.validators))
+	for _, validator := range m.validators {
+		windows = append(windows, m.windows[validator].SigningWindow)
+	}
+	return windows
+}
+
+// Window returns the window of the validator with the given signer address
+func (m *UptimeMonitor) Window(validator common.Address) (SigningWindow, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if w, ok := m.windows[validator]; ok {
+		return w.SigningWindow, true
+	}
+	return SigningWindow{}, false
+}
+
+// Sync adds the blocks mined since the previous sync to the windows. A block which cannot be read
+// is retried by the next sync. Concurrent calls, including the background sync of Start, run one at a time.
+func (m *UptimeMonitor) Sync(ctx context.Context) error {
+	m.syncMu.Lock()
+	defer m.syncMu.Unlock()
+	latest, err := m.node.LatestBlockNumber(ctx)
+	if err != nil {
+		return err
+	}
+	from, err := m.init(ctx, latest)
+	if err != nil {
+		return err
+	}
+	for height := from; height <= latest; height++ {
+		signatures, err := m.node.BlockSignatures(ctx, height)
+		if err != nil {
+			return err
+		}
+		for _, w := range m.add(height, signatures) {
+			if w.Status == UptimeOK {
+				m.cfg.Logger.Info("Validator uptime recovered", zap.String("validator", w.Validator.Hex()),
+					zap.Uint64("missed", w.Missed), zap.Uint64("height", w.Height))
+			} else {
+				m.cfg.Logger.Warn("Validator is missing blocks", zap.String("validator", w.Validator.Hex()),
+					zap.Stringer("status", w.Status), zap.Uint64("missed", w.Missed),
+					zap.Uint64("maxMissed", w.MaxMissed), zap.Uint64("height", w.Height))
+			}
+			m.feed.Send(w)
+		}
+	}
+	return nil
+}
+
+// init reads the window parameters missing from the config on the first sync and returns the next block to add
+func (m *UptimeMonitor) init(ctx context.Context, latest uint64) (uint64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.next != 0 {
+		return m.next, nil
+	}
+	window, minSigned := m.cfg.Window, m.cfg.MinSignedPerWindow
+	if window == 0 || minSigned == nil {
+		params, err := m.node.ChainParams(ctx)
+		if err != nil {
+			return 0, err
+		}
+		if window == 0 {
+			window = params.SignedBlockWindow
+		}
+		if minSigned == nil {
+			minSigned = params.MinSignedPerWindow
+		}
+	}
+	m.cfg.Window, m.cfg.MinSignedPerWindow = window, minSigned
+	m.maxMissed = window - mulDec(new(big.Int).SetUint64(window), minSigned).Uint64()
+	m.alertLevel = mulDec(new(big.Int).SetUint64(m.maxMissed), m.cfg.AlertRatio).Uint64()
+	if m.alertLevel == 0 {
+		m.alertLevel = 1
+	}
+	m.next = m.cfg.FromBlock
+	if m.next == 0 {
+		m.next = latest
+	}
+	return m.next, nil
+}
+
+// add moves the windows of the validators of the block and returns those whose status changed
+func (m *UptimeMonitor) add(height uint64, signatures []*BlockSignature) []SigningWindow {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var changes []SigningWindow
+	for _, sig := range signatures {
+		w, ok := m.windows[sig.Validator]
+		if !ok {
+			w = &signingWindow{
+				SigningWindow: SigningWindow{Validator: sig.Validator, MaxMissed: m.maxMissed},
+				missed:        make([]bool, m.cfg.Window),
+			}
+			m.windows[sig.Validator] = w
+			m.validators = append(m.validators, sig.Validator)
+		}
+		w.add(height, sig.Signed)
+		status := UptimeOK
+		switch {
+		case w.Missed > m.maxMissed:
+			status = UptimeJailable
+		case w.Missed >= m.alertLevel:
+			status = UptimeAtRisk
+		}
+		if w.Status != status {
+			w.Status = status
+			changes = append(changes, w.SigningWindow)
+		}
+	}
+	m.next = height + 1
+	return changes
+}
@@ -0,0 +1,158 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package kardia
+package kardia
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/types"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestCommitSignatures(t *testing.T) {
+	signer, other := common.HexToAddress("0x01"), common.HexToAddress("0x02")
+	validators := &types.ValidatorSet{Validators: []*types.Validator{{Address: signer, VotingPower: 10}, {Address: other, VotingPower: 5}}}
+	commit := types.NewCommit(1, 0, types.BlockID{}, []types.CommitSig{
+		types.NewCommitSigForBlock([]byte{1}, signer, time.Unix(0, 0)),
+		types.NewCommitSigAbsent(),
+	})
+	signatures, err := commitSignatures(commit, validators)
+	assert.Nil(t, err)
+	assert.Equal(t, []*BlockSignature{{Validator: signer, VotingPower: 10, Signed: true}, {Validator: other, VotingPower: 5}}, signatures)
+
+	commit.Signatures[1] = types.NewCommitSigForBlock([]byte{1}, signer, time.Unix(0, 0))
+	_, err = commitSignatures(commit, validators)
+	assert.True(t, errors.Is(err, ErrInvalidCommit))
+	commit.Signatures = commit.Signatures[:1]
+	_, err = commitSignatures(commit, validators)
+	assert.True(t, errors.Is(err, ErrInvalidCommit))
+}
+
+func TestSimulated_Uptime(t *testing.T) {
+	b, node, auth := setupSimulatedNode(t)
+	defer b.Close()
+	ctx := context.Background()
+
+	commit := func(blocks int) {
+		for i := 0; i < blocks; i++ {
+			_, err := b.Commit()
+			assert.Nil(t, err)
+		}
+	}
+	// blocks 2 to 4 are not signed
+	commit(1)
+	b.SetOffline(auth.From, true)
+	commit(3)
+	b.SetOffline(auth.From, false)
+	commit(2)
+
+	uptimes, err := node.Uptime(ctx, 1, 6)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(uptimes))
+	assert.Equal(t, auth.From, uptimes[0].Validator)
+	assert.Equal(t, uint64(6), uptimes[0].Blocks)
+	assert.Equal(t, uint64(3), uptimes[0].Signed)
+	assert.Equal(t, []uint64{2, 3, 4}, uptimes[0].MissedHeights)
+	assert.Equal(t, FloatToBigInt(0.5, 18), uptimes[0].Ratio())
+	_, err = node.Uptime(ctx, 6, 1)
+	assert.Equal(t, ErrInvalidBlockRange, err)
+
+	// the staking contract counts the same missed blocks
+	validators, err := node.ValidatorSMCAddresses(ctx)
+	assert.Nil(t, err)
+	info, err := node.SigningInfo(ctx, validators[0].Hex())
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(3), info.MissedBlockCounter.Uint64())
+
+	// 2 blocks may be missed in a window of 4, the validator is at risk from the first one
+	monitor := NewUptimeMonitor(UptimeConfig{FromBlock: 1, Window: 4, MinSignedPerWindow: FloatToBigInt(0.5, 18), Logger: zap.NewNop()}, node)
+	alerts := make(chan SigningWindow, 4)
+	sub := monitor.SubscribeUptimeAlerts(alerts)
+	defer sub.Unsubscribe()
+	assert.Nil(t, monitor.Sync(ctx))
+	expected := []struct {
+		status UptimeStatus
+		height uint64
+		missed uint64
+	}{{UptimeAtRisk, 2, 1}, {UptimeJailable, 4, 3}, {UptimeAtRisk, 6, 2}}
+	assert.Len(t, alerts, len(expected))
+	for _, e := range expected {
+		alert := <-alerts
+		assert.Equal(t, auth.From, alert.Validator)
+		assert.Equal(t, e.status, alert.Status)
+		assert.Equal(t, e.height, alert.Height)
+		assert.Equal(t, e.missed, alert.Missed)
+		assert.Equal(t, uint64(2), alert.MaxMissed)
+	}
+
+	// the missed blocks leave the window
+	commit(1)
+	assert.Nil(t, monitor.Sync(ctx))
+	assert.Len(t, alerts, 0)
+	commit(1)
+	assert.Nil(t, monitor.Sync(ctx))
+	assert.Len(t, alerts, 1)
+	window, ok := monitor.Window(auth.From)
+	assert.True(t, ok)
+	assert.Equal(t, SigningWindow{Validator: auth.From, Status: UptimeOK, Height: 8, Blocks: 4, MaxMissed: 2}, window)
+	assert.Equal(t, []SigningWindow{window}, monitor.Windows())
+
+	// the window defaults to the chain params and starts at the latest block
+	monitor = NewUptimeMonitor(UptimeConfig{Logger: zap.NewNop()}, node)
+	assert.Nil(t, monitor.Sync(ctx))
+	window, ok = monitor.Window(auth.From)
+	assert.True(t, ok)
+	assert.Equal(t, SigningWindow{Validator: auth.From, Height: 8, Blocks: 1, MaxMissed: 5000}, window)
+}
+
+func TestSimulated_UptimeConcurrentSync(t *testing.T) {
+	b, node, auth := setupSimulatedNode(t)
+	defer b.Close()
+	ctx := context.Background()
+
+	b.SetOffline(auth.From, true)
+	for i := 0; i < 3; i++ {
+		_, err := b.Commit()
+		assert.Nil(t, err)
+	}
+	b.SetOffline(auth.From, false)
+	_, err := b.Commit()
+	assert.Nil(t, err)
+
+	// every height is added once whatever the number of concurrent syncs
+	monitor := NewUptimeMonitor(UptimeConfig{FromBlock: 1, Window: 10, MinSignedPerWindow: FloatToBigInt(0.5, 18), Logger: zap.NewNop()}, node)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Nil(t, monitor.Sync(ctx))
+		}()
+	}
+	wg.Wait()
+	window, ok := monitor.Window(auth.From)
+	assert.True(t, ok)
+	assert.Equal(t, SigningWindow{Validator: auth.From, Height: 4, Blocks: 4, Missed: 3, MaxMissed: 5}, window)
+}
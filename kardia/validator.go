@@ -124,7 +124,7 @@ func (n *node) SlashEvents(ctx context.Context, validatorSMCAddress string) ([]*
 		return nil, err
 	}
 	for i := 0; i < eventsSize; i++ {
-		payload, err := n.validatorSMC.Abi.Pack("slashEvents", i)
+		payload, err := n.validatorSMC.Abi.Pack("slashEvents", big.NewInt(int64(i)))
 		if err != nil {
 			return nil, err
 		}
@@ -270,14 +270,14 @@ func (n *node) getSlashEventsSize(ctx context.Context, validatorSMCAddress strin
 		return 0, ErrEmptyList
 	}
 
-	var slashEventsSize int
+	var slashEventsSize *big.Int
 	// unpack result
 	err = n.validatorSMC.Abi.UnpackIntoInterface(&slashEventsSize, "getSlashEventsLength", res)
 	if err != nil {
 		n.lgr.Error("Error unpacking get slash events length error: ", zap.Error(err))
 		return 0, err
 	}
-	return slashEventsSize, nil
+	return int(slashEventsSize.Int64()), nil
 }
 
 func (n *node) ValidatorSets(ctx context.Context) ([]common.Address, error) {
@@ -310,24 +310,16 @@ func (n *node) ValidatorSets(ctx context.Context) ([]common.Address, error) {
 //deprecated
 func (n *node) Validators(ctx context.Context) ([]*Validator, error) {
 	lgr := n.lgr.With(zap.String("method", "Validators"))
-	var (
-		validators []*Validator
-	)
-
 	validatorSMCAddresses, err := n.ValidatorSMCAddresses(ctx)
 	if err != nil {
 		return nil, err
 	}
-
-	for _, smcAddr := range validatorSMCAddresses {
-		loadValidatorStartTime := time.Now()
-		v, err := n.Validator(ctx, smcAddr.Hex())
-		if err != nil {
-			return nil, err
-		}
-		lgr.Debug("Finished load validator", zap.String("Validator", fmt.Sprintf("%s", v.Name)), zap.String("SMCAddress", v.SMCAddress.String()), zap.Int("TotalDelegator", len(v.Delegators)), zap.Duration("Total", time.Now().Sub(loadValidatorStartTime)))
-		validators = append(validators, v)
+	loadValidatorsStartTime := time.Now()
+	validators, err := n.loadValidators(ctx, lgr, validatorSMCAddresses)
+	if err != nil {
+		return nil, err
 	}
+	lgr.Debug("Finished load validators", zap.Int("Total", len(validators)), zap.Duration("Time", time.Now().Sub(loadValidatorsStartTime)))
 	return validators, nil
 }
 
@@ -335,41 +327,54 @@ func (n *node) Validators(ctx context.Context) ([]*Validator, error) {
 func (n *node) Validator(ctx context.Context, validatorSMCAddress string) (*Validator, error) {
 	lgr := n.lgr.With(zap.String("method", "Validator"))
 	startLoadInfo := time.Now()
-	payload, err := n.validatorSMC.Abi.Pack("inforValidator")
-	if err != nil {
-		lgr.Error("Error packing validator info payload: ", zap.Error(err))
-		return nil, err
-	}
-	res, err := n.KardiaCall(ctx, ConstructCallArgs(validatorSMCAddress, payload))
+	validators, err := n.loadValidators(ctx, lgr, []common.Address{common.HexToAddress(validatorSMCAddress)})
 	if err != nil {
-		lgr.Error("GetValidatorInfo KardiaCall error: ", zap.Error(err))
 		return nil, err
 	}
-	var valInfo Validator
-	// unpack result
-	err = n.validatorSMC.Abi.UnpackIntoInterface(&valInfo, "inforValidator", res)
-	if err != nil {
-		lgr.Error("Error unpacking validator info: ", zap.Error(err))
-		return nil, err
+	lgr.Debug("Finished load validator", zap.Duration("Total", time.Now().Sub(startLoadInfo)))
+	return validators[0], nil
+}
+
+// loadValidators loads the info, commission and signing info of every validator in one batch
+func (n *node) loadValidators(ctx context.Context, lgr *zap.Logger, validatorSMCAddresses []common.Address) ([]*Validator, error) {
+	methods := []string{"inforValidator", "commission", "signingInfo"}
+	var calls []SMCCallArgs
+	for _, smcAddr := range validatorSMCAddresses {
+		for _, method := range methods {
+			payload, err := n.validatorSMC.Abi.Pack(method)
+			if err != nil {
+				return nil, err
+			}
+			calls = append(calls, ConstructCallArgs(smcAddr.Hex(), payload))
+		}
 	}
-	lgr.Debug("Finished load validator info", zap.Duration("Total", time.Now().Sub(startLoadInfo)))
-	valInfo.SMCAddress = common.HexToAddress(validatorSMCAddress)
-	startGetValidatorComm := time.Now()
-	commission, err := n.ValidatorCommission(ctx, validatorSMCAddress)
+	results, err := n.BatchKardiaCall(ctx, calls)
 	if err != nil {
+		lgr.Error("Validators batch call error: ", zap.Error(err))
 		return nil, err
 	}
-	valInfo.Commission = commission
-	lgr.Debug("Finished load get validator comm", zap.Duration("Total", time.Now().Sub(startGetValidatorComm)))
-
-	startGetSigningInfo := time.Now()
-	signingInfo, err := n.SigningInfo(ctx, validatorSMCAddress)
-	if err != nil {
-		return nil, err
+	var validators []*Validator
+	for i, smcAddr := range validatorSMCAddresses {
+		var (
+			v           = &Validator{}
+			commission  Commission
+			signingInfo SigningInfo
+		)
+		for j, out := range []interface{}{v, &commission, &signingInfo} {
+			result := results[i*len(methods)+j]
+			if result.Err != nil {
+				return nil, result.Err
+			}
+			if err := n.validatorSMC.Abi.UnpackIntoInterface(out, methods[j], result.Data); err != nil {
+				lgr.Error("Error unpacking validator", zap.String("method", methods[j]), zap.Error(err))
+				return nil, err
+			}
+		}
+		v.SMCAddress, v.Commission, v.SigningInfo = smcAddr, &commission, &signingInfo
+		lgr.Debug("Finished load validator", zap.String("Validator", fmt.Sprintf("%s", v.Name)), zap.String("SMCAddress", v.SMCAddress.String()))
+		validators = append(validators, v)
 	}
-	valInfo.SigningInfo = signingInfo
-	lgr.Debug("Finished load get signing info", zap.Duration("Time", time.Now().Sub(startGetSigningInfo)))
-	return &valInfo, nil
+	return validators, nil
 }
 
 // deprecated
@@ -0,0 +1,1054 @@
+// Code generated by kaibind. DO NOT EDIT.
+
+package kardia
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/kardiachain/go-kardia/lib/abi"
+	"github.com/kardiachain/go-kardia/lib/abi/bind"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/lib/event"
+	"github.com/kardiachain/go-kardia/types"
+
+	"github.com/kardiachain/go-kaiclient/kardia/smc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = abi.JSON
+	_ = bind.NewBoundContract
+	_ = common.Big1
+	_ = event.NewSubscription
+	_ = types.BloomLookup
+)
+
+// ValidatorContract is a typed binding of the contract, on top of BoundContract.
+type ValidatorContract struct {
+	*BoundContract
+}
+
+// NewValidatorContract binds the contract deployed at address.
+func NewValidatorContract(node Node, address common.Address) (*ValidatorContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(smc.ValidatorABI))
+	if err != nil {
+		return nil, err
+	}
+	return &ValidatorContract{NewBoundContract(node, &parsed, address)}, nil
+}
+
+// ValidatorCommissionOutput is the output of ValidatorContract.Commission.
+type ValidatorCommissionOutput struct {
+	Rate          *big.Int
+	MaxRate       *big.Int
+	MaxChangeRate *big.Int
+}
+
+// Commission calls function commission() view returns(uint256 rate, uint256 maxRate, uint256 maxChangeRate).
+func (c *ValidatorContract) Commission(opts *bind.CallOpts) (*ValidatorCommissionOutput, error) {
+	out := make([]interface{}, 3)
+	if err := c.BoundContract.Call(opts, &out, "commission"); err != nil {
+		return nil, err
+	}
+	return &ValidatorCommissionOutput{
+		Rate:          *abi.ConvertType(out[0], new(*big.Int)).(**big.Int),
+		MaxRate:       *abi.ConvertType(out[1], new(*big.Int)).(**big.Int),
+		MaxChangeRate: *abi.ConvertType(out[2], new(*big.Int)).(**big.Int),
+	}, nil
+}
+
+// ValidatorDelegationByAddrOutput is the output of ValidatorContract.DelegationByAddr.
+type ValidatorDelegationByAddrOutput struct {
+	Stake          *big.Int
+	PreviousPeriod *big.Int
+	Height         *big.Int
+	Shares         *big.Int
+	Owner          common.Address
+}
+
+// DelegationByAddr calls function delegationByAddr(address ) view returns(uint256 stake, uint256 previousPeriod, uint256 height, uint256 shares, address owner).
+func (c *ValidatorContract) DelegationByAddr(opts *bind.CallOpts, arg0 common.Address) (*ValidatorDelegationByAddrOutput, error) {
+	out := make([]interface{}, 5)
+	if err := c.BoundContract.Call(opts, &out, "delegationByAddr", arg0); err != nil {
+		return nil, err
+	}
+	return &ValidatorDelegationByAddrOutput{
+		Stake:          *abi.ConvertType(out[0], new(*big.Int)).(**big.Int),
+		PreviousPeriod: *abi.ConvertType(out[1], new(*big.Int)).(**big.Int),
+		Height:         *abi.ConvertType(out[2], new(*big.Int)).(**big.Int),
+		Shares:         *abi.ConvertType(out[3], new(*big.Int)).(**big.Int),
+		Owner:          *abi.ConvertType(out[4], new(common.Address)).(*common.Address),
+	}, nil
+}
+
+// GetCommissionRewards calls function getCommissionRewards() view returns(uint256).
+func (c *ValidatorContract) GetCommissionRewards(opts *bind.CallOpts) (*big.Int, error) {
+	var out *big.Int
+	err := c.BoundContract.Call(opts, &out, "getCommissionRewards")
+	return out, err
+}
+
+// GetDelegationRewards calls function getDelegationRewards(address _delAddr) view returns(uint256).
+func (c *ValidatorContract) GetDelegationRewards(opts *bind.CallOpts, delAddr common.Address) (*big.Int, error) {
+	var out *big.Int
+	err := c.BoundContract.Call(opts, &out, "getDelegationRewards", delAddr)
+	return out, err
+}
+
+// ValidatorGetDelegationsOutput is the output of ValidatorContract.GetDelegations.
+type ValidatorGetDelegationsOutput struct {
+	Addresses []common.Address
+	Shares    []*big.Int
+}
+
+// GetDelegations calls function getDelegations() view returns(address[] addresses, uint256[] shares).
+func (c *ValidatorContract) GetDelegations(opts *bind.CallOpts) (*ValidatorGetDelegationsOutput, error) {
+	out := make([]interface{}, 2)
+	if err := c.BoundContract.Call(opts, &out, "getDelegations"); err != nil {
+		return nil, err
+	}
+	return &ValidatorGetDelegationsOutput{
+		Addresses: *abi.ConvertType(out[0], new([]common.Address)).(*[]common.Address),
+		Shares:    *abi.ConvertType(out[1], new([]*big.Int)).(*[]*big.Int),
+	}, nil
+}
+
+// GetDelegatorStake calls function getDelegatorStake(address _delAddr) view returns(uint256).
+func (c *ValidatorContract) GetDelegatorStake(opts *bind.CallOpts, delAddr common.Address) (*big.Int, error) {
+	var out *big.Int
+	err := c.BoundContract.Call(opts, &out, "getDelegatorStake", delAddr)
+	return out, err
+}
+
+// GetMissedBlock calls function getMissedBlock() view returns(bool[]).
+func (c *ValidatorContract) GetMissedBlock(opts *bind.CallOpts) ([]bool, error) {
+	var out []bool
+	err := c.BoundContract.Call(opts, &out, "getMissedBlock")
+	return out, err
+}
+
+// GetSlashEventsLength calls function getSlashEventsLength() view returns(uint256).
+func (c *ValidatorContract) GetSlashEventsLength(opts *bind.CallOpts) (*big.Int, error) {
+	var out *big.Int
+	err := c.BoundContract.Call(opts, &out, "getSlashEventsLength")
+	return out, err
+}
+
+// ValidatorGetUBDEntriesOutput is the output of ValidatorContract.GetUBDEntries.
+type ValidatorGetUBDEntriesOutput struct {
+	Amounts         []*big.Int
+	CompletionTimes []*big.Int
+}
+
+// GetUBDEntries calls function getUBDEntries(address delAddr) view returns(uint256[] amounts, uint256[] completionTimes).
+func (c *ValidatorContract) GetUBDEntries(opts *bind.CallOpts, delAddr common.Address) (*ValidatorGetUBDEntriesOutput, error) {
+	out := make([]interface{}, 2)
+	if err := c.BoundContract.Call(opts, &out, "getUBDEntries", delAddr); err != nil {
+		return nil, err
+	}
+	return &ValidatorGetUBDEntriesOutput{
+		Amounts:         *abi.ConvertType(out[0], new([]*big.Int)).(*[]*big.Int),
+		CompletionTimes: *abi.ConvertType(out[1], new([]*big.Int)).(*[]*big.Int),
+	}, nil
+}
+
+// ValidatorInforValidatorOutput is the output of ValidatorContract.InforValidator.
+type ValidatorInforValidatorOutput struct {
+	Name                  [32]byte
+	Signer                common.Address
+	Tokens                *big.Int
+	Jailed                bool
+	DelegationShares      *big.Int
+	AccumulatedCommission *big.Int
+	UbdEntryCount         *big.Int
+	UpdateTime            *big.Int
+	MinSelfDelegation     *big.Int
+	Status                uint8
+	UnbondingTime         *big.Int
+	UnbondingHeight       *big.Int
+}
+
+// InforValidator calls function inforValidator() view returns(bytes32 name, address signer, uint256 tokens, bool jailed, uint256 delegationShares, uint256 accumulatedCommission, uint256 ubdEntryCount, uint256 updateTime, uint256 minSelfDelegation, uint8 status, uint256 unbondingTime, uint256 unbondingHeight).
+func (c *ValidatorContract) InforValidator(opts *bind.CallOpts) (*ValidatorInforValidatorOutput, error) {
+	out := make([]interface{}, 12)
+	if err := c.BoundContract.Call(opts, &out, "inforValidator"); err != nil {
+		return nil, err
+	}
+	return &ValidatorInforValidatorOutput{
+		Name:                  *abi.ConvertType(out[0], new([32]byte)).(*[32]byte),
+		Signer:                *abi.ConvertType(out[1], new(common.Address)).(*common.Address),
+		Tokens:                *abi.ConvertType(out[2], new(*big.Int)).(**big.Int),
+		Jailed:                *abi.ConvertType(out[3], new(bool)).(*bool),
+		DelegationShares:      *abi.ConvertType(out[4], new(*big.Int)).(**big.Int),
+		AccumulatedCommission: *abi.ConvertType(out[5], new(*big.Int)).(**big.Int),
+		UbdEntryCount:         *abi.ConvertType(out[6], new(*big.Int)).(**big.Int),
+		UpdateTime:            *abi.ConvertType(out[7], new(*big.Int)).(**big.Int),
+		MinSelfDelegation:     *abi.ConvertType(out[8], new(*big.Int)).(**big.Int),
+		Status:                *abi.ConvertType(out[9], new(uint8)).(*uint8),
+		UnbondingTime:         *abi.ConvertType(out[10], new(*big.Int)).(**big.Int),
+		UnbondingHeight:       *abi.ConvertType(out[11], new(*big.Int)).(**big.Int),
+	}, nil
+}
+
+// Owner calls function owner() view returns(address).
+func (c *ValidatorContract) Owner(opts *bind.CallOpts) (common.Address, error) {
+	var out common.Address
+	err := c.BoundContract.Call(opts, &out, "owner")
+	return out, err
+}
+
+// Params calls function params() view returns(address).
+func (c *ValidatorContract) Params(opts *bind.CallOpts) (common.Address, error) {
+	var out common.Address
+	err := c.BoundContract.Call(opts, &out, "params")
+	return out, err
+}
+
+// ValidatorSigningInfoOutput is the output of ValidatorContract.SigningInfo.
+type ValidatorSigningInfoOutput struct {
+	StartHeight        *big.Int
+	IndexOffset        *big.Int
+	Tombstoned         bool
+	MissedBlockCounter *big.Int
+	JailedUntil        *big.Int
+}
+
+// SigningInfo calls function signingInfo() view returns(uint256 startHeight, uint256 indexOffset, bool tombstoned, uint256 missedBlockCounter, uint256 jailedUntil).
+func (c *ValidatorContract) SigningInfo(opts *bind.CallOpts) (*ValidatorSigningInfoOutput, error) {
+	out := make([]interface{}, 5)
+	if err := c.BoundContract.Call(opts, &out, "signingInfo"); err != nil {
+		return nil, err
+	}
+	return &ValidatorSigningInfoOutput{
+		StartHeight:        *abi.ConvertType(out[0], new(*big.Int)).(**big.Int),
+		IndexOffset:        *abi.ConvertType(out[1], new(*big.Int)).(**big.Int),
+		Tombstoned:         *abi.ConvertType(out[2], new(bool)).(*bool),
+		MissedBlockCounter: *abi.ConvertType(out[3], new(*big.Int)).(**big.Int),
+		JailedUntil:        *abi.ConvertType(out[4], new(*big.Int)).(**big.Int),
+	}, nil
+}
+
+// ValidatorSlashEventsOutput is the output of ValidatorContract.SlashEvents.
+type ValidatorSlashEventsOutput struct {
+	Period   *big.Int
+	Fraction *big.Int
+	Height   *big.Int
+}
+
+// SlashEvents calls function slashEvents(uint256 ) view returns(uint256 period, uint256 fraction, uint256 height).
+func (c *ValidatorContract) SlashEvents(opts *bind.CallOpts, arg0 *big.Int) (*ValidatorSlashEventsOutput, error) {
+	out := make([]interface{}, 3)
+	if err := c.BoundContract.Call(opts, &out, "slashEvents", arg0); err != nil {
+		return nil, err
+	}
+	return &ValidatorSlashEventsOutput{
+		Period:   *abi.ConvertType(out[0], new(*big.Int)).(**big.Int),
+		Fraction: *abi.ConvertType(out[1], new(*big.Int)).(**big.Int),
+		Height:   *abi.ConvertType(out[2], new(*big.Int)).(**big.Int),
+	}, nil
+}
+
+// Treasury calls function treasury() view returns(address).
+func (c *ValidatorContract) Treasury(opts *bind.CallOpts) (common.Address, error) {
+	var out common.Address
+	err := c.BoundContract.Call(opts, &out, "treasury")
+	return out, err
+}
+
+// ValidatorUbdEntriesOutput is the output of ValidatorContract.UbdEntries.
+type ValidatorUbdEntriesOutput struct {
+	Amount         *big.Int
+	BlockHeight    *big.Int
+	CompletionTime *big.Int
+}
+
+// UbdEntries calls function ubdEntries(address , uint256 ) view returns(uint256 amount, uint256 blockHeight, uint256 completionTime).
+func (c *ValidatorContract) UbdEntries(opts *bind.CallOpts, arg0 common.Address, arg1 *big.Int) (*ValidatorUbdEntriesOutput, error) {
+	out := make([]interface{}, 3)
+	if err := c.BoundContract.Call(opts, &out, "ubdEntries", arg0, arg1); err != nil {
+		return nil, err
+	}
+	return &ValidatorUbdEntriesOutput{
+		Amount:         *abi.ConvertType(out[0], new(*big.Int)).(**big.Int),
+		BlockHeight:    *abi.ConvertType(out[1], new(*big.Int)).(**big.Int),
+		CompletionTime: *abi.ConvertType(out[2], new(*big.Int)).(**big.Int),
+	}, nil
+}
+
+// AllocateToken sends a transaction calling function allocateToken(uint256 _rewards) returns().
+func (c *ValidatorContract) AllocateToken(opts *bind.TransactOpts, rewards *big.Int) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "allocateToken", rewards)
+}
+
+// Delegate sends a transaction calling function delegate() payable returns().
+func (c *ValidatorContract) Delegate(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "delegate")
+}
+
+// DoubleSign sends a transaction calling function doubleSign(uint256 votingPower, uint256 distributionHeight) returns().
+func (c *ValidatorContract) DoubleSign(opts *bind.TransactOpts, votingPower *big.Int, distributionHeight *big.Int) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "doubleSign", votingPower, distributionHeight)
+}
+
+// Initialize sends a transaction calling function initialize(bytes32 _name, address _signer, uint256 _rate, uint256 _maxRate, uint256 _maxChangeRate) returns().
+func (c *ValidatorContract) Initialize(opts *bind.TransactOpts, name [32]byte, signer common.Address, rate *big.Int, maxRate *big.Int, maxChangeRate *big.Int) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "initialize", name, signer, rate, maxRate, maxChangeRate)
+}
+
+// RenounceOwnership sends a transaction calling function renounceOwnership() returns().
+func (c *ValidatorContract) RenounceOwnership(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "renounceOwnership")
+}
+
+// SelfDelegate sends a transaction calling function selfDelegate(address val, uint256 amount) returns().
+func (c *ValidatorContract) SelfDelegate(opts *bind.TransactOpts, val common.Address, amount *big.Int) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "selfDelegate", val, amount)
+}
+
+// SetParams sends a transaction calling function setParams(address _params) returns().
+func (c *ValidatorContract) SetParams(opts *bind.TransactOpts, params common.Address) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "setParams", params)
+}
+
+// SetTreasury sends a transaction calling function setTreasury(address _treasury) returns().
+func (c *ValidatorContract) SetTreasury(opts *bind.TransactOpts, treasury common.Address) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "setTreasury", treasury)
+}
+
+// Start sends a transaction calling function start() returns().
+func (c *ValidatorContract) Start(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "start")
+}
+
+// Stop sends a transaction calling function stop() returns().
+func (c *ValidatorContract) Stop(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "stop")
+}
+
+// TransferOwnership sends a transaction calling function transferOwnership(address newOwner) returns().
+func (c *ValidatorContract) TransferOwnership(opts *bind.TransactOpts, newOwner common.Address) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "transferOwnership", newOwner)
+}
+
+// Undelegate sends a transaction calling function undelegate() returns().
+func (c *ValidatorContract) Undelegate(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "undelegate")
+}
+
+// UndelegateWithAmount sends a transaction calling function undelegateWithAmount(uint256 _amount) returns().
+func (c *ValidatorContract) UndelegateWithAmount(opts *bind.TransactOpts, amount *big.Int) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "undelegateWithAmount", amount)
+}
+
+// Unjail sends a transaction calling function unjail() returns().
+func (c *ValidatorContract) Unjail(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "unjail")
+}
+
+// UpdateCommissionRate sends a transaction calling function updateCommissionRate(uint256 _commissionRate) returns().
+func (c *ValidatorContract) UpdateCommissionRate(opts *bind.TransactOpts, commissionRate *big.Int) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "updateCommissionRate", commissionRate)
+}
+
+// UpdateName sends a transaction calling function updateName(bytes32 _name) payable returns().
+func (c *ValidatorContract) UpdateName(opts *bind.TransactOpts, name [32]byte) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "updateName", name)
+}
+
+// UpdateSigner sends a transaction calling function updateSigner(address signerAddr) returns().
+func (c *ValidatorContract) UpdateSigner(opts *bind.TransactOpts, signerAddr common.Address) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "updateSigner", signerAddr)
+}
+
+// ValidateSignature sends a transaction calling function validateSignature(uint256 _votingPower, bool _signed) returns().
+func (c *ValidatorContract) ValidateSignature(opts *bind.TransactOpts, votingPower *big.Int, signed bool) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "validateSignature", votingPower, signed)
+}
+
+// Withdraw sends a transaction calling function withdraw() returns().
+func (c *ValidatorContract) Withdraw(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "withdraw")
+}
+
+// WithdrawCommission sends a transaction calling function withdrawCommission() returns().
+func (c *ValidatorContract) WithdrawCommission(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "withdrawCommission")
+}
+
+// WithdrawRewards sends a transaction calling function withdrawRewards() returns().
+func (c *ValidatorContract) WithdrawRewards(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return c.BoundContract.Transact(opts, "withdrawRewards")
+}
+
+// ValidatorDelegate is the Delegate event of ValidatorContract.
+type ValidatorDelegate struct {
+	DelAddr common.Address
+	Amount  *big.Int
+	Raw     types.Log
+}
+
+// ValidatorDelegateIterator iterates over the Delegate events returned by ValidatorContract.FilterDelegate.
+type ValidatorDelegateIterator struct {
+	// Event is the event the iterator is at
+	Event *ValidatorDelegate
+
+	*EventIterator
+}
+
+// FilterDelegate iterates over past logs of event Delegate(address _delAddr, uint256 _amount).
+func (c *ValidatorContract) FilterDelegate(opts *bind.FilterOpts) (*ValidatorDelegateIterator, error) {
+	it := new(ValidatorDelegateIterator)
+	var err error
+	it.EventIterator, err = c.BoundContract.FilterEvent(opts, "Delegate", func(log types.Log) error {
+		ev, err := c.ParseDelegate(log)
+		it.Event = ev
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return it, nil
+}
+
+// WatchDelegate sends new logs of event Delegate(address _delAddr, uint256 _amount) to sink.
+func (c *ValidatorContract) WatchDelegate(opts *bind.WatchOpts, sink chan<- *ValidatorDelegate) (event.Subscription, error) {
+	return c.BoundContract.WatchEvent(opts, "Delegate", func(log types.Log, quit <-chan struct{}) error {
+		ev, err := c.ParseDelegate(log)
+		if err != nil {
+			return err
+		}
+		select {
+		case sink <- ev:
+		case <-quit:
+		}
+		return nil
+	})
+}
+
+// ParseDelegate unpacks a log of event Delegate(address _delAddr, uint256 _amount).
+func (c *ValidatorContract) ParseDelegate(log types.Log) (*ValidatorDelegate, error) {
+	ev := new(ValidatorDelegate)
+	if err := c.BoundContract.UnpackLog(ev, "Delegate", log); err != nil {
+		return nil, err
+	}
+	ev.Raw = log
+	return ev, nil
+}
+
+// ValidatorLiveness is the Liveness event of ValidatorContract.
+type ValidatorLiveness struct {
+	MissedBlocks *big.Int
+	BlockHeight  *big.Int
+	Raw          types.Log
+}
+
+// ValidatorLivenessIterator iterates over the Liveness events returned by ValidatorContract.FilterLiveness.
+type ValidatorLivenessIterator struct {
+	// Event is the event the iterator is at
+	Event *ValidatorLiveness
+
+	*EventIterator
+}
+
+// FilterLiveness iterates over past logs of event Liveness(uint256 _missedBlocks, uint256 _blockHeight).
+func (c *ValidatorContract) FilterLiveness(opts *bind.FilterOpts) (*ValidatorLivenessIterator, error) {
+	it := new(ValidatorLivenessIterator)
+	var err error
+	it.EventIterator, err = c.BoundContract.FilterEvent(opts, "Liveness", func(log types.Log) error {
+		ev, err := c.ParseLiveness(log)
+		it.Event = ev
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return it, nil
+}
+
+// WatchLiveness sends new logs of event Liveness(uint256 _missedBlocks, uint256 _blockHeight) to sink.
+func (c *ValidatorContract) WatchLiveness(opts *bind.WatchOpts, sink chan<- *ValidatorLiveness) (event.Subscription, error) {
+	return c.BoundContract.WatchEvent(opts, "Liveness", func(log types.Log, quit <-chan struct{}) error {
+		ev, err := c.ParseLiveness(log)
+		if err != nil {
+			return err
+		}
+		select {
+		case sink <- ev:
+		case <-quit:
+		}
+		return nil
+	})
+}
+
+// ParseLiveness unpacks a log of event Liveness(uint256 _missedBlocks, uint256 _blockHeight).
+func (c *ValidatorContract) ParseLiveness(log types.Log) (*ValidatorLiveness, error) {
+	ev := new(ValidatorLiveness)
+	if err := c.BoundContract.UnpackLog(ev, "Liveness", log); err != nil {
+		return nil, err
+	}
+	ev.Raw = log
+	return ev, nil
+}
+
+// ValidatorOwnershipTransferred is the OwnershipTransferred event of ValidatorContract.
+type ValidatorOwnershipTransferred struct {
+	PreviousOwner common.Address
+	NewOwner      common.Address
+	Raw           types.Log
+}
+
+// ValidatorOwnershipTransferredIterator iterates over the OwnershipTransferred events returned by ValidatorContract.FilterOwnershipTransferred.
+type ValidatorOwnershipTransferredIterator struct {
+	// Event is the event the iterator is at
+	Event *ValidatorOwnershipTransferred
+
+	*EventIterator
+}
+
+// FilterOwnershipTransferred iterates over past logs of event OwnershipTransferred(address indexed previousOwner, address indexed newOwner).
+func (c *ValidatorContract) FilterOwnershipTransferred(opts *bind.FilterOpts, previousOwner []common.Address, newOwner []common.Address) (*ValidatorOwnershipTransferredIterator, error) {
+	var previousOwnerRule []interface{}
+	for _, item := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, item)
+	}
+	var newOwnerRule []interface{}
+	for _, item := range newOwner {
+		newOwnerRule = append(newOwnerRule, item)
+	}
+	it := new(ValidatorOwnershipTransferredIterator)
+	var err error
+	it.EventIterator, err = c.BoundContract.FilterEvent(opts, "OwnershipTransferred", func(log types.Log) error {
+		ev, err := c.ParseOwnershipTransferred(log)
+		it.Event = ev
+		return err
+	}, previousOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return it, nil
+}
+
+// WatchOwnershipTransferred sends new logs of event OwnershipTransferred(address indexed previousOwner, address indexed newOwner) to sink.
+func (c *ValidatorContract) WatchOwnershipTransferred(opts *bind.WatchOpts, sink chan<- *ValidatorOwnershipTransferred, previousOwner []common.Address, newOwner []common.Address) (event.Subscription, error) {
+	var previousOwnerRule []interface{}
+	for _, item := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, item)
+	}
+	var newOwnerRule []interface{}
+	for _, item := range newOwner {
+		newOwnerRule = append(newOwnerRule, item)
+	}
+	return c.BoundContract.WatchEvent(opts, "OwnershipTransferred", func(log types.Log, quit <-chan struct{}) error {
+		ev, err := c.ParseOwnershipTransferred(log)
+		if err != nil {
+			return err
+		}
+		select {
+		case sink <- ev:
+		case <-quit:
+		}
+		return nil
+	}, previousOwnerRule, newOwnerRule)
+}
+
+// ParseOwnershipTransferred unpacks a log of event OwnershipTransferred(address indexed previousOwner, address indexed newOwner).
+func (c *ValidatorContract) ParseOwnershipTransferred(log types.Log) (*ValidatorOwnershipTransferred, error) {
+	ev := new(ValidatorOwnershipTransferred)
+	if err := c.BoundContract.UnpackLog(ev, "OwnershipTransferred", log); err != nil {
+		return nil, err
+	}
+	ev.Raw = log
+	return ev, nil
+}
+
+// ValidatorSlashed is the Slashed event of ValidatorContract.
+type ValidatorSlashed struct {
+	Power  *big.Int
+	Reason *big.Int
+	Raw    types.Log
+}
+
+// ValidatorSlashedIterator iterates over the Slashed events returned by ValidatorContract.FilterSlashed.
+type ValidatorSlashedIterator struct {
+	// Event is the event the iterator is at
+	Event *ValidatorSlashed
+
+	*EventIterator
+}
+
+// FilterSlashed iterates over past logs of event Slashed(uint256 _power, uint256 _reason).
+func (c *ValidatorContract) FilterSlashed(opts *bind.FilterOpts) (*ValidatorSlashedIterator, error) {
+	it := new(ValidatorSlashedIterator)
+	var err error
+	it.EventIterator, err = c.BoundContract.FilterEvent(opts, "Slashed", func(log types.Log) error {
+		ev, err := c.ParseSlashed(log)
+		it.Event = ev
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return it, nil
+}
+
+// WatchSlashed sends new logs of event Slashed(uint256 _power, uint256 _reason) to sink.
+func (c *ValidatorContract) WatchSlashed(opts *bind.WatchOpts, sink chan<- *ValidatorSlashed) (event.Subscription, error) {
+	return c.BoundContract.WatchEvent(opts, "Slashed", func(log types.Log, quit <-chan struct{}) error {
+		ev, err := c.ParseSlashed(log)
+		if err != nil {
+			return err
+		}
+		select {
+		case sink <- ev:
+		case <-quit:
+		}
+		return nil
+	})
+}
+
+// ParseSlashed unpacks a log of event Slashed(uint256 _power, uint256 _reason).
+func (c *ValidatorContract) ParseSlashed(log types.Log) (*ValidatorSlashed, error) {
+	ev := new(ValidatorSlashed)
+	if err := c.BoundContract.UnpackLog(ev, "Slashed", log); err != nil {
+		return nil, err
+	}
+	ev.Raw = log
+	return ev, nil
+}
+
+// ValidatorStarted is the Started event of ValidatorContract.
+type ValidatorStarted struct {
+	Raw types.Log
+}
+
+// ValidatorStartedIterator iterates over the Started events returned by ValidatorContract.FilterStarted.
+type ValidatorStartedIterator struct {
+	// Event is the event the iterator is at
+	Event *ValidatorStarted
+
+	*EventIterator
+}
+
+// FilterStarted iterates over past logs of event Started().
+func (c *ValidatorContract) FilterStarted(opts *bind.FilterOpts) (*ValidatorStartedIterator, error) {
+	it := new(ValidatorStartedIterator)
+	var err error
+	it.EventIterator, err = c.BoundContract.FilterEvent(opts, "Started", func(log types.Log) error {
+		ev, err := c.ParseStarted(log)
+		it.Event = ev
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return it, nil
+}
+
+// WatchStarted sends new logs of event Started() to sink.
+func (c *ValidatorContract) WatchStarted(opts *bind.WatchOpts, sink chan<- *ValidatorStarted) (event.Subscription, error) {
+	return c.BoundContract.WatchEvent(opts, "Started", func(log types.Log, quit <-chan struct{}) error {
+		ev, err := c.ParseStarted(log)
+		if err != nil {
+			return err
+		}
+		select {
+		case sink <- ev:
+		case <-quit:
+		}
+		return nil
+	})
+}
+
+// ParseStarted unpacks a log of event Started().
+func (c *ValidatorContract) ParseStarted(log types.Log) (*ValidatorStarted, error) {
+	ev := new(ValidatorStarted)
+	if err := c.BoundContract.UnpackLog(ev, "Started", log); err != nil {
+		return nil, err
+	}
+	ev.Raw = log
+	return ev, nil
+}
+
+// ValidatorStopped is the Stopped event of ValidatorContract.
+type ValidatorStopped struct {
+	Raw types.Log
+}
+
+// ValidatorStoppedIterator iterates over the Stopped events returned by ValidatorContract.FilterStopped.
+type ValidatorStoppedIterator struct {
+	// Event is the event the iterator is at
+	Event *ValidatorStopped
+
+	*EventIterator
+}
+
+// FilterStopped iterates over past logs of event Stopped().
+func (c *ValidatorContract) FilterStopped(opts *bind.FilterOpts) (*ValidatorStoppedIterator, error) {
+	it := new(ValidatorStoppedIterator)
+	var err error
+	it.EventIterator, err = c.BoundContract.FilterEvent(opts, "Stopped", func(log types.Log) error {
+		ev, err := c.ParseStopped(log)
+		it.Event = ev
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return it, nil
+}
+
+// WatchStopped sends new logs of event Stopped() to sink.
+func (c *ValidatorContract) WatchStopped(opts *bind.WatchOpts, sink chan<- *ValidatorStopped) (event.Subscription, error) {
+	return c.BoundContract.WatchEvent(opts, "Stopped", func(log types.Log, quit <-chan struct{}) error {
+		ev, err := c.ParseStopped(log)
+		if err != nil {
+			return err
+		}
+		select {
+		case sink <- ev:
+		case <-quit:
+		}
+		return nil
+	})
+}
+
+// ParseStopped unpacks a log of event Stopped().
+func (c *ValidatorContract) ParseStopped(log types.Log) (*ValidatorStopped, error) {
+	ev := new(ValidatorStopped)
+	if err := c.BoundContract.UnpackLog(ev, "Stopped", log); err != nil {
+		return nil, err
+	}
+	ev.Raw = log
+	return ev, nil
+}
+
+// ValidatorUndelegate is the Undelegate event of ValidatorContract.
+type ValidatorUndelegate struct {
+	DelAddr        common.Address
+	Amount         *big.Int
+	CompletionTime *big.Int
+	Raw            types.Log
+}
+
+// ValidatorUndelegateIterator iterates over the Undelegate events returned by ValidatorContract.FilterUndelegate.
+type ValidatorUndelegateIterator struct {
+	// Event is the event the iterator is at
+	Event *ValidatorUndelegate
+
+	*EventIterator
+}
+
+// FilterUndelegate iterates over past logs of event Undelegate(address _delAddr, uint256 _amount, uint256 _completionTime).
+func (c *ValidatorContract) FilterUndelegate(opts *bind.FilterOpts) (*ValidatorUndelegateIterator, error) {
+	it := new(ValidatorUndelegateIterator)
+	var err error
+	it.EventIterator, err = c.BoundContract.FilterEvent(opts, "Undelegate", func(log types.Log) error {
+		ev, err := c.ParseUndelegate(log)
+		it.Event = ev
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return it, nil
+}
+
+// WatchUndelegate sends new logs of event Undelegate(address _delAddr, uint256 _amount, uint256 _completionTime) to sink.
+func (c *ValidatorContract) WatchUndelegate(opts *bind.WatchOpts, sink chan<- *ValidatorUndelegate) (event.Subscription, error) {
+	return c.BoundContract.WatchEvent(opts, "Undelegate", func(log types.Log, quit <-chan struct{}) error {
+		ev, err := c.ParseUndelegate(log)
+		if err != nil {
+			return err
+		}
+		select {
+		case sink <- ev:
+		case <-quit:
+		}
+		return nil
+	})
+}
+
+// ParseUndelegate unpacks a log of event Undelegate(address _delAddr, uint256 _amount, uint256 _completionTime).
+func (c *ValidatorContract) ParseUndelegate(log types.Log) (*ValidatorUndelegate, error) {
+	ev := new(ValidatorUndelegate)
+	if err := c.BoundContract.UnpackLog(ev, "Undelegate", log); err != nil {
+		return nil, err
+	}
+	ev.Raw = log
+	return ev, nil
+}
+
+// ValidatorUpdateCommissionRate is the UpdateCommissionRate event of ValidatorContract.
+type ValidatorUpdateCommissionRate struct {
+	CommissionRate *big.Int
+	Raw            types.Log
+}
+
+// ValidatorUpdateCommissionRateIterator iterates over the UpdateCommissionRate events returned by ValidatorContract.FilterUpdateCommissionRate.
+type ValidatorUpdateCommissionRateIterator struct {
+	// Event is the event the iterator is at
+	Event *ValidatorUpdateCommissionRate
+
+	*EventIterator
+}
+
+// FilterUpdateCommissionRate iterates over past logs of event UpdateCommissionRate(uint256 _commissionRate).
+func (c *ValidatorContract) FilterUpdateCommissionRate(opts *bind.FilterOpts) (*ValidatorUpdateCommissionRateIterator, error) {
+	it := new(ValidatorUpdateCommissionRateIterator)
+	var err error
+	it.EventIterator, err = c.BoundContract.FilterEvent(opts, "UpdateCommissionRate", func(log types.Log) error {
+		ev, err := c.ParseUpdateCommissionRate(log)
+		it.Event = ev
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return it, nil
+}
+
+// WatchUpdateCommissionRate sends new logs of event UpdateCommissionRate(uint256 _commissionRate) to sink.
+func (c *ValidatorContract) WatchUpdateCommissionRate(opts *bind.WatchOpts, sink chan<- *ValidatorUpdateCommissionRate) (event.Subscription, error) {
+	return c.BoundContract.WatchEvent(opts, "UpdateCommissionRate", func(log types.Log, quit <-chan struct{}) error {
+		ev, err := c.ParseUpdateCommissionRate(log)
+		if err != nil {
+			return err
+		}
+		select {
+		case sink <- ev:
+		case <-quit:
+		}
+		return nil
+	})
+}
+
+// ParseUpdateCommissionRate unpacks a log of event UpdateCommissionRate(uint256 _commissionRate).
+func (c *ValidatorContract) ParseUpdateCommissionRate(log types.Log) (*ValidatorUpdateCommissionRate, error) {
+	ev := new(ValidatorUpdateCommissionRate)
+	if err := c.BoundContract.UnpackLog(ev, "UpdateCommissionRate", log); err != nil {
+		return nil, err
+	}
+	ev.Raw = log
+	return ev, nil
+}
+
+// ValidatorUpdateName is the UpdateName event of ValidatorContract.
+type ValidatorUpdateName struct {
+	Name [32]byte
+	Raw  types.Log
+}
+
+// ValidatorUpdateNameIterator iterates over the UpdateName events returned by ValidatorContract.FilterUpdateName.
+type ValidatorUpdateNameIterator struct {
+	// Event is the event the iterator is at
+	Event *ValidatorUpdateName
+
+	*EventIterator
+}
+
+// FilterUpdateName iterates over past logs of event UpdateName(bytes32 _name).
+func (c *ValidatorContract) FilterUpdateName(opts *bind.FilterOpts) (*ValidatorUpdateNameIterator, error) {
+	it := new(ValidatorUpdateNameIterator)
+	var err error
+	it.EventIterator, err = c.BoundContract.FilterEvent(opts, "UpdateName", func(log types.Log) error {
+		ev, err := c.ParseUpdateName(log)
+		it.Event = ev
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return it, nil
+}
+
+// WatchUpdateName sends new logs of event UpdateName(bytes32 _name) to sink.
+func (c *ValidatorContract) WatchUpdateName(opts *bind.WatchOpts, sink chan<- *ValidatorUpdateName) (event.Subscription, error) {
+	return c.BoundContract.WatchEvent(opts, "UpdateName", func(log types.Log, quit <-chan struct{}) error {
+		ev, err := c.ParseUpdateName(log)
+		if err != nil {
+			return err
+		}
+		select {
+		case sink <- ev:
+		case <-quit:
+		}
+		return nil
+	})
+}
+
+// ParseUpdateName unpacks a log of event UpdateName(bytes32 _name).
+func (c *ValidatorContract) ParseUpdateName(log types.Log) (*ValidatorUpdateName, error) {
+	ev := new(ValidatorUpdateName)
+	if err := c.BoundContract.UnpackLog(ev, "UpdateName", log); err != nil {
+		return nil, err
+	}
+	ev.Raw = log
+	return ev, nil
+}
+
+// ValidatorUpdatedSigner is the UpdatedSigner event of ValidatorContract.
+type ValidatorUpdatedSigner struct {
+	PreviousSigner common.Address
+	NewSigner      common.Address
+	Raw            types.Log
+}
+
+// ValidatorUpdatedSignerIterator iterates over the UpdatedSigner events returned by ValidatorContract.FilterUpdatedSigner.
+type ValidatorUpdatedSignerIterator struct {
+	// Event is the event the iterator is at
+	Event *ValidatorUpdatedSigner
+
+	*EventIterator
+}
+
+// FilterUpdatedSigner iterates over past logs of event UpdatedSigner(address previousSigner, address newSigner).
+func (c *ValidatorContract) FilterUpdatedSigner(opts *bind.FilterOpts) (*ValidatorUpdatedSignerIterator, error) {
+	it := new(ValidatorUpdatedSignerIterator)
+	var err error
+	it.EventIterator, err = c.BoundContract.FilterEvent(opts, "UpdatedSigner", func(log types.Log) error {
+		ev, err := c.ParseUpdatedSigner(log)
+		it.Event = ev
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return it, nil
+}
+
+// WatchUpdatedSigner sends new logs of event UpdatedSigner(address previousSigner, address newSigner) to sink.
+func (c *ValidatorContract) WatchUpdatedSigner(opts *bind.WatchOpts, sink chan<- *ValidatorUpdatedSigner) (event.Subscription, error) {
+	return c.BoundContract.WatchEvent(opts, "UpdatedSigner", func(log types.Log, quit <-chan struct{}) error {
+		ev, err := c.ParseUpdatedSigner(log)
+		if err != nil {
+			return err
+		}
+		select {
+		case sink <- ev:
+		case <-quit:
+		}
+		return nil
+	})
+}
+
+// ParseUpdatedSigner unpacks a log of event UpdatedSigner(address previousSigner, address newSigner).
+func (c *ValidatorContract) ParseUpdatedSigner(log types.Log) (*ValidatorUpdatedSigner, error) {
+	ev := new(ValidatorUpdatedSigner)
+	if err := c.BoundContract.UnpackLog(ev, "UpdatedSigner", log); err != nil {
+		return nil, err
+	}
+	ev.Raw = log
+	return ev, nil
+}
+
+// ValidatorWithdraw is the Withdraw event of ValidatorContract.
+type ValidatorWithdraw struct {
+	DelAddr common.Address
+	Amount  *big.Int
+	Raw     types.Log
+}
+
+// ValidatorWithdrawIterator iterates over the Withdraw events returned by ValidatorContract.FilterWithdraw.
+type ValidatorWithdrawIterator struct {
+	// Event is the event the iterator is at
+	Event *ValidatorWithdraw
+
+	*EventIterator
+}
+
+// FilterWithdraw iterates over past logs of event Withdraw(address _delAddr, uint256 _amount).
+func (c *ValidatorContract) FilterWithdraw(opts *bind.FilterOpts) (*ValidatorWithdrawIterator, error) {
+	it := new(ValidatorWithdrawIterator)
+	var err error
+	it.EventIterator, err = c.BoundContract.FilterEvent(opts, "Withdraw", func(log types.Log) error {
+		ev, err := c.ParseWithdraw(log)
+		it.Event = ev
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return it, nil
+}
+
+// WatchWithdraw sends new logs of event Withdraw(address _delAddr, uint256 _amount) to sink.
+func (c *ValidatorContract) WatchWithdraw(opts *bind.WatchOpts, sink chan<- *ValidatorWithdraw) (event.Subscription, error) {
+	return c.BoundContract.WatchEvent(opts, "Withdraw", func(log types.Log, quit <-chan struct{}) error {
+		ev, err := c.ParseWithdraw(log)
+		if err != nil {
+			return err
+		}
+		select {
+		case sink <- ev:
+		case <-quit:
+		}
+		return nil
+	})
+}
+
+// ParseWithdraw unpacks a log of event Withdraw(address _delAddr, uint256 _amount).
+func (c *ValidatorContract) ParseWithdraw(log types.Log) (*ValidatorWithdraw, error) {
+	ev := new(ValidatorWithdraw)
+	if err := c.BoundContract.UnpackLog(ev, "Withdraw", log); err != nil {
+		return nil, err
+	}
+	ev.Raw = log
+	return ev, nil
+}
+
+// ValidatorWithdrawCommissionReward is the WithdrawCommissionReward event of ValidatorContract.
+type ValidatorWithdrawCommissionReward struct {
+	Rewards *big.Int
+	Raw     types.Log
+}
+
+// ValidatorWithdrawCommissionRewardIterator iterates over the WithdrawCommissionReward events returned by ValidatorContract.FilterWithdrawCommissionReward.
+type ValidatorWithdrawCommissionRewardIterator struct {
+	// Event is the event the iterator is at
+	Event *ValidatorWithdrawCommissionReward
+
+	*EventIterator
+}
+
+// FilterWithdrawCommissionReward iterates over past logs of event WithdrawCommissionReward(uint256 _rewards).
+func (c *ValidatorContract) FilterWithdrawCommissionReward(opts *bind.FilterOpts) (*ValidatorWithdrawCommissionRewardIterator, error) {
+	it := new(ValidatorWithdrawCommissionRewardIterator)
+	var err error
+	it.EventIterator, err = c.BoundContract.FilterEvent(opts, "WithdrawCommissionReward", func(log types.Log) error {
+		ev, err := c.ParseWithdrawCommissionReward(log)
+		it.Event = ev
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return it, nil
+}
+
+// WatchWithdrawCommissionReward sends new logs of event WithdrawCommissionReward(uint256 _rewards) to sink.
+func (c *ValidatorContract) WatchWithdrawCommissionReward(opts *bind.WatchOpts, sink chan<- *ValidatorWithdrawCommissionReward) (event.Subscription, error) {
+	return c.BoundContract.WatchEvent(opts, "WithdrawCommissionReward", func(log types.Log, quit <-chan struct{}) error {
+		ev, err := c.ParseWithdrawCommissionReward(log)
+		if err != nil {
+			return err
+		}
+		select {
+		case sink <- ev:
+		case <-quit:
+		}
+		return nil
+	})
+}
+
+// ParseWithdrawCommissionReward unpacks a log of event WithdrawCommissionReward(uint256 _rewards).
+func (c *ValidatorContract) ParseWithdrawCommissionReward(log types.Log) (*ValidatorWithdrawCommissionReward, error) {
+	ev := new(ValidatorWithdrawCommissionReward)
+	if err := c.BoundContract.UnpackLog(ev, "WithdrawCommissionReward", log); err != nil {
+		return nil, err
+	}
+	ev.Raw = log
+	return ev, nil
+}
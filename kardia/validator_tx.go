@@ -0,0 +1,150 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package kardia
+package kardia
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/kardiachain/go-kardia/lib/abi/bind"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"go.uber.org/zap"
+)
+
+// commissionChangeInterval is the minimum time between two commission rate changes of a validator
+const commissionChangeInterval = 24 * time.Hour
+
+// IValidatorTx sends the transactions of validator operators. Like IStakingTx, every transaction is executed
+// through KardiaCall before being sent, so a violated contract rule is returned as a *RevertError without spending gas.
+// Errors of a sent transaction are returned as a *TxSentError, the transaction must not be sent again.
+type IValidatorTx interface {
+	// CreateValidator creates a validator signed by opts.From in the staking contract, and returns its contract address
+	CreateValidator(ctx context.Context, opts *bind.TransactOpts, args CreateValidatorArgs) (common.Address, *StakingReceipt, error)
+	// UpdateCommissionRate changes the commission rate to a positive rate, at most once a day, within the max rate
+	// and max change rate. The day is checked against the time of the latest block.
+	UpdateCommissionRate(ctx context.Context, opts *bind.TransactOpts, validatorSMCAddress common.Address, rate *big.Int) (*StakingReceipt, error)
+	// UpdateName renames the validator, paying the name change fee of the params contract
+	UpdateName(ctx context.Context, opts *bind.TransactOpts, validatorSMCAddress common.Address, name string) (*StakingReceipt, error)
+	// UpdateSigner moves the validator to the signer address
+	UpdateSigner(ctx context.Context, opts *bind.TransactOpts, validatorSMCAddress common.Address, signer common.Address) (*StakingReceipt, error)
+	// Start adds the validator to the validator set candidates. There is no stop, the staking contract stops the
+	// validator it replaces in the validator set.
+	Start(ctx context.Context, opts *bind.TransactOpts, validatorSMCAddress common.Address) (*StakingReceipt, error)
+	// Unjail unjails the validator once its jail time is over
+	Unjail(ctx context.Context, opts *bind.TransactOpts, validatorSMCAddress common.Address) (*StakingReceipt, error)
+}
+
+// CreateValidatorArgs are the arguments of CreateValidator. Rates are in 1e18 units, 1e18 is 100%.
+type CreateValidatorArgs struct {
+	Name           string
+	Rate           *big.Int
+	MaxRate        *big.Int
+	MaxChangeRate  *big.Int
+	SelfDelegation *big.Int
+}
+
+func (n *node) CreateValidator(ctx context.Context, opts *bind.TransactOpts, args CreateValidatorArgs) (common.Address, *StakingReceipt, error) {
+	name, err := validatorName(args.Name)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	staking := NewBoundContract(n, n.stakingSMC.Abi, n.stakingSMC.ContractAddress)
+	receipt, err := n.sendStakingTx(ctx, opts, staking, args.SelfDelegation, "createValidator", name, args.Rate, args.MaxRate, args.MaxChangeRate)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	// the validator is created, Nodes must not fail over and create a second one
+	address, err := n.SMCAddressOfValidator(ctx, opts.From.Hex())
+	if err != nil {
+		return common.Address{}, nil, &TxSentError{Hash: common.HexToHash(receipt.TransactionHash), Err: err}
+	}
+	c, err := NewValidatorContract(n, address)
+	if err != nil {
+		return common.Address{}, nil, &TxSentError{Hash: common.HexToHash(receipt.TransactionHash), Err: err}
+	}
+	result, err := decodeStakingReceipt(c, receipt)
+	if err != nil {
+		return common.Address{}, nil, &TxSentError{Hash: common.HexToHash(receipt.TransactionHash), Err: err}
+	}
+	return address, result, nil
+}
+
+func (n *node) UpdateCommissionRate(ctx context.Context, opts *bind.TransactOpts, validatorSMCAddress common.Address, rate *big.Int) (*StakingReceipt, error) {
+	// the contract ignores a zero rate
+	if rate == nil || rate.Sign() <= 0 {
+		return nil, ErrInvalidCommissionRate
+	}
+	validators, err := n.loadValidators(ctx, n.lgr.With(zap.String("method", "UpdateCommissionRate")), []common.Address{validatorSMCAddress})
+	if err != nil {
+		return nil, err
+	}
+	validator, commission := validators[0], validators[0].Commission
+	header, err := n.selectedHeader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// the next block is not older than the latest one
+	if next := time.Unix(validator.UpdateTime.Int64(), 0).Add(commissionChangeInterval); header.Time.Before(next) {
+		return nil, fmt.Errorf("%w: next change at %s", ErrCommissionChangeTooSoon, next.UTC())
+	}
+	if rate.Cmp(commission.MaxRate) > 0 {
+		return nil, fmt.Errorf("%w: %s > %s", ErrCommissionAboveMaxRate, rate, commission.MaxRate)
+	}
+	// decreases are not limited
+	if change := new(big.Int).Sub(rate, commission.Rate); change.Cmp(commission.MaxChangeRate) > 0 {
+		return nil, fmt.Errorf("%w: %s > %s", ErrCommissionChangeTooLarge, change, commission.MaxChangeRate)
+	}
+	return n.validatorTx(ctx, opts, validatorSMCAddress, nil, "updateCommissionRate", rate)
+}
+
+func (n *node) UpdateName(ctx context.Context, opts *bind.TransactOpts, validatorSMCAddress common.Address, name string) (*StakingReceipt, error) {
+	validatorName, err := validatorName(name)
+	if err != nil {
+		return nil, err
+	}
+	fee, err := n.paramsValue(ctx, "getMinAmountChangeName")
+	if err != nil {
+		return nil, err
+	}
+	return n.validatorTx(ctx, opts, validatorSMCAddress, fee, "updateName", validatorName)
+}
+
+func (n *node) UpdateSigner(ctx context.Context, opts *bind.TransactOpts, validatorSMCAddress common.Address, signer common.Address) (*StakingReceipt, error) {
+	return n.validatorTx(ctx, opts, validatorSMCAddress, nil, "updateSigner", signer)
+}
+
+func (n *node) Start(ctx context.Context, opts *bind.TransactOpts, validatorSMCAddress common.Address) (*StakingReceipt, error) {
+	return n.validatorTx(ctx, opts, validatorSMCAddress, nil, "start")
+}
+
+func (n *node) Unjail(ctx context.Context, opts *bind.TransactOpts, validatorSMCAddress common.Address) (*StakingReceipt, error) {
+	return n.validatorTx(ctx, opts, validatorSMCAddress, nil, "unjail")
+}
+
+// validatorName returns name as the bytes32 name of a validator
+func validatorName(name string) ([32]byte, error) {
+	var result [32]byte
+	if len(name) > len(result) {
+		return result, fmt.Errorf("%w: %q", ErrNameTooLong, name)
+	}
+	copy(result[:], name)
+	return result, nil
+}
@@ -0,0 +1,141 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package kardia
+package kardia
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNode_CreateValidator(t *testing.T) {
+	b, node, _, operator, _ := setupSimulatedStaking(t)
+	defer b.Close()
+	ctx := context.Background()
+
+	args := CreateValidatorArgs{
+		Name:           "operator",
+		Rate:           FloatToBigInt(0.05, 18),
+		MaxRate:        FloatToBigInt(0.2, 18),
+		MaxChangeRate:  FloatToBigInt(0.01, 18),
+		SelfDelegation: FloatToBigInt(1000, 18),
+	}
+	// rule violations are reported by the simulation, without sending a transaction
+	_, _, err := node.CreateValidator(ctx, operator, args)
+	var revert *RevertError
+	assert.True(t, errors.As(err, &revert))
+	assert.Equal(t, "self delegation below minimum", revert.Reason)
+	nonce, err := node.NonceAt(ctx, operator.From.Hex())
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0), nonce)
+	args.Name = strings.Repeat("x", 33)
+	_, _, err = node.CreateValidator(ctx, operator, args)
+	assert.True(t, errors.Is(err, ErrNameTooLong))
+
+	args.Name = "operator"
+	args.SelfDelegation = FloatToBigInt(13000000, 18)
+	validator, receipt, err := node.CreateValidator(ctx, operator, args)
+	assert.Nil(t, err)
+	assert.NotEqual(t, common.Address{}, validator)
+	assert.Equal(t, 1, len(receipt.Delegates))
+	assert.Equal(t, operator.From, receipt.Delegates[0].DelAddr)
+	info, err := node.ValidatorInfo(ctx, validator.Hex())
+	assert.Nil(t, err)
+	assert.Equal(t, operator.From, info.Signer)
+	assert.Equal(t, "operator", strings.TrimRight(string(info.Name[:]), "\x00"))
+
+	receipt, err = node.Start(ctx, operator, validator)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(receipt.Started))
+	_, err = node.Start(ctx, operator, validator)
+	assert.True(t, errors.As(err, &revert))
+	assert.Equal(t, "validator bonded", revert.Reason)
+	_, err = node.Unjail(ctx, operator, validator)
+	assert.True(t, errors.As(err, &revert))
+	assert.Equal(t, "validator not jailed", revert.Reason)
+}
+
+func TestNode_UpdateValidator(t *testing.T) {
+	b, node, auth, delegator, validator := setupSimulatedStaking(t)
+	defer b.Close()
+	ctx := context.Background()
+
+	// the genesis validator has a 10% rate, 25% max rate and 5% max change rate, set at genesis
+	for _, rate := range []*big.Int{nil, big.NewInt(0), big.NewInt(-1)} {
+		_, err := node.UpdateCommissionRate(ctx, auth, validator, rate)
+		assert.Equal(t, ErrInvalidCommissionRate, err)
+	}
+	_, err := node.UpdateCommissionRate(ctx, auth, validator, FloatToBigInt(0.12, 18))
+	assert.True(t, errors.Is(err, ErrCommissionChangeTooSoon))
+
+	assert.Nil(t, b.AdjustTime(25*time.Hour))
+	_, err = b.Commit()
+	assert.Nil(t, err)
+	_, err = node.UpdateCommissionRate(ctx, auth, validator, FloatToBigInt(0.3, 18))
+	assert.True(t, errors.Is(err, ErrCommissionAboveMaxRate))
+	_, err = node.UpdateCommissionRate(ctx, auth, validator, FloatToBigInt(0.2, 18))
+	assert.True(t, errors.Is(err, ErrCommissionChangeTooLarge))
+	_, err = node.UpdateCommissionRate(ctx, delegator, validator, FloatToBigInt(0.12, 18))
+	var revert *RevertError
+	assert.True(t, errors.As(err, &revert))
+	assert.Equal(t, "Ownable: caller is not the validator", revert.Reason)
+	receipt, err := node.UpdateCommissionRate(ctx, auth, validator, FloatToBigInt(0.12, 18))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(receipt.CommissionRateUpdates))
+	assert.Equal(t, FloatToBigInt(0.12, 18), receipt.CommissionRateUpdates[0].CommissionRate)
+	commission, err := node.ValidatorCommission(ctx, validator.Hex())
+	assert.Nil(t, err)
+	assert.Equal(t, FloatToBigInt(0.12, 18), commission.Rate)
+	_, err = node.UpdateCommissionRate(ctx, auth, validator, FloatToBigInt(0.11, 18))
+	assert.True(t, errors.Is(err, ErrCommissionChangeTooSoon))
+
+	receipt, err = node.UpdateName(ctx, auth, validator, "renamed")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(receipt.NameUpdates))
+	assert.Equal(t, "renamed", strings.TrimRight(string(receipt.NameUpdates[0].Name[:]), "\x00"))
+	// the 10K KAI fee is sent with the transaction
+	tx, err := node.GetTransaction(ctx, receipt.TransactionHash)
+	assert.Nil(t, err)
+	assert.Equal(t, FloatToBigInt(10000, 18).String(), tx.Value)
+
+	// a new signer of a bonded validator takes effect with the validator set, move an unbonded one
+	validator, _, err = node.CreateValidator(ctx, delegator, CreateValidatorArgs{
+		Name:           "unbonded",
+		Rate:           FloatToBigInt(0.05, 18),
+		MaxRate:        FloatToBigInt(0.2, 18),
+		MaxChangeRate:  FloatToBigInt(0.01, 18),
+		SelfDelegation: FloatToBigInt(25000, 18),
+	})
+	assert.Nil(t, err)
+	signer := common.HexToAddress("0x0000000000000000000000000000000000c0ffee")
+	receipt, err = node.UpdateSigner(ctx, delegator, validator, signer)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(receipt.SignerUpdates))
+	assert.Equal(t, delegator.From, receipt.SignerUpdates[0].PreviousSigner)
+	assert.Equal(t, signer, receipt.SignerUpdates[0].NewSigner)
+	info, err := node.ValidatorInfo(ctx, validator.Hex())
+	assert.Nil(t, err)
+	assert.Equal(t, signer, info.Signer)
+}
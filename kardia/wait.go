@@ -0,0 +1,149 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package kardia
+package kardia
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kardiachain/go-kardia"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/types"
+	"go.uber.org/zap"
+)
+
+const (
+	ReceiptStatusFailed     uint = 0
+	ReceiptStatusSuccessful uint = 1
+)
+
+// receiptPollInterval is how often receipts are polled when the node cannot push new heads
+var receiptPollInterval = time.Second
+
+// TxFailedError is returned by WaitMined and WaitConfirmed when the transaction was mined
+// but its execution failed
+type TxFailedError struct {
+	Receipt *Receipt
+}
+
+func (e *TxFailedError) Error() string {
+	return fmt.Sprintf("transaction %s failed at block %d", e.Receipt.TransactionHash, e.Receipt.BlockHeight)
+}
+
+// TxSentError is returned when a transaction was sent but its receipt could not be read.
+// The transaction must be waited for by Hash, sending it again would execute it twice.
+type TxSentError struct {
+	Hash common.Hash
+	Err  error
+}
+
+func (e *TxSentError) Error() string {
+	return fmt.Sprintf("transaction %s sent, waiting for its receipt failed: %v", e.Hash.Hex(), e.Err)
+}
+
+func (e *TxSentError) Unwrap() error {
+	return e.Err
+}
+
+// WaitMined waits until the transaction is mined and returns its receipt.
+// A *TxFailedError is returned with the receipt if the execution failed.
+func (n *node) WaitMined(ctx context.Context, txHash string) (*Receipt, error) {
+	return n.WaitConfirmed(ctx, txHash, 0)
+}
+
+// WaitConfirmed waits until the transaction is mined and confirmations blocks are
+// produced on top of its block, then returns its receipt.
+// A *TxFailedError is returned with the receipt if the execution failed.
+func (n *node) WaitConfirmed(ctx context.Context, txHash string, confirmations uint64) (*Receipt, error) {
+	var receipt *Receipt
+	err := n.waitHeads(ctx, func(head uint64) (bool, error) {
+		if receipt == nil {
+			r, err := n.GetTransactionReceipt(ctx, txHash)
+			if errors.Is(err, kardia.NotFound) {
+				return false, nil
+			}
+			if err != nil {
+				return false, err
+			}
+			receipt = r
+		}
+		return head >= receipt.BlockHeight+confirmations, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status != ReceiptStatusSuccessful {
+		return receipt, &TxFailedError{Receipt: receipt}
+	}
+	return receipt, nil
+}
+
+// waitHeads calls done with the current head then on every new head until it returns true.
+// New heads are pushed by the node when it supports subscriptions, polled otherwise.
+func (n *node) waitHeads(ctx context.Context, done func(head uint64) (bool, error)) error {
+	head, err := n.LatestBlockNumber(ctx)
+	if err != nil {
+		return err
+	}
+	if ok, err := done(head); ok || err != nil {
+		return err
+	}
+
+	headers := make(chan *types.Header)
+	sub, err := n.client.Subscribe(ctx, "kai", headers, "newHeads")
+	if err != nil {
+		n.lgr.Debug("Cannot subscribe new heads, polling", zap.Error(err))
+		return n.pollHeads(ctx, done)
+	}
+	defer sub.Unsubscribe()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-sub.Err():
+			n.lgr.Warn("New heads subscription dropped, polling", zap.Error(err))
+			return n.pollHeads(ctx, done)
+		case header := <-headers:
+			if ok, err := done(header.Height); ok || err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (n *node) pollHeads(ctx context.Context, done func(head uint64) (bool, error)) error {
+	ticker := time.NewTicker(receiptPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			head, err := n.LatestBlockNumber(ctx)
+			if err != nil {
+				return err
+			}
+			if ok, err := done(head); ok || err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,174 @@
+/*
+ *  Copyright 2020 KardiaChain
+ *  This file is part of the go-kardia library.
+ *
+ *  The go-kardia library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Lesser General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The go-kardia library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ *  GNU Lesser General Public License for more details.
+ *
+ *  You should have received a copy of the GNU Lesser General Public License
+ *  along with the go-kardia library. If not, see <http://www.gnu.org/licenses/>.
+ */
+// Package kardia
+package kardia
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/kardiachain/go-kardia/rpc"
+	"github.com/kardiachain/go-kardia/types"
+	"github.com/stretchr/testify/assert"
+)
+
+const testTxHash = "0x7bd6b412dcce2de672a3932f4d0b92a3fad639b1f475be846f7c5302e8d555e4"
+
+// mockReceiptChain serves kai_blockNumber and tx_getTransactionReceipt, a block is mined
+// every tick and testTxHash is included in block includeAt
+type mockReceiptChain struct {
+	mu        sync.Mutex
+	head      uint64
+	includeAt uint64
+	status    uint
+	subs      map[rpc.ID]chan *types.Header
+}
+
+func newMockReceiptChain(head, includeAt uint64, status uint) *mockReceiptChain {
+	return &mockReceiptChain{head: head, includeAt: includeAt, status: status, subs: make(map[rpc.ID]chan *types.Header)}
+}
+
+func (api *mockReceiptChain) BlockNumber() uint64 {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	return api.head
+}
+
+func (api *mockReceiptChain) GetTransactionReceipt(hash common.Hash) *Receipt {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	if hash != common.HexToHash(testTxHash) || api.head < api.includeAt {
+		return nil
+	}
+	return &Receipt{TransactionHash: testTxHash, BlockHeight: api.includeAt, Status: api.status}
+}
+
+// mine produces a block every tick until ctx is done
+func (api *mockReceiptChain) mine(ctx context.Context, tick time.Duration) {
+	go func() {
+		ticker := time.NewTicker(tick)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				api.mu.Lock()
+				api.head++
+				for _, ch := range api.subs {
+					select {
+					case ch <- &types.Header{Height: api.head}:
+					default:
+					}
+				}
+				api.mu.Unlock()
+			}
+		}
+	}()
+}
+
+// mockReceiptChainWS also pushes new heads
+type mockReceiptChainWS struct {
+	*mockReceiptChain
+}
+
+func (api *mockReceiptChainWS) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	rpcSub := notifier.CreateSubscription()
+	headers := make(chan *types.Header, 10)
+	api.mu.Lock()
+	api.subs[rpcSub.ID] = headers
+	api.mu.Unlock()
+	go func() {
+		defer func() {
+			api.mu.Lock()
+			delete(api.subs, rpcSub.ID)
+			api.mu.Unlock()
+		}()
+		for {
+			select {
+			case header := <-headers:
+				_ = notifier.Notify(rpcSub.ID, header)
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
+
+func TestWait_WaitMinedPolling(t *testing.T) {
+	receiptPollInterval = 10 * time.Millisecond
+	defer func() { receiptPollInterval = time.Second }()
+	api := newMockReceiptChain(10, 13, ReceiptStatusSuccessful)
+	n, err := setupMockNodeInstance(map[string]interface{}{"kai": api, "tx": api})
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	api.mine(ctx, 10*time.Millisecond)
+	receipt, err := n.WaitMined(ctx, testTxHash)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(13), receipt.BlockHeight)
+}
+
+func TestWait_WaitConfirmedSubscription(t *testing.T) {
+	api := newMockReceiptChain(10, 12, ReceiptStatusSuccessful)
+	n, _, err := setupMockWSNodeInstance(map[string]interface{}{"kai": &mockReceiptChainWS{api}, "tx": api})
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	api.mine(ctx, 10*time.Millisecond)
+	receipt, err := n.WaitConfirmed(ctx, testTxHash, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(12), receipt.BlockHeight)
+	assert.GreaterOrEqual(t, api.BlockNumber(), uint64(15))
+}
+
+func TestWait_WaitMinedFailed(t *testing.T) {
+	api := newMockReceiptChain(10, 10, ReceiptStatusFailed)
+	n, err := setupMockNodeInstance(map[string]interface{}{"kai": api, "tx": api})
+	assert.Nil(t, err)
+
+	receipt, err := n.WaitMined(context.Background(), testTxHash)
+	var txFailure *TxFailedError
+	assert.True(t, errors.As(err, &txFailure))
+	assert.Equal(t, receipt, txFailure.Receipt)
+	assert.Equal(t, ReceiptStatusFailed, receipt.Status)
+}
+
+func TestWait_WaitMinedCancel(t *testing.T) {
+	api := newMockReceiptChain(10, 100, ReceiptStatusSuccessful)
+	n, err := setupMockNodeInstance(map[string]interface{}{"kai": api, "tx": api})
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = n.WaitMined(ctx, testTxHash)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}